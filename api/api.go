@@ -0,0 +1,46 @@
+// Package api встраивает спецификацию OpenAPI и страницу документации в бинарник.
+package api
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Spec спецификация api/openapi.yaml, по которой сгенерированы DTO.
+//
+//go:embed openapi.yaml
+var Spec []byte
+
+//go:embed docs
+var docs embed.FS
+
+// Docs статические файлы страницы документации. Страница не использует CDN
+// и загружает спецификацию с того же сервера, поэтому работает без интернета.
+var Docs = mustSub(docs, "docs")
+
+// SpecJSON возвращает спецификацию в формате JSON.
+func SpecJSON() ([]byte, error) {
+	var spec any
+	if err := yaml.Unmarshal(Spec, &spec); err != nil {
+		return nil, fmt.Errorf("unmarshal spec: %w", err)
+	}
+
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return nil, fmt.Errorf("marshal spec: %w", err)
+	}
+
+	return data, nil
+}
+
+func mustSub(fsys fs.FS, dir string) fs.FS {
+	sub, err := fs.Sub(fsys, dir)
+	if err != nil {
+		panic(err)
+	}
+	return sub
+}
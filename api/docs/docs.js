@@ -0,0 +1,292 @@
+// Страница документации: читает спецификацию с сервиса, выводит операции по тегам
+// и позволяет отправить запрос прямо из браузера. Внешних зависимостей нет.
+(function () {
+  "use strict";
+
+  const METHODS = ["get", "post", "put", "patch", "delete", "head"];
+
+  function el(tag, attrs, children) {
+    const node = document.createElement(tag);
+    for (const [key, value] of Object.entries(attrs || {})) {
+      if (key === "class") {
+        node.className = value;
+      } else {
+        node.setAttribute(key, value);
+      }
+    }
+    for (const child of [].concat(children || [])) {
+      node.append(child instanceof Node ? child : String(child));
+    }
+    return node;
+  }
+
+  function resolve(spec, value) {
+    let seen = 0;
+    while (value && value.$ref && seen < 32) {
+      value = value.$ref
+        .replace(/^#\//, "")
+        .split("/")
+        .reduce((node, part) => node && node[part.replace(/~1/g, "/").replace(/~0/g, "~")], spec);
+      seen++;
+    }
+    return value;
+  }
+
+  // sample строит пример значения по схеме, если в спецификации нет готового примера.
+  function sample(spec, schema, depth) {
+    schema = resolve(spec, schema);
+    if (!schema || depth > 8) {
+      return null;
+    }
+    if (schema.example !== undefined) {
+      return schema.example;
+    }
+    if (schema.enum) {
+      return schema.enum[0];
+    }
+    if (schema.allOf) {
+      return Object.assign({}, ...schema.allOf.map((s) => sample(spec, s, depth + 1)));
+    }
+    if (schema.oneOf || schema.anyOf) {
+      return sample(spec, (schema.oneOf || schema.anyOf)[0], depth + 1);
+    }
+    switch (schema.type) {
+      case "object": {
+        const result = {};
+        for (const [name, property] of Object.entries(schema.properties || {})) {
+          result[name] = sample(spec, property, depth + 1);
+        }
+        return result;
+      }
+      case "array":
+        return [sample(spec, schema.items, depth + 1)];
+      case "integer":
+      case "number":
+        return schema.minimum !== undefined ? schema.minimum : 0;
+      case "boolean":
+        return true;
+      case "string":
+        if (schema.format === "uuid") {
+          return "550e8400-e29b-41d4-a716-446655440000";
+        }
+        if (schema.format === "date-time") {
+          return new Date(0).toISOString();
+        }
+        return "string";
+      default:
+        return null;
+    }
+  }
+
+  function example(spec, media) {
+    if (!media) {
+      return undefined;
+    }
+    if (media.example !== undefined) {
+      return media.example;
+    }
+    const examples = Object.values(media.examples || {});
+    if (examples.length > 0) {
+      return resolve(spec, examples[0]).value;
+    }
+    return sample(spec, media.schema, 0);
+  }
+
+  function credentials() {
+    const headers = {};
+    const apiKey = document.getElementById("api-key").value.trim();
+    const token = document.getElementById("bearer-token").value.trim();
+    const orgID = document.getElementById("org-id").value.trim();
+    if (apiKey) {
+      headers["X-API-Key"] = apiKey;
+    }
+    if (token) {
+      headers["Authorization"] = "Bearer " + token;
+    }
+    if (orgID) {
+      headers["X-Org-ID"] = orgID;
+    }
+    return headers;
+  }
+
+  function renderParameters(spec, parameters) {
+    const inputs = [];
+    if (parameters.length === 0) {
+      return { node: null, inputs };
+    }
+
+    const rows = parameters.map((parameter) => {
+      const input = el("input", { type: "text", placeholder: parameter.schema ? parameter.schema.format || parameter.schema.type || "" : "" });
+      inputs.push({ parameter, input });
+      return el("tr", {}, [
+        el("td", {}, [
+          el("code", {}, parameter.name),
+          parameter.required ? el("span", { class: "required" }, " *") : "",
+        ]),
+        el("td", {}, parameter.in),
+        el("td", {}, parameter.description || ""),
+        el("td", {}, input),
+      ]);
+    });
+
+    const table = el("table", {}, [
+      el("thead", {}, el("tr", {}, ["Имя", "Где", "Описание", "Значение"].map((h) => el("th", {}, h)))),
+      el("tbody", {}, rows),
+    ]);
+    return { node: el("div", {}, [el("h4", {}, "Параметры"), table]), inputs };
+  }
+
+  function renderResponses(spec, responses) {
+    const rows = Object.entries(responses || {}).map(([code, response]) => {
+      response = resolve(spec, response) || {};
+      const media = response.content && response.content["application/json"];
+      const body = example(spec, media);
+      return el("tr", {}, [
+        el("td", {}, el("code", {}, code)),
+        el("td", {}, [
+          response.description || "",
+          body !== undefined ? el("pre", {}, JSON.stringify(body, null, 2)) : "",
+        ]),
+      ]);
+    });
+    return el("div", {}, [
+      el("h4", {}, "Ответы"),
+      el("table", {}, [
+        el("thead", {}, el("tr", {}, [el("th", {}, "Код"), el("th", {}, "Описание")])),
+        el("tbody", {}, rows),
+      ]),
+    ]);
+  }
+
+  async function send(method, path, inputs, bodyInput, result, button) {
+    const headers = credentials();
+    const query = new URLSearchParams();
+    for (const { parameter, input } of inputs) {
+      const value = input.value;
+      if (value === "") {
+        continue;
+      }
+      if (parameter.in === "query") {
+        query.append(parameter.name, value);
+      } else if (parameter.in === "header") {
+        headers[parameter.name] = value;
+      } else if (parameter.in === "path") {
+        path = path.replace("{" + parameter.name + "}", encodeURIComponent(value));
+      }
+    }
+
+    const init = { method: method.toUpperCase(), headers };
+    if (bodyInput) {
+      headers["Content-Type"] = "application/json";
+      init.body = bodyInput.value;
+    }
+
+    const url = path + (query.toString() ? "?" + query.toString() : "");
+    button.disabled = true;
+    result.replaceChildren("Отправка…");
+    try {
+      const response = await fetch(url, init);
+      const text = await response.text();
+      let pretty = text;
+      try {
+        pretty = JSON.stringify(JSON.parse(text), null, 2);
+      } catch (e) {
+        // Тело не JSON, показываем как есть.
+      }
+      const statusClass = response.ok ? "status-ok" : "status-error";
+      result.replaceChildren(
+        el("h4", {}, ["Ответ ", el("span", { class: statusClass }, response.status + " " + response.statusText)]),
+        el("pre", {}, Array.from(response.headers.entries()).map(([k, v]) => k + ": " + v).join("\n")),
+        el("pre", {}, pretty),
+      );
+    } catch (e) {
+      result.replaceChildren(el("p", { class: "error" }, "Запрос не выполнен: " + e.message));
+    } finally {
+      button.disabled = false;
+    }
+  }
+
+  function renderOperation(spec, path, method, operation, pathParameters) {
+    const parameters = pathParameters
+      .concat(operation.parameters || [])
+      .map((parameter) => resolve(spec, parameter));
+
+    const body = el("div", { class: "operation-body" });
+    if (operation.description) {
+      body.append(el("p", { class: "description" }, operation.description));
+    }
+
+    const { node: parametersNode, inputs } = renderParameters(spec, parameters);
+    if (parametersNode) {
+      body.append(parametersNode);
+    }
+
+    let bodyInput = null;
+    const requestBody = resolve(spec, operation.requestBody);
+    if (requestBody && requestBody.content && requestBody.content["application/json"]) {
+      const value = example(spec, requestBody.content["application/json"]);
+      bodyInput = el("textarea", { spellcheck: "false" });
+      bodyInput.value = JSON.stringify(value, null, 2);
+      body.append(el("h4", {}, "Тело запроса"), bodyInput);
+    }
+
+    body.append(renderResponses(spec, operation.responses));
+
+    const result = el("div", {});
+    const button = el("button", { type: "button" }, "Отправить");
+    button.addEventListener("click", () => send(method, path, inputs, bodyInput, result, button));
+    body.append(button, result);
+
+    return el("details", { class: "operation" }, [
+      el("summary", {}, [
+        el("span", { class: "method " + method }, method),
+        el("span", { class: "path" }, path),
+        el("span", { class: "summary" }, operation.summary || ""),
+      ]),
+      body,
+    ]);
+  }
+
+  function render(spec) {
+    document.getElementById("title").textContent = spec.info.title;
+    document.getElementById("version").textContent = "Версия " + spec.info.version;
+    document.title = spec.info.title;
+
+    const groups = new Map((spec.tags || []).map((tag) => [tag.name, []]));
+    for (const [path, item] of Object.entries(spec.paths || {})) {
+      for (const method of METHODS) {
+        const operation = item[method];
+        if (!operation) {
+          continue;
+        }
+        const tag = (operation.tags && operation.tags[0]) || "default";
+        if (!groups.has(tag)) {
+          groups.set(tag, []);
+        }
+        groups.get(tag).push(renderOperation(spec, path, method, operation, item.parameters || []));
+      }
+    }
+
+    const container = document.getElementById("operations");
+    container.replaceChildren();
+    for (const [tag, operations] of groups) {
+      if (operations.length > 0) {
+        container.append(el("section", {}, [el("h2", {}, tag)].concat(operations)));
+      }
+    }
+  }
+
+  fetch("../openapi.json")
+    .then((response) => {
+      if (!response.ok) {
+        throw new Error("HTTP " + response.status);
+      }
+      return response.json();
+    })
+    .then(render)
+    .catch((e) => {
+      document.getElementById("operations").replaceChildren(
+        el("p", { class: "error" }, "Не удалось загрузить спецификацию: " + e.message),
+      );
+    });
+})();
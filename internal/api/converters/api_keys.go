@@ -0,0 +1,40 @@
+package converters
+
+import (
+	"service-pr-reviewer-assignment/internal/generated/api/dto"
+	"service-pr-reviewer-assignment/internal/service/entities"
+)
+
+func ScopesFromDTO(scopes []dto.APIKeyScope) []entities.Scope {
+	out := make([]entities.Scope, 0, len(scopes))
+	for _, scope := range scopes {
+		out = append(out, entities.Scope(scope))
+	}
+	return out
+}
+
+func APIKeyToDTO(key *entities.APIKey) dto.APIKey {
+	scopes := make([]dto.APIKeyScope, 0, len(key.Scopes))
+	for _, scope := range key.Scopes {
+		scopes = append(scopes, dto.APIKeyScope(scope))
+	}
+
+	return dto.APIKey{
+		Id:        key.ID,
+		Name:      key.Name,
+		Scopes:    scopes,
+		CreatedAt: key.CreatedAt,
+		RevokedAt: key.RevokedAt,
+	}
+}
+
+func APIKeysToDTO(keys []entities.APIKey) dto.APIKeyListResponse {
+	out := make([]dto.APIKey, 0, len(keys))
+	for i := range keys {
+		out = append(out, APIKeyToDTO(&keys[i]))
+	}
+
+	return dto.APIKeyListResponse{
+		ApiKeys: out,
+	}
+}
@@ -0,0 +1,26 @@
+package converters
+
+import (
+	"service-pr-reviewer-assignment/internal/generated/api/dto"
+	"service-pr-reviewer-assignment/internal/service/entities"
+)
+
+func EventToDTO(event *entities.Event) dto.Event {
+	result := dto.Event{
+		Id:            event.ID,
+		Type:          dto.EventType(event.Type),
+		TeamName:      event.TeamName,
+		PullRequestId: event.PullRequestID,
+		AuthorId:      event.AuthorID,
+		OldReviewerId: event.OldReviewerID,
+		NewReviewerId: event.NewReviewerID,
+		UserId:        event.UserID,
+		IsActive:      event.IsActive,
+		CreatedAt:     event.CreatedAt,
+	}
+	if event.ReviewerIDs != nil {
+		result.ReviewerIds = &event.ReviewerIDs
+	}
+
+	return result
+}
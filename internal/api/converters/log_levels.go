@@ -0,0 +1,49 @@
+package converters
+
+import (
+	"log/slog"
+
+	"service-pr-reviewer-assignment/internal/generated/api/dto"
+)
+
+func LogLevelsToDTO(level slog.Level, packageLevels map[string]slog.Level) dto.LogLevels {
+	packages := make(map[string]dto.LogLevel, len(packageLevels))
+	for pkg, packageLevel := range packageLevels {
+		packages[pkg] = logLevelToDTO(packageLevel)
+	}
+
+	return dto.LogLevels{
+		Level:    logLevelToDTO(level),
+		Packages: packages,
+	}
+}
+
+// LogLevelFromDTO возвращает false для уровня, которого нет в спецификации.
+func LogLevelFromDTO(level dto.LogLevel) (slog.Level, bool) {
+	switch level {
+	case dto.LogLevelDebug:
+		return slog.LevelDebug, true
+	case dto.LogLevelInfo:
+		return slog.LevelInfo, true
+	case dto.LogLevelWarn:
+		return slog.LevelWarn, true
+	case dto.LogLevelError:
+		return slog.LevelError, true
+	default:
+		return 0, false
+	}
+}
+
+// logLevelToDTO округляет промежуточные уровни slog (например, INFO+2) вниз до ближайшего из спецификации.
+func logLevelToDTO(level slog.Level) dto.LogLevel {
+	switch {
+	case level >= slog.LevelError:
+		return dto.LogLevelError
+	case level >= slog.LevelWarn:
+		return dto.LogLevelWarn
+	case level >= slog.LevelInfo:
+		return dto.LogLevelInfo
+	default:
+		return dto.LogLevelDebug
+	}
+}
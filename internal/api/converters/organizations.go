@@ -0,0 +1,14 @@
+package converters
+
+import (
+	"service-pr-reviewer-assignment/internal/generated/api/dto"
+	"service-pr-reviewer-assignment/internal/service/entities"
+)
+
+func OrganizationToDTO(organization *entities.Organization) dto.Organization {
+	return dto.Organization{
+		Id:        organization.ID,
+		Name:      organization.Name,
+		CreatedAt: organization.CreatedAt,
+	}
+}
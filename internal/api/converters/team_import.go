@@ -0,0 +1,158 @@
+package converters
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"service-pr-reviewer-assignment/internal/generated/api/dto"
+	"service-pr-reviewer-assignment/internal/service/entities"
+
+	"github.com/google/uuid"
+	"gopkg.in/yaml.v3"
+)
+
+// requiredTeamImportColumns обязательные колонки CSV, колонка is_active необязательна.
+var requiredTeamImportColumns = []string{"team", "user_id", "username"}
+
+// TeamImportFromCSV разбирает CSV с заголовком team,user_id,username,is_active (в любом
+// порядке, is_active необязателен). Строки нумеруются как в файле, заголовок — первая строка.
+// Ошибки в значениях полей возвращаются все сразу как ErrTeamImportValidation.
+func TeamImportFromCSV(r io.Reader) ([]entities.TeamImportRow, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read header: %w", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if _, ok := columns[name]; ok {
+			return nil, fmt.Errorf("duplicate column %q", name)
+		}
+		columns[name] = i
+	}
+	for _, name := range requiredTeamImportColumns {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("missing column %q", name)
+		}
+	}
+
+	var rows []entities.TeamImportRow
+	var rowErrors []entities.TeamImportRowError
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read row: %w", err)
+		}
+
+		line, _ := reader.FieldPos(0)
+		value := func(name string) string {
+			i, ok := columns[name]
+			if !ok {
+				return ""
+			}
+			return strings.TrimSpace(record[i])
+		}
+
+		row, errs := teamImportRow(line, value("team"), value("user_id"), value("username"), value("is_active"))
+		rows = append(rows, row)
+		rowErrors = append(rowErrors, errs...)
+	}
+
+	if len(rowErrors) > 0 {
+		return nil, &entities.ErrTeamImportValidation{Errors: rowErrors}
+	}
+
+	return rows, nil
+}
+
+// TeamImportFromYAML разбирает YAML-список записей TeamImportRow. Строки нумеруются
+// по порядку записей с единицы.
+func TeamImportFromYAML(r io.Reader) ([]entities.TeamImportRow, error) {
+	decoder := yaml.NewDecoder(r)
+	decoder.KnownFields(true)
+
+	var records []struct {
+		Team     string `yaml:"team"`
+		UserID   string `yaml:"user_id"`
+		Username string `yaml:"username"`
+		IsActive *bool  `yaml:"is_active"`
+	}
+	if err := decoder.Decode(&records); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("decode yaml: %w", err)
+	}
+
+	rows := make([]entities.TeamImportRow, 0, len(records))
+	var rowErrors []entities.TeamImportRowError
+	for i, record := range records {
+		isActive := ""
+		if record.IsActive != nil {
+			isActive = strconv.FormatBool(*record.IsActive)
+		}
+
+		row, errs := teamImportRow(i+1, record.Team, record.UserID, record.Username, isActive)
+		rows = append(rows, row)
+		rowErrors = append(rowErrors, errs...)
+	}
+
+	if len(rowErrors) > 0 {
+		return nil, &entities.ErrTeamImportValidation{Errors: rowErrors}
+	}
+
+	return rows, nil
+}
+
+func teamImportRow(line int, team, userID, username, isActive string) (entities.TeamImportRow, []entities.TeamImportRowError) {
+	row := entities.TeamImportRow{
+		Row:      line,
+		TeamName: team,
+		Username: username,
+		IsActive: true,
+	}
+
+	var rowErrors []entities.TeamImportRowError
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		rowErrors = append(rowErrors, entities.TeamImportRowError{Row: line, Field: "user_id", Reason: "invalid user_id format"})
+	}
+	row.UserID = id
+
+	if isActive != "" {
+		row.IsActive, err = strconv.ParseBool(isActive)
+		if err != nil {
+			rowErrors = append(rowErrors, entities.TeamImportRowError{Row: line, Field: "is_active", Reason: "is_active must be true or false"})
+		}
+	}
+
+	return row, rowErrors
+}
+
+func TeamImportResultToDTO(result *entities.TeamImportResult) dto.TeamImportResult {
+	teams := make([]dto.TeamImportTeam, 0, len(result.Teams))
+	for i := range result.Teams {
+		team := TeamToDTO(&result.Teams[i].Team)
+		teams = append(teams, dto.TeamImportTeam{
+			TeamName: team.TeamName,
+			Created:  result.Teams[i].Created,
+			Members:  team.Members,
+		})
+	}
+
+	return dto.TeamImportResult{
+		DryRun: result.DryRun,
+		Teams:  teams,
+	}
+}
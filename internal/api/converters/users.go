@@ -11,5 +11,6 @@ func UserToDTO(user *entities.User) *dto.User {
 		Username: user.Name,
 		TeamName: user.TeamName,
 		IsActive: user.IsActive,
+		Role:     dto.UserRole(user.Role),
 	}
 }
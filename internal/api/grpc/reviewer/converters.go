@@ -0,0 +1,136 @@
+package reviewer
+
+import (
+	"fmt"
+
+	reviewerv1 "service-pr-reviewer-assignment/internal/generated/api/grpc/reviewer/v1"
+	"service-pr-reviewer-assignment/internal/pkg/response"
+	"service-pr-reviewer-assignment/internal/service/entities"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func parseUUID(field, value string) (uuid.UUID, error) {
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return uuid.Nil, response.BadRequest("invalid " + field + ": " + err.Error())
+	}
+	return id, nil
+}
+
+func teamFromProto(team *reviewerv1.Team) (string, []entities.User, error) {
+	if team == nil {
+		return "", nil, response.BadRequest("team is required")
+	}
+
+	users := make([]entities.User, 0, len(team.GetMembers()))
+	for i, m := range team.GetMembers() {
+		id, err := parseUUID(fmt.Sprintf("members[%d].user_id", i), m.GetUserId())
+		if err != nil {
+			return "", nil, err
+		}
+
+		users = append(users, entities.User{
+			ID:       id,
+			Name:     m.GetUsername(),
+			TeamName: team.GetTeamName(),
+			IsActive: m.GetIsActive(),
+		})
+	}
+
+	return team.GetTeamName(), users, nil
+}
+
+func teamToProto(team *entities.Team) *reviewerv1.Team {
+	members := make([]*reviewerv1.TeamMember, 0, len(team.Members))
+	for _, u := range team.Members {
+		members = append(members, &reviewerv1.TeamMember{
+			UserId:   u.ID.String(),
+			Username: u.Name,
+			IsActive: u.IsActive,
+		})
+	}
+
+	return &reviewerv1.Team{
+		TeamName: team.Name,
+		Members:  members,
+	}
+}
+
+func userToProto(user *entities.User) *reviewerv1.User {
+	return &reviewerv1.User{
+		UserId:   user.ID.String(),
+		Username: user.Name,
+		TeamName: user.TeamName,
+		IsActive: user.IsActive,
+		Role:     userRoleToProto(user.Role),
+	}
+}
+
+func userRoleToProto(role entities.UserRole) reviewerv1.UserRole {
+	switch role {
+	case entities.UserRoleMember:
+		return reviewerv1.UserRole_USER_ROLE_MEMBER
+	case entities.UserRoleTeamLead:
+		return reviewerv1.UserRole_USER_ROLE_TEAM_LEAD
+	case entities.UserRoleOrgAdmin:
+		return reviewerv1.UserRole_USER_ROLE_ORG_ADMIN
+	default:
+		return reviewerv1.UserRole_USER_ROLE_UNSPECIFIED
+	}
+}
+
+func userRoleFromProto(role reviewerv1.UserRole) (entities.UserRole, error) {
+	switch role {
+	case reviewerv1.UserRole_USER_ROLE_MEMBER:
+		return entities.UserRoleMember, nil
+	case reviewerv1.UserRole_USER_ROLE_TEAM_LEAD:
+		return entities.UserRoleTeamLead, nil
+	case reviewerv1.UserRole_USER_ROLE_ORG_ADMIN:
+		return entities.UserRoleOrgAdmin, nil
+	default:
+		return "", response.BadRequest("invalid role: " + role.String())
+	}
+}
+
+func pullRequestStatusToProto(status entities.PullRequestStatus) reviewerv1.PullRequestStatus {
+	switch status {
+	case entities.PullRequestStatusOpen:
+		return reviewerv1.PullRequestStatus_PULL_REQUEST_STATUS_OPEN
+	case entities.PullRequestStatusMerged:
+		return reviewerv1.PullRequestStatus_PULL_REQUEST_STATUS_MERGED
+	default:
+		return reviewerv1.PullRequestStatus_PULL_REQUEST_STATUS_UNSPECIFIED
+	}
+}
+
+func pullRequestToProto(pr *entities.PullRequest, reviewerIDs []uuid.UUID) *reviewerv1.PullRequest {
+	reviewers := make([]string, 0, len(reviewerIDs))
+	for _, id := range reviewerIDs {
+		reviewers = append(reviewers, id.String())
+	}
+
+	result := &reviewerv1.PullRequest{
+		PullRequestId:     pr.ID.String(),
+		PullRequestName:   pr.Name,
+		AuthorId:          pr.AuthorID.String(),
+		Status:            pullRequestStatusToProto(pr.Status),
+		AssignedReviewers: reviewers,
+		CreatedAt:         timestamppb.New(pr.CreatedAt),
+	}
+	if pr.MergedAt != nil {
+		result.MergedAt = timestamppb.New(*pr.MergedAt)
+	}
+
+	return result
+}
+
+func pullRequestToShortProto(pr entities.PullRequest) *reviewerv1.PullRequestShort {
+	return &reviewerv1.PullRequestShort{
+		PullRequestId:   pr.ID.String(),
+		PullRequestName: pr.Name,
+		AuthorId:        pr.AuthorID.String(),
+		Status:          pullRequestStatusToProto(pr.Status),
+	}
+}
@@ -0,0 +1,268 @@
+package reviewer
+
+import (
+	"context"
+
+	reviewerv1 "service-pr-reviewer-assignment/internal/generated/api/grpc/reviewer/v1"
+	"service-pr-reviewer-assignment/internal/service/entities"
+
+	"github.com/google/uuid"
+)
+
+type Logger interface {
+	InfoContext(ctx context.Context, msg string)
+	ErrorfContext(ctx context.Context, format string, args ...interface{})
+	LogCtx(ctx context.Context, fields ...any) context.Context
+}
+
+type Service interface {
+	CreateTeam(ctx context.Context, teamName string, members []entities.User) (*entities.Team, error)
+	GetTeam(ctx context.Context, teamName string) (*entities.Team, error)
+
+	SetUserActiveStatus(ctx context.Context, userID uuid.UUID, isActive bool) (*entities.User, error)
+	SetUserRole(ctx context.Context, userID uuid.UUID, role entities.UserRole) (*entities.User, error)
+	GetUserPullRequestReviewRequests(ctx context.Context, userID uuid.UUID) ([]entities.PullRequest, error)
+
+	CreatePullRequestAndAssignReviewers(
+		ctx context.Context,
+		pullRequestID uuid.UUID,
+		pullRequestName string,
+		authorID uuid.UUID,
+		returnExisting bool,
+	) (pr *entities.PullRequest, reviewerIDs []uuid.UUID, err error)
+	MergePullRequestAndGetReviewers(ctx context.Context, pullRequestID uuid.UUID) (*entities.PullRequest, []uuid.UUID, error)
+	ReassignReviewer(
+		ctx context.Context,
+		pullRequestID uuid.UUID,
+		oldReviewerID uuid.UUID,
+		requestedReviewerID *uuid.UUID,
+	) (pr *entities.PullRequest, prReviewerIDs []uuid.UUID, newReviewerID uuid.UUID, err error)
+}
+
+// Server реализует reviewer.v1.ReviewerService поверх того же сервиса, что и HTTP API.
+// Методы возвращают доменные ошибки как есть, в статусы gRPC их переводит grpc_status.UnaryServerInterceptor.
+type Server struct {
+	reviewerv1.UnimplementedReviewerServiceServer
+
+	logger  Logger
+	service Service
+}
+
+func NewServer(logger Logger, service Service) *Server {
+	return &Server{
+		logger:  logger,
+		service: service,
+	}
+}
+
+func (s *Server) CreateTeam(ctx context.Context, req *reviewerv1.CreateTeamRequest) (*reviewerv1.CreateTeamResponse, error) {
+	teamName, members, err := teamFromProto(req.GetTeam())
+	if err != nil {
+		s.logger.ErrorfContext(ctx, "decode request failed: %v", err)
+		return nil, err
+	}
+
+	ctx = s.logger.LogCtx(ctx,
+		"team_name", teamName,
+		"members_count", len(members),
+	)
+
+	team, err := s.service.CreateTeam(ctx, teamName, members)
+	if err != nil {
+		s.logger.ErrorfContext(ctx, "create team failed: %v", err)
+		return nil, err
+	}
+
+	s.logger.InfoContext(ctx, "team created successfully")
+	return &reviewerv1.CreateTeamResponse{Team: teamToProto(team)}, nil
+}
+
+func (s *Server) GetTeam(ctx context.Context, req *reviewerv1.GetTeamRequest) (*reviewerv1.GetTeamResponse, error) {
+	ctx = s.logger.LogCtx(ctx, "team_name", req.GetTeamName())
+
+	team, err := s.service.GetTeam(ctx, req.GetTeamName())
+	if err != nil {
+		s.logger.ErrorfContext(ctx, "get team failed: %v", err)
+		return nil, err
+	}
+
+	s.logger.InfoContext(ctx, "team retrieved successfully")
+	return &reviewerv1.GetTeamResponse{Team: teamToProto(team)}, nil
+}
+
+func (s *Server) SetUserActive(ctx context.Context, req *reviewerv1.SetUserActiveRequest) (*reviewerv1.SetUserActiveResponse, error) {
+	userID, err := parseUUID("user_id", req.GetUserId())
+	if err != nil {
+		s.logger.ErrorfContext(ctx, "decode request failed: %v", err)
+		return nil, err
+	}
+
+	ctx = s.logger.LogCtx(ctx,
+		"user_id", userID,
+		"is_active", req.GetIsActive(),
+	)
+
+	user, err := s.service.SetUserActiveStatus(ctx, userID, req.GetIsActive())
+	if err != nil {
+		s.logger.ErrorfContext(ctx, "set user active status failed: %v", err)
+		return nil, err
+	}
+
+	s.logger.InfoContext(ctx, "user active status updated successfully")
+	return &reviewerv1.SetUserActiveResponse{User: userToProto(user)}, nil
+}
+
+func (s *Server) SetUserRole(ctx context.Context, req *reviewerv1.SetUserRoleRequest) (*reviewerv1.SetUserRoleResponse, error) {
+	userID, err := parseUUID("user_id", req.GetUserId())
+	if err != nil {
+		s.logger.ErrorfContext(ctx, "decode request failed: %v", err)
+		return nil, err
+	}
+	role, err := userRoleFromProto(req.GetRole())
+	if err != nil {
+		s.logger.ErrorfContext(ctx, "decode request failed: %v", err)
+		return nil, err
+	}
+
+	ctx = s.logger.LogCtx(ctx,
+		"user_id", userID,
+		"role", role,
+	)
+
+	user, err := s.service.SetUserRole(ctx, userID, role)
+	if err != nil {
+		s.logger.ErrorfContext(ctx, "set user role failed: %v", err)
+		return nil, err
+	}
+
+	s.logger.InfoContext(ctx, "user role updated successfully")
+	return &reviewerv1.SetUserRoleResponse{User: userToProto(user)}, nil
+}
+
+func (s *Server) GetUserReviews(ctx context.Context, req *reviewerv1.GetUserReviewsRequest) (*reviewerv1.GetUserReviewsResponse, error) {
+	userID, err := parseUUID("user_id", req.GetUserId())
+	if err != nil {
+		s.logger.ErrorfContext(ctx, "decode request failed: %v", err)
+		return nil, err
+	}
+
+	ctx = s.logger.LogCtx(ctx, "user_id", userID)
+
+	prs, err := s.service.GetUserPullRequestReviewRequests(ctx, userID)
+	if err != nil {
+		s.logger.ErrorfContext(ctx, "get user review requests failed: %v", err)
+		return nil, err
+	}
+
+	result := make([]*reviewerv1.PullRequestShort, 0, len(prs))
+	for _, pr := range prs {
+		result = append(result, pullRequestToShortProto(pr))
+	}
+
+	s.logger.InfoContext(ctx, "user review requests retrieved successfully")
+	return &reviewerv1.GetUserReviewsResponse{
+		UserId:       userID.String(),
+		PullRequests: result,
+	}, nil
+}
+
+func (s *Server) CreatePullRequest(
+	ctx context.Context,
+	req *reviewerv1.CreatePullRequestRequest,
+) (*reviewerv1.CreatePullRequestResponse, error) {
+	prID, err := parseUUID("pull_request_id", req.GetPullRequestId())
+	if err != nil {
+		s.logger.ErrorfContext(ctx, "decode request failed: %v", err)
+		return nil, err
+	}
+	authorID, err := parseUUID("author_id", req.GetAuthorId())
+	if err != nil {
+		s.logger.ErrorfContext(ctx, "decode request failed: %v", err)
+		return nil, err
+	}
+
+	ctx = s.logger.LogCtx(ctx,
+		"pull_request_id", prID,
+		"pull_request_name", req.GetPullRequestName(),
+		"author_id", authorID,
+		"return_existing", req.GetReturnExisting(),
+	)
+
+	pullRequest, reviewerIDs, err := s.service.CreatePullRequestAndAssignReviewers(
+		ctx, prID, req.GetPullRequestName(), authorID, req.GetReturnExisting(),
+	)
+	if err != nil {
+		s.logger.ErrorfContext(ctx, "create pull request failed: %v", err)
+		return nil, err
+	}
+
+	s.logger.InfoContext(ctx, "pull request created successfully")
+	return &reviewerv1.CreatePullRequestResponse{Pr: pullRequestToProto(pullRequest, reviewerIDs)}, nil
+}
+
+func (s *Server) MergePullRequest(
+	ctx context.Context,
+	req *reviewerv1.MergePullRequestRequest,
+) (*reviewerv1.MergePullRequestResponse, error) {
+	prID, err := parseUUID("pull_request_id", req.GetPullRequestId())
+	if err != nil {
+		s.logger.ErrorfContext(ctx, "decode request failed: %v", err)
+		return nil, err
+	}
+
+	ctx = s.logger.LogCtx(ctx, "pull_request_id", prID)
+
+	pullRequest, reviewerIDs, err := s.service.MergePullRequestAndGetReviewers(ctx, prID)
+	if err != nil {
+		s.logger.ErrorfContext(ctx, "merge pull request failed: %v", err)
+		return nil, err
+	}
+
+	s.logger.InfoContext(ctx, "pull request merged successfully")
+	return &reviewerv1.MergePullRequestResponse{Pr: pullRequestToProto(pullRequest, reviewerIDs)}, nil
+}
+
+func (s *Server) ReassignReviewer(
+	ctx context.Context,
+	req *reviewerv1.ReassignReviewerRequest,
+) (*reviewerv1.ReassignReviewerResponse, error) {
+	prID, err := parseUUID("pull_request_id", req.GetPullRequestId())
+	if err != nil {
+		s.logger.ErrorfContext(ctx, "decode request failed: %v", err)
+		return nil, err
+	}
+	oldReviewerID, err := parseUUID("old_user_id", req.GetOldUserId())
+	if err != nil {
+		s.logger.ErrorfContext(ctx, "decode request failed: %v", err)
+		return nil, err
+	}
+	var requestedReviewerID *uuid.UUID
+	if req.NewUserId != nil {
+		id, err := parseUUID("new_user_id", req.GetNewUserId())
+		if err != nil {
+			s.logger.ErrorfContext(ctx, "decode request failed: %v", err)
+			return nil, err
+		}
+		requestedReviewerID = &id
+	}
+
+	ctx = s.logger.LogCtx(ctx,
+		"pull_request_id", prID,
+		"old_user_id", oldReviewerID,
+		"requested_user_id", requestedReviewerID,
+	)
+
+	pullRequest, prReviewerIDs, newReviewerID, err := s.service.ReassignReviewer(ctx, prID, oldReviewerID, requestedReviewerID)
+	if err != nil {
+		s.logger.ErrorfContext(ctx, "reassign reviewer failed: %v", err)
+		return nil, err
+	}
+
+	ctx = s.logger.LogCtx(ctx, "new_reviewer_id", newReviewerID.String())
+
+	s.logger.InfoContext(ctx, "reviewer reassigned successfully")
+	return &reviewerv1.ReassignReviewerResponse{
+		Pr:         pullRequestToProto(pullRequest, prReviewerIDs),
+		ReplacedBy: newReviewerID.String(),
+	}, nil
+}
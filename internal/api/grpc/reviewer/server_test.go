@@ -0,0 +1,190 @@
+package reviewer
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"service-pr-reviewer-assignment/internal/generated/api/dto"
+	reviewerv1 "service-pr-reviewer-assignment/internal/generated/api/grpc/reviewer/v1"
+	"service-pr-reviewer-assignment/internal/pkg/authentication"
+	"service-pr-reviewer-assignment/internal/pkg/grpc_status"
+	"service-pr-reviewer-assignment/internal/pkg/panic_recover"
+	"service-pr-reviewer-assignment/internal/service/entities"
+
+	"github.com/google/uuid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const (
+	readKey  = "read-key"
+	writeKey = "write-key"
+)
+
+type noopLogger struct{}
+
+func (noopLogger) InfoContext(context.Context, string) {}
+
+func (noopLogger) ErrorfContext(context.Context, string, ...interface{}) {}
+
+func (noopLogger) LogCtx(ctx context.Context, _ ...any) context.Context { return ctx }
+
+// apiKeys принимает readKey со scope read и writeKey со scope write:teams.
+type apiKeys struct{}
+
+func (apiKeys) AuthenticateAPIKey(_ context.Context, secret string) (*entities.Identity, error) {
+	switch secret {
+	case readKey:
+		return &entities.Identity{Subject: "key:read", Scopes: []entities.Scope{entities.ScopeRead}}, nil
+	case writeKey:
+		return &entities.Identity{Subject: "key:write", Scopes: []entities.Scope{entities.ScopeWriteTeams}}, nil
+	default:
+		return nil, entities.ErrInvalidCredentials
+	}
+}
+
+// fakeService отвечает на GetTeam по имени команды: "payments" есть, "panic" паникует,
+// остальных нет. Остальные методы не вызываются.
+type fakeService struct {
+	Service
+}
+
+func (fakeService) GetTeam(_ context.Context, teamName string) (*entities.Team, error) {
+	switch teamName {
+	case "payments":
+		return &entities.Team{Name: "payments", Members: []entities.User{{ID: uuid.New(), Name: "Alice", IsActive: true}}}, nil
+	case "panic":
+		panic("unexpected nil team")
+	default:
+		return nil, &entities.ErrTeamNotFound{Name: teamName}
+	}
+}
+
+func (fakeService) CreateTeam(_ context.Context, teamName string, _ []entities.User) (*entities.Team, error) {
+	return nil, &entities.ErrTeamAlreadyExists{Name: teamName}
+}
+
+// newClient поднимает ReviewerService на bufconn с цепочкой interceptor-ов, как в grpc_server.
+func newClient(t *testing.T) reviewerv1.ReviewerServiceClient {
+	t.Helper()
+
+	scopes := map[string]entities.Scope{
+		reviewerv1.ReviewerService_GetTeam_FullMethodName:    entities.ScopeRead,
+		reviewerv1.ReviewerService_CreateTeam_FullMethodName: entities.ScopeWriteTeams,
+	}
+
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer(grpc.ChainUnaryInterceptor(
+		panic_recover.UnaryServerInterceptor(noopLogger{}),
+		authentication.UnaryServerInterceptor(noopLogger{}, apiKeys{}, nil, true),
+		authentication.RequireScopeInterceptor(noopLogger{}, scopes),
+		grpc_status.UnaryServerInterceptor(),
+	))
+	reviewerv1.RegisterReviewerServiceServer(server, NewServer(noopLogger{}, fakeService{}))
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return reviewerv1.NewReviewerServiceClient(conn)
+}
+
+func withKey(key string) context.Context {
+	if key == "" {
+		return context.Background()
+	}
+	return metadata.AppendToOutgoingContext(context.Background(), "x-api-key", key)
+}
+
+func assertStatus(t *testing.T, err error, wantCode codes.Code, wantReason dto.ErrorResponseErrorCode) {
+	t.Helper()
+
+	st := status.Convert(err)
+	if st.Code() != wantCode {
+		t.Fatalf("code = %s (%s), want %s", st.Code(), st.Message(), wantCode)
+	}
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok {
+			if info.GetReason() != string(wantReason) {
+				t.Errorf("reason = %s, want %s", info.GetReason(), wantReason)
+			}
+			return
+		}
+	}
+	t.Errorf("status has no ErrorInfo")
+}
+
+func TestGetTeam(t *testing.T) {
+	client := newClient(t)
+
+	resp, err := client.GetTeam(withKey(readKey), &reviewerv1.GetTeamRequest{TeamName: "payments"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.GetTeam().GetTeamName() != "payments" || len(resp.GetTeam().GetMembers()) != 1 {
+		t.Errorf("team = %v", resp.GetTeam())
+	}
+}
+
+func TestAuthenticationRejections(t *testing.T) {
+	client := newClient(t)
+
+	tests := []struct {
+		name       string
+		key        string
+		wantCode   codes.Code
+		wantReason dto.ErrorResponseErrorCode
+	}{
+		{name: "missing key", wantCode: codes.Unauthenticated, wantReason: dto.UNAUTHORIZED},
+		{name: "unknown key", key: "unknown", wantCode: codes.Unauthenticated, wantReason: dto.UNAUTHORIZED},
+		{name: "insufficient scope", key: readKey, wantCode: codes.PermissionDenied, wantReason: dto.INSUFFICIENTSCOPE},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.CreateTeam(withKey(tt.key), &reviewerv1.CreateTeamRequest{Team: &reviewerv1.Team{TeamName: "payments"}})
+			assertStatus(t, err, tt.wantCode, tt.wantReason)
+		})
+	}
+}
+
+func TestErrorsMapToStatuses(t *testing.T) {
+	client := newClient(t)
+
+	_, err := client.GetTeam(withKey(readKey), &reviewerv1.GetTeamRequest{TeamName: "search"})
+	assertStatus(t, err, codes.NotFound, dto.NOTFOUND)
+
+	_, err = client.CreateTeam(withKey(writeKey), &reviewerv1.CreateTeamRequest{Team: &reviewerv1.Team{TeamName: "payments"}})
+	assertStatus(t, err, codes.AlreadyExists, dto.TEAMEXISTS)
+
+	_, err = client.CreateTeam(withKey(writeKey), &reviewerv1.CreateTeamRequest{Team: &reviewerv1.Team{
+		TeamName: "payments",
+		Members:  []*reviewerv1.TeamMember{{UserId: "u1", Username: "Alice"}},
+	}})
+	assertStatus(t, err, codes.InvalidArgument, dto.BADREQUEST)
+}
+
+func TestPanicRecovery(t *testing.T) {
+	client := newClient(t)
+
+	_, err := client.GetTeam(withKey(readKey), &reviewerv1.GetTeamRequest{TeamName: "panic"})
+	assertStatus(t, err, codes.Internal, dto.INTERNALERROR)
+
+	// Сервер продолжает обслуживать вызовы после паники.
+	if _, err := client.GetTeam(withKey(readKey), &reviewerv1.GetTeamRequest{TeamName: "payments"}); err != nil {
+		t.Errorf("call after panic: %v", err)
+	}
+}
@@ -0,0 +1,49 @@
+package apikey_list
+
+import (
+	"context"
+	"net/http"
+
+	"service-pr-reviewer-assignment/internal/api/converters"
+	"service-pr-reviewer-assignment/internal/pkg/response"
+	"service-pr-reviewer-assignment/internal/service/entities"
+)
+
+type Logger interface {
+	InfoContext(ctx context.Context, msg string)
+	ErrorfContext(ctx context.Context, format string, args ...interface{})
+}
+
+type Service interface {
+	ListAPIKeys(ctx context.Context) ([]entities.APIKey, error)
+}
+
+type Handler struct {
+	logger  Logger
+	service Service
+}
+
+func NewHandler(logger Logger, service Service) *Handler {
+	return &Handler{
+		logger:  logger,
+		service: service,
+	}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	response.HandlerFunc(h.handle).ServeHTTP(w, r)
+}
+
+func (h *Handler) handle(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+
+	keys, err := h.service.ListAPIKeys(ctx)
+	if err != nil {
+		h.logger.ErrorfContext(ctx, "list api keys failed: %v", err)
+		return err
+	}
+
+	h.logger.InfoContext(ctx, "api keys listed successfully")
+	response.OK(w, converters.APIKeysToDTO(keys))
+	return nil
+}
@@ -0,0 +1,76 @@
+package apikey_revoke
+
+import (
+	"context"
+	"net/http"
+
+	"service-pr-reviewer-assignment/internal/api/converters"
+	"service-pr-reviewer-assignment/internal/api/path_params"
+	"service-pr-reviewer-assignment/internal/generated/api/dto"
+	"service-pr-reviewer-assignment/internal/pkg/response"
+	"service-pr-reviewer-assignment/internal/service/entities"
+
+	"github.com/google/uuid"
+)
+
+type Logger interface {
+	InfoContext(ctx context.Context, msg string)
+	ErrorfContext(ctx context.Context, format string, args ...interface{})
+	LogCtx(ctx context.Context, fields ...any) context.Context
+}
+
+type Service interface {
+	RevokeAPIKey(ctx context.Context, id uuid.UUID) (*entities.APIKey, error)
+}
+
+type Handler struct {
+	logger  Logger
+	service Service
+	decode  path_params.Decoder[dto.RevokeAPIKeyRequest]
+}
+
+// NewHandler обрабатывает устаревший маршрут POST /admin/apiKeys/revoke.
+func NewHandler(logger Logger, service Service) *Handler {
+	return &Handler{
+		logger:  logger,
+		service: service,
+		decode:  path_params.JSON[dto.RevokeAPIKeyRequest],
+	}
+}
+
+// NewResourceHandler обрабатывает маршрут POST /api/v1/admin/api-keys/{id}/revoke.
+func NewResourceHandler(logger Logger, service Service) *Handler {
+	return &Handler{
+		logger:  logger,
+		service: service,
+		decode: path_params.UUIDInto("id", func(id uuid.UUID) dto.RevokeAPIKeyRequest {
+			return dto.RevokeAPIKeyRequest{Id: id}
+		}),
+	}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	response.HandlerFunc(h.handle).ServeHTTP(w, r)
+}
+
+func (h *Handler) handle(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+
+	req, err := h.decode(r)
+	if err != nil {
+		h.logger.ErrorfContext(ctx, "decode request failed: %v", err)
+		return err
+	}
+
+	ctx = h.logger.LogCtx(ctx, "api_key_id", req.Id)
+
+	key, err := h.service.RevokeAPIKey(ctx, req.Id)
+	if err != nil {
+		h.logger.ErrorfContext(ctx, "revoke api key failed: %v", err)
+		return err
+	}
+
+	h.logger.InfoContext(ctx, "api key revoked successfully")
+	response.OK(w, converters.APIKeyToDTO(key))
+	return nil
+}
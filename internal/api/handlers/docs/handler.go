@@ -0,0 +1,25 @@
+package docs
+
+import (
+	"io/fs"
+	"net/http"
+)
+
+// Prefix путь, по которому доступна страница документации.
+const Prefix = "/docs/"
+
+// Handler отдаёт статические файлы страницы документации.
+type Handler struct {
+	files http.Handler
+}
+
+func NewHandler(assets fs.FS) *Handler {
+	return &Handler{
+		files: http.StripPrefix(Prefix, http.FileServerFS(assets)),
+	}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Cache-Control", "no-cache")
+	h.files.ServeHTTP(w, r)
+}
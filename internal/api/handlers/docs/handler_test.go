@@ -0,0 +1,68 @@
+package docs
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"service-pr-reviewer-assignment/api"
+)
+
+func serve(handler http.Handler, method, target string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(method, target, nil))
+	return rec
+}
+
+func TestHandlerServesFiles(t *testing.T) {
+	handler := NewHandler(fstest.MapFS{
+		"index.html": {Data: []byte("<html>docs</html>")},
+		"docs.css":   {Data: []byte("body {}")},
+	})
+
+	tests := []struct {
+		name            string
+		method          string
+		target          string
+		wantStatus      int
+		wantContentType string
+		wantBody        string
+	}{
+		{name: "index", method: http.MethodGet, target: Prefix, wantStatus: http.StatusOK, wantContentType: "text/html", wantBody: "<html>docs</html>"},
+		{name: "asset", method: http.MethodGet, target: Prefix + "docs.css", wantStatus: http.StatusOK, wantContentType: "text/css", wantBody: "body {}"},
+		{name: "head", method: http.MethodHead, target: Prefix, wantStatus: http.StatusOK, wantContentType: "text/html"},
+		{name: "missing file", method: http.MethodGet, target: Prefix + "missing.js", wantStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := serve(handler, tt.method, tt.target)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			// Ответы с ошибкой http.FileServer отдаёт без заголовков кэширования.
+			if got := rec.Header().Get("Cache-Control"); tt.wantStatus == http.StatusOK && got != "no-cache" {
+				t.Errorf("Cache-Control = %q, want no-cache", got)
+			}
+			if tt.wantContentType != "" && !strings.HasPrefix(rec.Header().Get("Content-Type"), tt.wantContentType) {
+				t.Errorf("Content-Type = %q, want %s", rec.Header().Get("Content-Type"), tt.wantContentType)
+			}
+			if tt.wantBody != "" && rec.Body.String() != tt.wantBody {
+				t.Errorf("body = %q, want %q", rec.Body.String(), tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestHandlerServesEmbeddedDocs(t *testing.T) {
+	handler := NewHandler(api.Docs)
+
+	for _, name := range []string{"", "docs.css", "docs.js", "problems.html"} {
+		if rec := serve(handler, http.MethodGet, Prefix+name); rec.Code != http.StatusOK {
+			t.Errorf("%s%s: status = %d, want 200", Prefix, name, rec.Code)
+		}
+	}
+}
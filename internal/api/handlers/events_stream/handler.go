@@ -0,0 +1,210 @@
+package events_stream
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"service-pr-reviewer-assignment/internal/api/converters"
+	"service-pr-reviewer-assignment/internal/pkg/response"
+	"service-pr-reviewer-assignment/internal/pkg/tenant"
+	"service-pr-reviewer-assignment/internal/service/entities"
+
+	"github.com/google/uuid"
+)
+
+const (
+	HeaderLastEventID = "Last-Event-ID"
+
+	heartbeatInterval = 15 * time.Second
+	replayBatchSize   = 500
+)
+
+type Logger interface {
+	InfoContext(ctx context.Context, msg string)
+	ErrorfContext(ctx context.Context, format string, args ...interface{})
+	LogCtx(ctx context.Context, fields ...any) context.Context
+}
+
+type Service interface {
+	ListEventsAfter(ctx context.Context, afterID int64, filter entities.EventFilter, limit uint64) ([]entities.Event, error)
+}
+
+// Broker раздаёт новые события. Канал закрывается, когда подписчик пропустил события
+// или реплика останавливается, — клиент переподключается с Last-Event-ID.
+type Broker interface {
+	Subscribe(orgID uuid.UUID, filter entities.EventFilter) (<-chan entities.Event, func())
+}
+
+type Handler struct {
+	logger  Logger
+	service Service
+	broker  Broker
+}
+
+// NewHandler обрабатывает маршрут GET /events/stream.
+func NewHandler(logger Logger, service Service, broker Broker) *Handler {
+	return &Handler{
+		logger:  logger,
+		service: service,
+		broker:  broker,
+	}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	response.HandlerFunc(h.handle).ServeHTTP(w, r)
+}
+
+func (h *Handler) handle(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+
+	filter, lastEventID, err := decode(r)
+	if err != nil {
+		h.logger.ErrorfContext(ctx, "decode request failed: %v", err)
+		return err
+	}
+
+	orgID, ok := tenant.FromContext(ctx)
+	if !ok {
+		return entities.ErrTenantNotResolved
+	}
+
+	ctx = h.logger.LogCtx(ctx,
+		"user_id", filter.UserID,
+		"team_name", filter.TeamName,
+		"pull_request_id", filter.PullRequestID,
+		"last_event_id", lastEventID,
+	)
+
+	// Подписка оформляется до чтения истории, чтобы не потерять события между ними.
+	live, unsubscribe := h.broker.Subscribe(orgID, filter)
+	defer unsubscribe()
+
+	// Первая порция истории читается до заголовков, чтобы ошибка базы дошла до клиента обычным ответом.
+	var backlog []entities.Event
+	if lastEventID != nil {
+		backlog, err = h.service.ListEventsAfter(ctx, *lastEventID, filter, replayBatchSize)
+		if err != nil {
+			h.logger.ErrorfContext(ctx, "list events failed: %v", err)
+			return err
+		}
+	}
+
+	rc := http.NewResponseController(w)
+	// Поток живёт дольше WriteTimeout сервера.
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil {
+		h.logger.ErrorfContext(ctx, "disable write deadline failed: %v", err)
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	if err := rc.Flush(); err != nil {
+		h.logger.ErrorfContext(ctx, "flush failed: %v", err)
+		return nil
+	}
+
+	h.logger.InfoContext(ctx, "event stream opened")
+
+	// Номера событий из истории запоминаются, чтобы не отправить их второй раз из подписки.
+	replayed := make(map[int64]struct{})
+	for len(backlog) > 0 {
+		for i := range backlog {
+			if err := writeEvent(w, &backlog[i]); err != nil {
+				return nil
+			}
+			replayed[backlog[i].ID] = struct{}{}
+		}
+		if err := rc.Flush(); err != nil || len(backlog) < replayBatchSize {
+			break
+		}
+
+		backlog, err = h.service.ListEventsAfter(ctx, backlog[len(backlog)-1].ID, filter, replayBatchSize)
+		if err != nil {
+			h.logger.ErrorfContext(ctx, "list events failed: %v", err)
+			return nil
+		}
+	}
+
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			h.logger.InfoContext(ctx, "event stream closed by client")
+			return nil
+		case event, ok := <-live:
+			if !ok {
+				h.logger.InfoContext(ctx, "event stream closed by server")
+				return nil
+			}
+			if _, ok := replayed[event.ID]; ok {
+				continue
+			}
+			if err := writeEvent(w, &event); err != nil {
+				return nil
+			}
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return nil
+			}
+		}
+
+		if err := rc.Flush(); err != nil {
+			return nil
+		}
+	}
+}
+
+func writeEvent(w http.ResponseWriter, event *entities.Event) error {
+	data, err := json.Marshal(converters.EventToDTO(event))
+	if err != nil {
+		return fmt.Errorf("marshal event %d: %w", event.ID, err)
+	}
+
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data)
+	return err
+}
+
+func decode(r *http.Request) (entities.EventFilter, *int64, error) {
+	var filter entities.EventFilter
+	query := r.URL.Query()
+
+	if value := query.Get("user_id"); value != "" {
+		userID, err := uuid.Parse(value)
+		if err != nil {
+			return filter, nil, response.BadRequest("invalid user_id format")
+		}
+		filter.UserID = &userID
+	}
+	if value := query.Get("team_name"); value != "" {
+		filter.TeamName = &value
+	}
+	if value := query.Get("pull_request_id"); value != "" {
+		prID, err := uuid.Parse(value)
+		if err != nil {
+			return filter, nil, response.BadRequest("invalid pull_request_id format")
+		}
+		filter.PullRequestID = &prID
+	}
+
+	value := r.Header.Get(HeaderLastEventID)
+	if value == "" {
+		value = query.Get("last_event_id")
+	}
+	if value == "" {
+		return filter, nil, nil
+	}
+
+	lastEventID, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || lastEventID < 0 {
+		return filter, nil, response.BadRequest("invalid last event id")
+	}
+
+	return filter, &lastEventID, nil
+}
@@ -0,0 +1,262 @@
+package events_stream
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"service-pr-reviewer-assignment/internal/pkg/tenant"
+	"service-pr-reviewer-assignment/internal/service/entities"
+
+	"github.com/google/uuid"
+)
+
+var (
+	orgID  = uuid.MustParse("750e8400-e29b-41d4-a716-446655440001")
+	alice  = uuid.MustParse("550e8400-e29b-41d4-a716-446655440001")
+	bob    = uuid.MustParse("550e8400-e29b-41d4-a716-446655440002")
+	prPay  = uuid.MustParse("650e8400-e29b-41d4-a716-446655440001")
+	prFind = uuid.MustParse("650e8400-e29b-41d4-a716-446655440002")
+)
+
+type noopLogger struct{}
+
+func (noopLogger) InfoContext(context.Context, string) {}
+
+func (noopLogger) ErrorfContext(context.Context, string, ...interface{}) {}
+
+func (noopLogger) LogCtx(ctx context.Context, _ ...any) context.Context { return ctx }
+
+// fakeService хранит историю событий и отдаёт её как storage: после afterID, по фильтру,
+// порциями. calls — afterID каждого чтения.
+type fakeService struct {
+	events []entities.Event
+	err    error
+	calls  []int64
+}
+
+func (s *fakeService) ListEventsAfter(
+	_ context.Context,
+	afterID int64,
+	filter entities.EventFilter,
+	limit uint64,
+) ([]entities.Event, error) {
+	s.calls = append(s.calls, afterID)
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	var result []entities.Event
+	for i := range s.events {
+		if s.events[i].ID > afterID && filter.Matches(&s.events[i]) && uint64(len(result)) < limit {
+			result = append(result, s.events[i])
+		}
+	}
+	return result, nil
+}
+
+// fakeBroker отдаёт подписчику заранее заданные события и закрывает канал, как при остановке реплики.
+type fakeBroker struct {
+	live         []entities.Event
+	orgID        uuid.UUID
+	unsubscribed bool
+}
+
+func (b *fakeBroker) Subscribe(orgID uuid.UUID, filter entities.EventFilter) (<-chan entities.Event, func()) {
+	b.orgID = orgID
+
+	events := make(chan entities.Event, len(b.live))
+	for _, event := range b.live {
+		if filter.Matches(&event) {
+			events <- event
+		}
+	}
+	close(events)
+	return events, func() { b.unsubscribed = true }
+}
+
+func assigned(id int64, team string, prID uuid.UUID, reviewers ...uuid.UUID) entities.Event {
+	return entities.Event{
+		ID:            id,
+		Type:          entities.EventTypeReviewersAssigned,
+		TeamName:      team,
+		PullRequestID: &prID,
+		ReviewerIDs:   reviewers,
+	}
+}
+
+func stream(t *testing.T, service *fakeService, broker *fakeBroker, target string, header string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	if header != "" {
+		req.Header.Set(HeaderLastEventID, header)
+	}
+	req = req.WithContext(tenant.WithOrgID(req.Context(), orgID))
+
+	rec := httptest.NewRecorder()
+	NewHandler(noopLogger{}, service, broker).ServeHTTP(rec, req)
+	return rec
+}
+
+// eventIDs возвращает номера событий потока в порядке отправки.
+func eventIDs(body string) []string {
+	var ids []string
+	for _, line := range strings.Split(body, "\n") {
+		if id, ok := strings.CutPrefix(line, "id: "); ok {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+func TestStreamSendsLiveEvents(t *testing.T) {
+	service := &fakeService{events: []entities.Event{assigned(1, "payments", prPay, alice)}}
+	broker := &fakeBroker{live: []entities.Event{assigned(2, "payments", prPay, bob)}}
+
+	rec := stream(t, service, broker, "/events/stream", "")
+
+	if rec.Code != http.StatusOK || rec.Header().Get("Content-Type") != "text/event-stream" {
+		t.Fatalf("status = %d, Content-Type = %q", rec.Code, rec.Header().Get("Content-Type"))
+	}
+	if len(service.calls) != 0 {
+		t.Errorf("history was read without Last-Event-ID: %+v", service.calls)
+	}
+	if got := eventIDs(rec.Body.String()); fmt.Sprint(got) != "[2]" {
+		t.Errorf("events = %v, want [2]", got)
+	}
+	if !strings.Contains(rec.Body.String(), "event: pull_request.reviewers_assigned\ndata: {") {
+		t.Errorf("body = %q", rec.Body.String())
+	}
+	if broker.orgID != orgID || !broker.unsubscribed {
+		t.Errorf("subscription org = %s, unsubscribed = %t", broker.orgID, broker.unsubscribed)
+	}
+}
+
+func TestStreamResumesAfterLastEventID(t *testing.T) {
+	history := []entities.Event{
+		assigned(4, "payments", prPay, alice),
+		assigned(5, "payments", prPay, alice),
+		assigned(6, "payments", prPay, bob),
+		assigned(7, "payments", prPay, alice),
+	}
+
+	tests := []struct {
+		name   string
+		target string
+		header string
+	}{
+		{name: "header", target: "/events/stream", header: "5"},
+		{name: "query", target: "/events/stream?last_event_id=5"},
+		{name: "header wins over query", target: "/events/stream?last_event_id=1", header: "5"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &fakeService{events: history}
+			// Событие 7 пришло и в подписку, и в историю: отправляется один раз.
+			broker := &fakeBroker{live: []entities.Event{history[3], assigned(8, "payments", prPay, bob)}}
+
+			rec := stream(t, service, broker, tt.target, tt.header)
+
+			if got := eventIDs(rec.Body.String()); fmt.Sprint(got) != "[6 7 8]" {
+				t.Errorf("events = %v, want [6 7 8]", got)
+			}
+			if len(service.calls) == 0 || service.calls[0] != 5 {
+				t.Errorf("history calls = %+v, want after 5", service.calls)
+			}
+		})
+	}
+}
+
+func TestStreamReplaysHistoryInBatches(t *testing.T) {
+	history := make([]entities.Event, 0, replayBatchSize+2)
+	for i := range replayBatchSize + 2 {
+		history = append(history, assigned(int64(i+1), "payments", prPay, alice))
+	}
+	service := &fakeService{events: history}
+
+	rec := stream(t, service, &fakeBroker{}, "/events/stream", "0")
+
+	if got := len(eventIDs(rec.Body.String())); got != len(history) {
+		t.Errorf("replayed %d events, want %d", got, len(history))
+	}
+	if len(service.calls) != 2 || service.calls[1] != replayBatchSize {
+		t.Errorf("history calls = %+v, want a second batch after %d", service.calls, replayBatchSize)
+	}
+}
+
+func TestStreamFilters(t *testing.T) {
+	events := []entities.Event{
+		assigned(1, "payments", prPay, alice),
+		assigned(2, "search", prFind, bob),
+		assigned(3, "payments", prPay, bob),
+	}
+
+	tests := []struct {
+		name   string
+		query  string
+		wantID string
+	}{
+		{name: "user", query: "user_id=" + alice.String(), wantID: "[1]"},
+		{name: "team", query: "team_name=search", wantID: "[2]"},
+		{name: "pull request", query: "pull_request_id=" + prPay.String(), wantID: "[1 3]"},
+		{name: "team and user", query: "team_name=payments&user_id=" + bob.String(), wantID: "[3]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &fakeService{events: events}
+			broker := &fakeBroker{live: events}
+
+			// Фильтр из запроса применяется и к истории, и к подписке.
+			replayed := stream(t, service, &fakeBroker{}, "/events/stream?last_event_id=0&"+tt.query, "")
+			live := stream(t, &fakeService{}, broker, "/events/stream?"+tt.query, "")
+
+			if got := fmt.Sprint(eventIDs(replayed.Body.String())); got != tt.wantID {
+				t.Errorf("replayed events = %s, want %s", got, tt.wantID)
+			}
+			if got := fmt.Sprint(eventIDs(live.Body.String())); got != tt.wantID {
+				t.Errorf("live events = %s, want %s", got, tt.wantID)
+			}
+		})
+	}
+}
+
+func TestStreamRejectsInvalidRequest(t *testing.T) {
+	for _, target := range []string{
+		"/events/stream?user_id=u1",
+		"/events/stream?pull_request_id=pr-1",
+		"/events/stream?last_event_id=-1",
+		"/events/stream?last_event_id=abc",
+	} {
+		t.Run(target, func(t *testing.T) {
+			broker := &fakeBroker{}
+			rec := stream(t, &fakeService{}, broker, target, "")
+
+			if rec.Code != http.StatusBadRequest {
+				t.Errorf("status = %d, want 400", rec.Code)
+			}
+			if broker.unsubscribed {
+				t.Error("invalid request subscribed to events")
+			}
+		})
+	}
+}
+
+func TestStreamReportsHistoryErrorBeforeStreaming(t *testing.T) {
+	service := &fakeService{err: errors.New("connection refused")}
+
+	rec := stream(t, service, &fakeBroker{}, "/events/stream", "5")
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("status = %d, want 500", rec.Code)
+	}
+	if rec.Header().Get("Content-Type") == "text/event-stream" {
+		t.Error("error response is sent as an event stream")
+	}
+}
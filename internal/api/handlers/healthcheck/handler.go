@@ -8,6 +8,7 @@ import (
 
 type Logger interface {
 	InfoContext(ctx context.Context, msg string)
+	DebugContext(ctx context.Context, msg string)
 }
 
 type Handler struct {
@@ -31,6 +32,6 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	h.logger.InfoContext(ctx, "ok")
+	h.logger.DebugContext(ctx, "ok")
 	w.WriteHeader(http.StatusOK)
 }
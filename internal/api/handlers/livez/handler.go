@@ -0,0 +1,20 @@
+package livez
+
+import (
+	"net/http"
+
+	"service-pr-reviewer-assignment/internal/generated/api/dto"
+	"service-pr-reviewer-assignment/internal/pkg/response"
+)
+
+// Handler отвечает на liveness-пробу без обращения к зависимостям и без логирования,
+// чтобы частые пробы оставались дешёвыми.
+type Handler struct{}
+
+func NewHandler() *Handler {
+	return &Handler{}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	response.OK(w, dto.LivenessResponse{Status: dto.HealthStatusOk})
+}
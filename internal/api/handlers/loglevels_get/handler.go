@@ -0,0 +1,34 @@
+package loglevels_get
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"service-pr-reviewer-assignment/internal/api/converters"
+	"service-pr-reviewer-assignment/internal/pkg/response"
+)
+
+type Logger interface {
+	InfoContext(ctx context.Context, msg string)
+	Levels() (slog.Level, map[string]slog.Level)
+}
+
+type Handler struct {
+	logger Logger
+}
+
+func NewHandler(logger Logger) *Handler {
+	return &Handler{
+		logger: logger,
+	}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	level, packageLevels := h.logger.Levels()
+
+	h.logger.InfoContext(ctx, "log levels fetched successfully")
+	response.OK(w, converters.LogLevelsToDTO(level, packageLevels))
+}
@@ -0,0 +1,78 @@
+package loglevels_set
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"service-pr-reviewer-assignment/internal/api/converters"
+	"service-pr-reviewer-assignment/internal/generated/api/dto"
+	"service-pr-reviewer-assignment/internal/pkg/response"
+	"service-pr-reviewer-assignment/internal/pkg/tenant"
+	"service-pr-reviewer-assignment/internal/service/entities"
+)
+
+type Logger interface {
+	WarnContext(ctx context.Context, msg string)
+	ErrorfContext(ctx context.Context, format string, args ...interface{})
+	LogCtx(ctx context.Context, fields ...any) context.Context
+	Levels() (slog.Level, map[string]slog.Level)
+	SetLevels(level slog.Level, packageLevels map[string]slog.Level)
+}
+
+type Handler struct {
+	logger Logger
+}
+
+func NewHandler(logger Logger) *Handler {
+	return &Handler{
+		logger: logger,
+	}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	response.HandlerFunc(h.handle).ServeHTTP(w, r)
+}
+
+func (h *Handler) handle(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+
+	// Уровни логирования общие для всего процесса, поэтому менять их могут только
+	// администраторы организации по умолчанию.
+	if orgID, _ := tenant.FromContext(ctx); orgID != entities.DefaultOrganizationID {
+		h.logger.ErrorfContext(ctx, "set log levels forbidden for org %s", orgID)
+		return &entities.ErrForbidden{Reason: "only admins of the default organization can change log levels"}
+	}
+
+	var req dto.LogLevels
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.ErrorfContext(ctx, "decode body failed: %v", err)
+		return response.DecodeBodyError(err, "decode body failed")
+	}
+
+	level, ok := converters.LogLevelFromDTO(req.Level)
+	if !ok {
+		return response.BadRequest(fmt.Sprintf("unknown log level %q", req.Level))
+	}
+
+	packageLevels := make(map[string]slog.Level, len(req.Packages))
+	for pkg, raw := range req.Packages {
+		packageLevel, ok := converters.LogLevelFromDTO(raw)
+		if !ok || pkg == "" {
+			return response.BadRequest(fmt.Sprintf("invalid log level %q for package %q", raw, pkg))
+		}
+		packageLevels[pkg] = packageLevel
+	}
+
+	h.logger.SetLevels(level, packageLevels)
+
+	// WARN, чтобы смена уровня попала в лог при любом новом уровне, кроме error.
+	ctx = h.logger.LogCtx(ctx, "log_level", req.Level, "package_log_levels", req.Packages)
+	h.logger.WarnContext(ctx, "log levels changed")
+
+	level, packageLevels = h.logger.Levels()
+	response.OK(w, converters.LogLevelsToDTO(level, packageLevels))
+	return nil
+}
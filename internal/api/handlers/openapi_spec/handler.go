@@ -0,0 +1,30 @@
+package openapi_spec
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// Handler отдаёт встроенную спецификацию OpenAPI в заранее подготовленном формате.
+type Handler struct {
+	contentType string
+	body        []byte
+}
+
+func NewHandler(contentType string, body []byte) *Handler {
+	return &Handler{
+		contentType: contentType,
+		body:        body,
+	}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", h.contentType)
+	w.Header().Set("Content-Length", strconv.Itoa(len(h.body)))
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+
+	if r.Method != http.MethodHead {
+		_, _ = w.Write(h.body)
+	}
+}
@@ -0,0 +1,68 @@
+package organization_create
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"service-pr-reviewer-assignment/internal/api/converters"
+	"service-pr-reviewer-assignment/internal/generated/api/dto"
+	"service-pr-reviewer-assignment/internal/pkg/response"
+	"service-pr-reviewer-assignment/internal/service/entities"
+)
+
+type Logger interface {
+	InfoContext(ctx context.Context, msg string)
+	ErrorfContext(ctx context.Context, format string, args ...interface{})
+	LogCtx(ctx context.Context, fields ...any) context.Context
+}
+
+type Service interface {
+	CreateOrganization(
+		ctx context.Context,
+		name string,
+	) (organization *entities.Organization, key *entities.APIKey, secret string, err error)
+}
+
+type Handler struct {
+	logger  Logger
+	service Service
+}
+
+func NewHandler(logger Logger, service Service) *Handler {
+	return &Handler{
+		logger:  logger,
+		service: service,
+	}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	response.HandlerFunc(h.handle).ServeHTTP(w, r)
+}
+
+func (h *Handler) handle(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+
+	var req dto.CreateOrganizationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.ErrorfContext(ctx, "decode body failed: %v", err)
+		return response.DecodeBodyError(err, "decode body failed")
+	}
+
+	ctx = h.logger.LogCtx(ctx, "organization_name", req.Name)
+
+	organization, key, secret, err := h.service.CreateOrganization(ctx, req.Name)
+	if err != nil {
+		h.logger.ErrorfContext(ctx, "create organization failed: %v", err)
+		return err
+	}
+
+	ctx = h.logger.LogCtx(ctx, "created_org_id", organization.ID)
+	h.logger.InfoContext(ctx, "organization created successfully")
+	response.OK(w, dto.CreateOrganizationResponse{
+		Organization: converters.OrganizationToDTO(organization),
+		ApiKey:       converters.APIKeyToDTO(key),
+		Key:          secret,
+	})
+	return nil
+}
@@ -3,7 +3,6 @@ package pullrequest_create
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"net/http"
 
 	"service-pr-reviewer-assignment/internal/api/converters"
@@ -11,6 +10,7 @@ import (
 	"service-pr-reviewer-assignment/internal/pkg/response"
 	"service-pr-reviewer-assignment/internal/service/entities"
 
+	"github.com/AlekSi/pointer"
 	"github.com/google/uuid"
 )
 
@@ -26,6 +26,7 @@ type Service interface {
 		pullRequestID uuid.UUID,
 		pullRequestName string,
 		authorID uuid.UUID,
+		returnExisting bool,
 	) (pr *entities.PullRequest, reviewerIDs []uuid.UUID, err error)
 }
 
@@ -42,51 +43,38 @@ func NewHandler(logger Logger, service Service) *Handler {
 }
 
 func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	response.HandlerFunc(h.handle).ServeHTTP(w, r)
+}
+
+func (h *Handler) handle(w http.ResponseWriter, r *http.Request) error {
 	ctx := r.Context()
 
 	var req dto.CreatePullRequestRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		h.logger.ErrorfContext(ctx, "decode body failed: %v", err)
-		response.Error(w, http.StatusBadRequest, dto.BADREQUEST, "decode body failed"+err.Error())
-		return
+		return response.DecodeBodyError(err, "decode body failed: "+err.Error())
 	}
 
 	ctx = h.logger.LogCtx(ctx,
 		"pull_request_id", req.PullRequestId,
 		"pull_request_name", req.PullRequestName,
 		"author_id", req.AuthorId,
+		"return_existing", pointer.Get(req.ReturnExisting),
 	)
 
 	prID := req.PullRequestId
 	authorID := req.AuthorId
 
-	pullRequest, reviewerIDs, err := h.service.CreatePullRequestAndAssignReviewers(ctx, prID, req.PullRequestName, authorID)
+	pullRequest, reviewerIDs, err := h.service.CreatePullRequestAndAssignReviewers(
+		ctx, prID, req.PullRequestName, authorID, pointer.Get(req.ReturnExisting),
+	)
 	if err != nil {
 		h.logger.ErrorfContext(ctx, "create pull request failed: %v", err)
-
-		var prExists *entities.ErrPullRequestAlreadyExists
-		if errors.As(err, &prExists) {
-			response.Error(w, http.StatusConflict, dto.PREXISTS, prExists.Error())
-			return
-		}
-
-		var userNotFound *entities.ErrUserNotFound
-		if errors.As(err, &userNotFound) {
-			response.Error(w, http.StatusNotFound, dto.NOTFOUND, userNotFound.Error())
-			return
-		}
-
-		var pullRequestNameValidation *entities.ErrPullRequestNameValidation
-		if errors.As(err, &pullRequestNameValidation) {
-			response.Error(w, http.StatusNotFound, dto.BADREQUEST, pullRequestNameValidation.Error())
-			return
-		}
-
-		response.Error(w, http.StatusInternalServerError, dto.INTERNALERROR, "internal server error")
-		return
+		return err
 	}
 
 	h.logger.InfoContext(ctx, "pull request created successfully")
 	resp := converters.PullRequestToDTO(pullRequest, reviewerIDs)
 	response.OK(w, resp)
+	return nil
 }
@@ -2,11 +2,10 @@ package pullrequest_merge
 
 import (
 	"context"
-	"encoding/json"
-	"errors"
 	"net/http"
 
 	"service-pr-reviewer-assignment/internal/api/converters"
+	"service-pr-reviewer-assignment/internal/api/path_params"
 	"service-pr-reviewer-assignment/internal/generated/api/dto"
 	"service-pr-reviewer-assignment/internal/pkg/response"
 	"service-pr-reviewer-assignment/internal/service/entities"
@@ -30,23 +29,40 @@ type Service interface {
 type Handler struct {
 	logger  Logger
 	service Service
+	decode  path_params.Decoder[dto.MergePullRequestRequest]
 }
 
+// NewHandler обрабатывает устаревший маршрут POST /pullRequest/merge.
 func NewHandler(logger Logger, service Service) *Handler {
 	return &Handler{
 		logger:  logger,
 		service: service,
+		decode:  path_params.JSON[dto.MergePullRequestRequest],
+	}
+}
+
+// NewResourceHandler обрабатывает маршрут POST /api/v1/pull-requests/{id}/merge.
+func NewResourceHandler(logger Logger, service Service) *Handler {
+	return &Handler{
+		logger:  logger,
+		service: service,
+		decode: path_params.UUIDInto("id", func(id uuid.UUID) dto.MergePullRequestRequest {
+			return dto.MergePullRequestRequest{PullRequestId: id}
+		}),
 	}
 }
 
 func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	response.HandlerFunc(h.handle).ServeHTTP(w, r)
+}
+
+func (h *Handler) handle(w http.ResponseWriter, r *http.Request) error {
 	ctx := r.Context()
 
-	var req dto.MergePullRequestRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.logger.ErrorfContext(ctx, "decode body failed: %v", err)
-		response.Error(w, http.StatusBadRequest, dto.BADREQUEST, "decode body failed"+err.Error())
-		return
+	req, err := h.decode(r)
+	if err != nil {
+		h.logger.ErrorfContext(ctx, "decode request failed: %v", err)
+		return err
 	}
 
 	ctx = h.logger.LogCtx(ctx,
@@ -58,18 +74,11 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	pullRequest, reviewerIDs, err := h.service.MergePullRequestAndGetReviewers(ctx, prID)
 	if err != nil {
 		h.logger.ErrorfContext(ctx, "merge pull request failed: %v", err)
-
-		var prNotFound *entities.ErrPullRequestNotFound
-		if errors.As(err, &prNotFound) {
-			response.Error(w, http.StatusNotFound, dto.NOTFOUND, prNotFound.Error())
-			return
-		}
-
-		response.Error(w, http.StatusInternalServerError, dto.INTERNALERROR, "internal server error")
-		return
+		return err
 	}
 
 	h.logger.InfoContext(ctx, "pull request merged successfully")
 	resp := converters.PullRequestToDTO(pullRequest, reviewerIDs)
 	response.OK(w, resp)
+	return nil
 }
@@ -2,11 +2,10 @@ package pullrequest_reassign
 
 import (
 	"context"
-	"encoding/json"
-	"errors"
 	"net/http"
 
 	"service-pr-reviewer-assignment/internal/api/converters"
+	"service-pr-reviewer-assignment/internal/api/path_params"
 	"service-pr-reviewer-assignment/internal/generated/api/dto"
 	"service-pr-reviewer-assignment/internal/pkg/response"
 	"service-pr-reviewer-assignment/internal/service/entities"
@@ -25,75 +24,62 @@ type Service interface {
 		ctx context.Context,
 		pullRequestID uuid.UUID,
 		oldReviewerID uuid.UUID,
+		requestedReviewerID *uuid.UUID,
 	) (pr *entities.PullRequest, prReviewerIDs []uuid.UUID, newReviewerID uuid.UUID, err error)
 }
 
 type Handler struct {
 	logger  Logger
 	service Service
+	decode  path_params.Decoder[dto.ReassignPullRequestRequest]
 }
 
+// NewHandler обрабатывает устаревший маршрут POST /pullRequest/reassign.
 func NewHandler(logger Logger, service Service) *Handler {
 	return &Handler{
 		logger:  logger,
 		service: service,
+		decode:  path_params.JSON[dto.ReassignPullRequestRequest],
+	}
+}
+
+// NewResourceHandler обрабатывает маршрут POST /api/v1/pull-requests/{id}/reassign.
+func NewResourceHandler(logger Logger, service Service) *Handler {
+	return &Handler{
+		logger:  logger,
+		service: service,
+		decode: path_params.UUIDWithJSON("id", func(id uuid.UUID, body dto.ReassignReviewerBody) dto.ReassignPullRequestRequest {
+			return dto.ReassignPullRequestRequest{PullRequestId: id, OldUserId: body.OldUserId, NewUserId: body.NewUserId}
+		}),
 	}
 }
 
 func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	response.HandlerFunc(h.handle).ServeHTTP(w, r)
+}
+
+func (h *Handler) handle(w http.ResponseWriter, r *http.Request) error {
 	ctx := r.Context()
 
-	var req dto.ReassignPullRequestRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.logger.ErrorfContext(ctx, "decode body failed: %v", err)
-		response.Error(w, http.StatusBadRequest, dto.BADREQUEST, "decode body failed"+err.Error())
-		return
+	req, err := h.decode(r)
+	if err != nil {
+		h.logger.ErrorfContext(ctx, "decode request failed: %v", err)
+		return err
 	}
 
 	ctx = h.logger.LogCtx(ctx,
 		"pull_request_id", req.PullRequestId,
 		"old_user_id", req.OldUserId,
+		"requested_user_id", req.NewUserId,
 	)
 
 	prID := req.PullRequestId
 	oldReviewerID := req.OldUserId
 
-	pullRequest, prReviewerIDs, newReviewerID, err := h.service.ReassignReviewer(ctx, prID, oldReviewerID)
+	pullRequest, prReviewerIDs, newReviewerID, err := h.service.ReassignReviewer(ctx, prID, oldReviewerID, req.NewUserId)
 	if err != nil {
 		h.logger.ErrorfContext(ctx, "reassign reviewer failed: %v", err)
-
-		var prNotFound *entities.ErrPullRequestNotFound
-		if errors.As(err, &prNotFound) {
-			response.Error(w, http.StatusNotFound, dto.NOTFOUND, prNotFound.Error())
-			return
-		}
-
-		var userNotFound *entities.ErrUserNotFound
-		if errors.As(err, &userNotFound) {
-			response.Error(w, http.StatusNotFound, dto.NOTFOUND, userNotFound.Error())
-			return
-		}
-
-		var prMerged *entities.ErrPullRequestAlreadyMerged
-		if errors.As(err, &prMerged) {
-			response.Error(w, http.StatusConflict, dto.PRMERGED, prMerged.Error())
-			return
-		}
-
-		var notAssigned *entities.ErrReviewerNotAssigned
-		if errors.As(err, &notAssigned) {
-			response.Error(w, http.StatusConflict, dto.NOTASSIGNED, notAssigned.Error())
-			return
-		}
-
-		noReplacement := entities.ErrNoReplacementCandidate
-		if errors.Is(err, noReplacement) {
-			response.Error(w, http.StatusConflict, dto.NOCANDIDATE, noReplacement.Error())
-			return
-		}
-
-		response.Error(w, http.StatusInternalServerError, dto.INTERNALERROR, "internal server error")
-		return
+		return err
 	}
 
 	ctx = h.logger.LogCtx(ctx,
@@ -103,4 +89,5 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	h.logger.InfoContext(ctx, "reviewer reassigned successfully")
 	resp := converters.ReassignResponseToDTO(pullRequest, prReviewerIDs, newReviewerID)
 	response.OK(w, resp)
+	return nil
 }
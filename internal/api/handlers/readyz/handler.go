@@ -0,0 +1,106 @@
+package readyz
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"service-pr-reviewer-assignment/internal/generated/api/dto"
+
+	"github.com/AlekSi/pointer"
+)
+
+// checkTimeout ограничивает одну проверку, чтобы зависшая зависимость не задерживала ответ.
+var checkTimeout = 2 * time.Second
+
+type Logger interface {
+	WarnfContext(ctx context.Context, format string, args ...any)
+}
+
+// Check проверка одной зависимости.
+type Check struct {
+	Name string
+	Func func(ctx context.Context) error
+}
+
+type Handler struct {
+	isShuttingDown *atomic.Bool
+	logger         Logger
+	checks         []Check
+}
+
+func NewHandler(isShuttingDown *atomic.Bool, logger Logger, checks []Check) *Handler {
+	return &Handler{
+		isShuttingDown: isShuttingDown,
+		logger:         logger,
+		checks:         checks,
+	}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	resp := dto.ReadinessResponse{
+		Status: dto.HealthStatusOk,
+		Checks: make(map[string]dto.ReadinessCheck, len(h.checks)+1),
+	}
+
+	if h.isShuttingDown.Load() {
+		resp.Checks["shutdown"] = dto.ReadinessCheck{
+			Status: dto.HealthStatusFail,
+			Error:  pointer.To("service is shutting down"),
+		}
+	}
+
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+	for _, check := range h.checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result := run(ctx, check)
+
+			mu.Lock()
+			resp.Checks[check.Name] = result
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	status := http.StatusOK
+	for name, check := range resp.Checks {
+		if check.Status == dto.HealthStatusFail {
+			h.logger.WarnfContext(ctx, "readiness check %s failed: %s", name, *check.Error)
+			resp.Status = dto.HealthStatusFail
+			status = http.StatusServiceUnavailable
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func run(ctx context.Context, check Check) dto.ReadinessCheck {
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+
+	start := time.Now()
+	err := check.Func(ctx)
+	result := dto.ReadinessCheck{
+		Status:     dto.HealthStatusOk,
+		DurationMs: time.Since(start).Milliseconds(),
+	}
+	if err != nil {
+		result.Status = dto.HealthStatusFail
+		result.Error = pointer.To(err.Error())
+	}
+
+	return result
+}
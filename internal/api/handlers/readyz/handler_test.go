@@ -0,0 +1,107 @@
+package readyz
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"service-pr-reviewer-assignment/internal/generated/api/dto"
+)
+
+type noopLogger struct{}
+
+func (noopLogger) WarnfContext(context.Context, string, ...any) {}
+
+func serve(t *testing.T, shuttingDown bool, checks ...Check) (int, dto.ReadinessResponse) {
+	t.Helper()
+
+	var isShuttingDown atomic.Bool
+	isShuttingDown.Store(shuttingDown)
+
+	rec := httptest.NewRecorder()
+	NewHandler(&isShuttingDown, noopLogger{}, checks).
+		ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+	var resp dto.ReadinessResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatal(err)
+	}
+	if got := rec.Header().Get("Cache-Control"); got != "no-store" {
+		t.Errorf("Cache-Control = %q, want no-store", got)
+	}
+	return rec.Code, resp
+}
+
+func ok(context.Context) error { return nil }
+
+func TestHandlerReady(t *testing.T) {
+	code, resp := serve(t, false, Check{Name: "postgres", Func: ok}, Check{Name: "migrations", Func: ok})
+
+	if code != http.StatusOK || resp.Status != dto.HealthStatusOk {
+		t.Fatalf("status = %d %s, want 200 ok", code, resp.Status)
+	}
+	if len(resp.Checks) != 2 {
+		t.Errorf("checks = %v, want postgres and migrations", resp.Checks)
+	}
+}
+
+func TestHandlerFailedCheck(t *testing.T) {
+	code, resp := serve(t, false,
+		Check{Name: "postgres", Func: ok},
+		Check{Name: "migrations", Func: func(context.Context) error {
+			return errors.New("migration 42 is not applied")
+		}},
+	)
+
+	if code != http.StatusServiceUnavailable || resp.Status != dto.HealthStatusFail {
+		t.Fatalf("status = %d %s, want 503 fail", code, resp.Status)
+	}
+	if got := resp.Checks["postgres"].Status; got != dto.HealthStatusOk {
+		t.Errorf("postgres = %s, want ok", got)
+	}
+	failed := resp.Checks["migrations"]
+	if failed.Status != dto.HealthStatusFail || failed.Error == nil || *failed.Error != "migration 42 is not applied" {
+		t.Errorf("migrations = %+v", failed)
+	}
+}
+
+func TestHandlerCheckTimeout(t *testing.T) {
+	prev := checkTimeout
+	checkTimeout = 20 * time.Millisecond
+	t.Cleanup(func() { checkTimeout = prev })
+
+	hanging := Check{Name: "postgres", Func: func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}}
+
+	start := time.Now()
+	code, resp := serve(t, false, hanging)
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("response took %s, want it bounded by the check timeout", elapsed)
+	}
+	if code != http.StatusServiceUnavailable {
+		t.Fatalf("status = %d, want 503", code)
+	}
+	check := resp.Checks["postgres"]
+	if check.Error == nil || *check.Error != context.DeadlineExceeded.Error() {
+		t.Errorf("postgres = %+v, want a deadline error", check)
+	}
+}
+
+func TestHandlerShuttingDown(t *testing.T) {
+	code, resp := serve(t, true, Check{Name: "postgres", Func: ok})
+
+	if code != http.StatusServiceUnavailable {
+		t.Fatalf("status = %d, want 503", code)
+	}
+	if got := resp.Checks["shutdown"].Status; got != dto.HealthStatusFail {
+		t.Errorf("shutdown = %s, want fail", got)
+	}
+}
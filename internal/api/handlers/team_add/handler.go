@@ -3,7 +3,6 @@ package team_add
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"net/http"
 
 	"service-pr-reviewer-assignment/internal/api/converters"
@@ -39,13 +38,16 @@ func NewHandler(logger Logger, service Service) *Handler {
 }
 
 func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	response.HandlerFunc(h.handle).ServeHTTP(w, r)
+}
+
+func (h *Handler) handle(w http.ResponseWriter, r *http.Request) error {
 	ctx := r.Context()
 
 	var req dto.Team
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		h.logger.ErrorfContext(ctx, "decode body failed: %v", err)
-		response.Error(w, http.StatusBadRequest, dto.BADREQUEST, "decode body failed"+err.Error())
-		return
+		return response.DecodeBodyError(err, "decode body failed: "+err.Error())
 	}
 
 	ctx = h.logger.LogCtx(ctx,
@@ -56,37 +58,17 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	teamName, users, err := converters.TeamFromDTO(req)
 	if err != nil {
 		h.logger.ErrorfContext(ctx, "convert dto to entities failed: %v", err)
-		response.Error(w, http.StatusBadRequest, dto.BADREQUEST, "invalid team data")
-		return
+		return response.BadRequest("invalid team data")
 	}
 
 	team, err := h.service.CreateTeam(ctx, teamName, users)
 	if err != nil {
 		h.logger.ErrorfContext(ctx, "create team failed: %v", err)
-
-		var dupIDs *entities.ErrDuplicateUserIDs
-		if errors.As(err, &dupIDs) {
-			response.Error(w, http.StatusBadRequest, dto.DUPLICATEUSERID, dupIDs.Error())
-			return
-		}
-
-		var teamExists *entities.ErrTeamAlreadyExists
-		if errors.As(err, &teamExists) {
-			response.Error(w, http.StatusConflict, dto.TEAMEXISTS, teamExists.Error())
-			return
-		}
-
-		var userNameValidation *entities.ErrUserNameValidation
-		if errors.As(err, &userNameValidation) {
-			response.Error(w, http.StatusNotFound, dto.BADREQUEST, userNameValidation.Error())
-			return
-		}
-
-		response.Error(w, http.StatusInternalServerError, dto.INTERNALERROR, "internal server error")
-		return
+		return err
 	}
 
 	h.logger.InfoContext(ctx, "team created successfully")
 	resp := converters.TeamToDTO(team)
 	response.OK(w, resp)
+	return nil
 }
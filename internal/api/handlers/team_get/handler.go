@@ -2,11 +2,10 @@ package team_get
 
 import (
 	"context"
-	"errors"
 	"net/http"
 
 	"service-pr-reviewer-assignment/internal/api/converters"
-	"service-pr-reviewer-assignment/internal/generated/api/dto"
+	"service-pr-reviewer-assignment/internal/api/path_params"
 	"service-pr-reviewer-assignment/internal/pkg/response"
 	"service-pr-reviewer-assignment/internal/service/entities"
 )
@@ -27,23 +26,38 @@ type Service interface {
 type Handler struct {
 	logger  Logger
 	service Service
+	decode  path_params.Decoder[string]
 }
 
+// NewHandler обрабатывает устаревший маршрут GET /team/get?team_name=.
 func NewHandler(logger Logger, service Service) *Handler {
 	return &Handler{
 		logger:  logger,
 		service: service,
+		decode:  teamNameFromQuery,
+	}
+}
+
+// NewResourceHandler обрабатывает маршрут GET /api/v1/teams/{name}.
+func NewResourceHandler(logger Logger, service Service) *Handler {
+	return &Handler{
+		logger:  logger,
+		service: service,
+		decode:  teamNameFromPath,
 	}
 }
 
 func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	response.HandlerFunc(h.handle).ServeHTTP(w, r)
+}
+
+func (h *Handler) handle(w http.ResponseWriter, r *http.Request) error {
 	ctx := r.Context()
 
-	teamName := r.URL.Query().Get("team_name")
-	if teamName == "" {
-		h.logger.ErrorfContext(ctx, "team_name parameter is required")
-		response.Error(w, http.StatusBadRequest, dto.BADREQUEST, "team_name parameter is required")
-		return
+	teamName, err := h.decode(r)
+	if err != nil {
+		h.logger.ErrorfContext(ctx, "decode request failed: %v", err)
+		return err
 	}
 
 	ctx = h.logger.LogCtx(ctx, "team_name", teamName)
@@ -51,18 +65,23 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	team, err := h.service.GetTeam(ctx, teamName)
 	if err != nil {
 		h.logger.ErrorfContext(ctx, "get team failed: %v", err)
-
-		var notFoundErr *entities.ErrTeamNotFound
-		if errors.As(err, &notFoundErr) {
-			response.Error(w, http.StatusNotFound, dto.NOTFOUND, notFoundErr.Error())
-			return
-		}
-
-		response.Error(w, http.StatusInternalServerError, dto.INTERNALERROR, "internal server error")
-		return
+		return err
 	}
 
 	h.logger.InfoContext(ctx, "team retrieved successfully")
 	resp := converters.TeamToDTO(team)
 	response.OK(w, resp)
+	return nil
+}
+
+func teamNameFromQuery(r *http.Request) (string, error) {
+	teamName := r.URL.Query().Get("team_name")
+	if teamName == "" {
+		return "", response.BadRequest("team_name parameter is required")
+	}
+	return teamName, nil
+}
+
+func teamNameFromPath(r *http.Request) (string, error) {
+	return path_params.String(r, "name")
 }
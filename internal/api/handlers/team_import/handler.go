@@ -0,0 +1,118 @@
+package team_import
+
+import (
+	"context"
+	"errors"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"service-pr-reviewer-assignment/internal/api/converters"
+	"service-pr-reviewer-assignment/internal/pkg/response"
+	"service-pr-reviewer-assignment/internal/service/entities"
+)
+
+type Logger interface {
+	InfoContext(ctx context.Context, msg string)
+	ErrorfContext(ctx context.Context, format string, args ...interface{})
+	LogCtx(ctx context.Context, fields ...any) context.Context
+}
+
+type Service interface {
+	ImportTeams(
+		ctx context.Context,
+		rows []entities.TeamImportRow,
+		opts entities.TeamImportOptions,
+	) (*entities.TeamImportResult, error)
+}
+
+type Handler struct {
+	logger  Logger
+	service Service
+}
+
+// NewHandler обрабатывает маршруты POST /api/v1/teams/import и /team/import.
+func NewHandler(logger Logger, service Service) *Handler {
+	return &Handler{
+		logger:  logger,
+		service: service,
+	}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	response.HandlerFunc(h.handle).ServeHTTP(w, r)
+}
+
+func (h *Handler) handle(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+
+	opts, err := decodeOptions(r)
+	if err != nil {
+		h.logger.ErrorfContext(ctx, "decode query failed: %v", err)
+		return err
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+
+	ctx = h.logger.LogCtx(ctx,
+		"content_type", mediaType,
+		"dry_run", opts.DryRun,
+		"upsert", opts.Upsert,
+	)
+
+	var rows []entities.TeamImportRow
+	switch mediaType {
+	case "text/csv":
+		rows, err = converters.TeamImportFromCSV(r.Body)
+	case "application/yaml", "application/x-yaml":
+		rows, err = converters.TeamImportFromYAML(r.Body)
+	default:
+		h.logger.ErrorfContext(ctx, "unsupported content type %q", mediaType)
+		return response.BadRequest("content type must be text/csv or application/yaml")
+	}
+	if err != nil {
+		h.logger.ErrorfContext(ctx, "parse import file failed: %v", err)
+		var invalid *entities.ErrTeamImportValidation
+		if errors.As(err, &invalid) {
+			return err
+		}
+		return response.DecodeBodyError(err, "parse import file failed: "+err.Error())
+	}
+
+	result, err := h.service.ImportTeams(ctx, rows, opts)
+	if err != nil {
+		h.logger.ErrorfContext(ctx, "import teams failed: %v", err)
+		return err
+	}
+
+	h.logger.InfoContext(ctx, "teams imported successfully")
+	response.OK(w, converters.TeamImportResultToDTO(result))
+	return nil
+}
+
+func decodeOptions(r *http.Request) (entities.TeamImportOptions, error) {
+	var opts entities.TeamImportOptions
+	var err error
+
+	if opts.DryRun, err = queryBool(r, "dry_run"); err != nil {
+		return opts, err
+	}
+	if opts.Upsert, err = queryBool(r, "upsert"); err != nil {
+		return opts, err
+	}
+
+	return opts, nil
+}
+
+func queryBool(r *http.Request, name string) (bool, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return false, nil
+	}
+
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, response.BadRequest("invalid " + name + " value")
+	}
+	return parsed, nil
+}
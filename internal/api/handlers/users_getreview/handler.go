@@ -2,11 +2,10 @@ package users_getreview
 
 import (
 	"context"
-	"errors"
 	"net/http"
 
 	"service-pr-reviewer-assignment/internal/api/converters"
-	"service-pr-reviewer-assignment/internal/generated/api/dto"
+	"service-pr-reviewer-assignment/internal/api/path_params"
 	"service-pr-reviewer-assignment/internal/pkg/response"
 	"service-pr-reviewer-assignment/internal/service/entities"
 
@@ -29,49 +28,67 @@ type Service interface {
 type Handler struct {
 	logger  Logger
 	service Service
+	decode  path_params.Decoder[uuid.UUID]
 }
 
+// NewHandler обрабатывает устаревший маршрут GET /users/getReview?user_id=.
 func NewHandler(logger Logger, service Service) *Handler {
 	return &Handler{
 		logger:  logger,
 		service: service,
+		decode:  userIDFromQuery,
 	}
 }
 
-func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
-
-	userIDStr := r.URL.Query().Get("user_id")
-	if userIDStr == "" {
-		h.logger.ErrorfContext(ctx, "user_id parameter is required")
-		response.Error(w, http.StatusBadRequest, dto.BADREQUEST, "user_id parameter is required")
-		return
+// NewResourceHandler обрабатывает маршрут GET /api/v1/users/{id}/reviews.
+func NewResourceHandler(logger Logger, service Service) *Handler {
+	return &Handler{
+		logger:  logger,
+		service: service,
+		decode:  userIDFromPath,
 	}
+}
 
-	ctx = h.logger.LogCtx(ctx, "user_id", userIDStr)
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	response.HandlerFunc(h.handle).ServeHTTP(w, r)
+}
 
-	userID, err := uuid.Parse(userIDStr)
+func (h *Handler) handle(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+
+	userID, err := h.decode(r)
 	if err != nil {
-		h.logger.ErrorfContext(ctx, "failed to parse user_id as uuid: %v", err)
-		response.Error(w, http.StatusBadRequest, dto.BADREQUEST, "invalid user_id format")
-		return
+		h.logger.ErrorfContext(ctx, "decode request failed: %v", err)
+		return err
 	}
 
+	ctx = h.logger.LogCtx(ctx, "user_id", userID)
+
 	pullRequests, err := h.service.GetUserPullRequestReviewRequests(ctx, userID)
 	if err != nil {
 		h.logger.ErrorfContext(ctx, "get user review requests failed: %v", err)
-
-		var notFoundErr *entities.ErrUserNotFound
-		if errors.As(err, &notFoundErr) {
-			response.Error(w, http.StatusNotFound, dto.NOTFOUND, notFoundErr.Error())
-			return
-		}
-
-		response.Error(w, http.StatusInternalServerError, dto.INTERNALERROR, "internal server error")
-		return
+		return err
 	}
 
 	h.logger.InfoContext(ctx, "user review requests retrieved successfully")
 	resp := converters.UserReviewRequestsToDTO(userID, pullRequests)
 	response.OK(w, resp)
+	return nil
+}
+
+func userIDFromQuery(r *http.Request) (uuid.UUID, error) {
+	userIDStr := r.URL.Query().Get("user_id")
+	if userIDStr == "" {
+		return uuid.Nil, response.BadRequest("user_id parameter is required")
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return uuid.Nil, response.BadRequest("invalid user_id format")
+	}
+	return userID, nil
+}
+
+func userIDFromPath(r *http.Request) (uuid.UUID, error) {
+	return path_params.UUID(r, "id")
 }
@@ -2,11 +2,10 @@ package users_setisactive
 
 import (
 	"context"
-	"encoding/json"
-	"errors"
 	"net/http"
 
 	"service-pr-reviewer-assignment/internal/api/converters"
+	"service-pr-reviewer-assignment/internal/api/path_params"
 	"service-pr-reviewer-assignment/internal/generated/api/dto"
 	"service-pr-reviewer-assignment/internal/pkg/response"
 	"service-pr-reviewer-assignment/internal/service/entities"
@@ -31,23 +30,40 @@ type Service interface {
 type Handler struct {
 	logger  Logger
 	service Service
+	decode  path_params.Decoder[dto.SetUserActiveRequest]
 }
 
+// NewHandler обрабатывает устаревший маршрут POST /users/setIsActive.
 func NewHandler(logger Logger, service Service) *Handler {
 	return &Handler{
 		logger:  logger,
 		service: service,
+		decode:  path_params.JSON[dto.SetUserActiveRequest],
+	}
+}
+
+// NewResourceHandler обрабатывает маршрут PUT /api/v1/users/{id}/active.
+func NewResourceHandler(logger Logger, service Service) *Handler {
+	return &Handler{
+		logger:  logger,
+		service: service,
+		decode: path_params.UUIDWithJSON("id", func(id uuid.UUID, body dto.SetUserActiveBody) dto.SetUserActiveRequest {
+			return dto.SetUserActiveRequest{UserId: id, IsActive: body.IsActive}
+		}),
 	}
 }
 
 func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	response.HandlerFunc(h.handle).ServeHTTP(w, r)
+}
+
+func (h *Handler) handle(w http.ResponseWriter, r *http.Request) error {
 	ctx := r.Context()
 
-	var req dto.SetUserActiveRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.logger.ErrorfContext(ctx, "decode body failed: %v", err)
-		response.Error(w, http.StatusBadRequest, dto.BADREQUEST, "decode body failed")
-		return
+	req, err := h.decode(r)
+	if err != nil {
+		h.logger.ErrorfContext(ctx, "decode request failed: %v", err)
+		return err
 	}
 
 	ctx = h.logger.LogCtx(ctx,
@@ -60,18 +76,11 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	user, err := h.service.SetUserActiveStatus(ctx, userID, req.IsActive)
 	if err != nil {
 		h.logger.ErrorfContext(ctx, "set user active status failed: %v", err)
-
-		var userNotFound *entities.ErrUserNotFound
-		if errors.As(err, &userNotFound) {
-			response.Error(w, http.StatusNotFound, dto.NOTFOUND, userNotFound.Error())
-			return
-		}
-
-		response.Error(w, http.StatusInternalServerError, dto.INTERNALERROR, "internal server error")
-		return
+		return err
 	}
 
 	h.logger.InfoContext(ctx, "user active status updated successfully")
 	resp := converters.UserToDTO(user)
 	response.OK(w, resp)
+	return nil
 }
@@ -0,0 +1,84 @@
+package users_setrole
+
+import (
+	"context"
+	"net/http"
+
+	"service-pr-reviewer-assignment/internal/api/converters"
+	"service-pr-reviewer-assignment/internal/api/path_params"
+	"service-pr-reviewer-assignment/internal/generated/api/dto"
+	"service-pr-reviewer-assignment/internal/pkg/response"
+	"service-pr-reviewer-assignment/internal/service/entities"
+
+	"github.com/google/uuid"
+)
+
+type Logger interface {
+	InfoContext(ctx context.Context, msg string)
+	ErrorfContext(ctx context.Context, format string, args ...interface{})
+	LogCtx(ctx context.Context, fields ...any) context.Context
+}
+
+type Service interface {
+	SetUserRole(
+		ctx context.Context,
+		userID uuid.UUID,
+		role entities.UserRole,
+	) (user *entities.User, err error)
+}
+
+type Handler struct {
+	logger  Logger
+	service Service
+	decode  path_params.Decoder[dto.SetUserRoleRequest]
+}
+
+// NewHandler обрабатывает устаревший маршрут POST /users/setRole.
+func NewHandler(logger Logger, service Service) *Handler {
+	return &Handler{
+		logger:  logger,
+		service: service,
+		decode:  path_params.JSON[dto.SetUserRoleRequest],
+	}
+}
+
+// NewResourceHandler обрабатывает маршрут PUT /api/v1/users/{id}/role.
+func NewResourceHandler(logger Logger, service Service) *Handler {
+	return &Handler{
+		logger:  logger,
+		service: service,
+		decode: path_params.UUIDWithJSON("id", func(id uuid.UUID, body dto.SetUserRoleBody) dto.SetUserRoleRequest {
+			return dto.SetUserRoleRequest{UserId: id, Role: body.Role}
+		}),
+	}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	response.HandlerFunc(h.handle).ServeHTTP(w, r)
+}
+
+func (h *Handler) handle(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+
+	req, err := h.decode(r)
+	if err != nil {
+		h.logger.ErrorfContext(ctx, "decode request failed: %v", err)
+		return err
+	}
+
+	ctx = h.logger.LogCtx(ctx,
+		"user_id", req.UserId,
+		"role", req.Role,
+	)
+
+	user, err := h.service.SetUserRole(ctx, req.UserId, entities.UserRole(req.Role))
+	if err != nil {
+		h.logger.ErrorfContext(ctx, "set user role failed: %v", err)
+		return err
+	}
+
+	h.logger.InfoContext(ctx, "user role updated successfully")
+	resp := converters.UserToDTO(user)
+	response.OK(w, resp)
+	return nil
+}
@@ -0,0 +1,80 @@
+// Package path_params читает параметры операций из запроса: из параметров пути ресурсных
+// маршрутов /api/v1 и из тела устаревших RPC-маршрутов.
+package path_params
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"service-pr-reviewer-assignment/internal/pkg/response"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+// Decoder читает параметры операции из запроса. Обработчик операции, доступной по
+// устаревшему и ресурсному маршрутам, получает свой Decoder для каждого маршрута.
+type Decoder[T any] func(r *http.Request) (T, error)
+
+// String возвращает параметр пути name. Пустая строка — ошибка BAD_REQUEST.
+func String(r *http.Request, name string) (string, error) {
+	value := mux.Vars(r)[name]
+	if value == "" {
+		return "", response.BadRequest(fmt.Sprintf("path parameter %s is required", name))
+	}
+	return value, nil
+}
+
+// UUID возвращает параметр пути name как uuid. Невалидное значение — ошибка BAD_REQUEST.
+func UUID(r *http.Request, name string) (uuid.UUID, error) {
+	value, err := String(r, name)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return uuid.Nil, response.BadRequest(fmt.Sprintf("invalid %s format", name))
+	}
+	return id, nil
+}
+
+// JSON читает параметры операции из тела запроса, как их передают устаревшие маршруты.
+func JSON[T any](r *http.Request) (T, error) {
+	var req T
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return req, response.DecodeBodyError(err, "decode body failed: "+err.Error())
+	}
+	return req, nil
+}
+
+// UUIDInto собирает параметры операции из uuid в параметре пути name.
+func UUIDInto[T any](name string, build func(id uuid.UUID) T) Decoder[T] {
+	return func(r *http.Request) (T, error) {
+		id, err := UUID(r, name)
+		if err != nil {
+			var zero T
+			return zero, err
+		}
+		return build(id), nil
+	}
+}
+
+// UUIDWithJSON собирает параметры операции из uuid в параметре пути name и тела запроса B.
+func UUIDWithJSON[B, T any](name string, build func(id uuid.UUID, body B) T) Decoder[T] {
+	return func(r *http.Request) (T, error) {
+		var zero T
+
+		id, err := UUID(r, name)
+		if err != nil {
+			return zero, err
+		}
+
+		body, err := JSON[B](r)
+		if err != nil {
+			return zero, err
+		}
+		return build(id, body), nil
+	}
+}
@@ -0,0 +1,87 @@
+package path_params
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"service-pr-reviewer-assignment/internal/pkg/response"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+type renameBody struct {
+	Name string `json:"name"`
+}
+
+type renameRequest struct {
+	ID   uuid.UUID `json:"id"`
+	Name string    `json:"name"`
+}
+
+// request собирает запрос с параметром пути id, как его передаёт mux.
+func request(id, body string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/items/"+id, strings.NewReader(body))
+	return mux.SetURLVars(req, map[string]string{"id": id})
+}
+
+func assertStatus(t *testing.T, err error, want int) {
+	t.Helper()
+
+	classified, ok := response.Classify(err)
+	if !ok {
+		t.Fatalf("err = %v is not classified", err)
+	}
+	if classified.Status != want {
+		t.Errorf("status = %d, want %d", classified.Status, want)
+	}
+}
+
+func TestUUIDWithJSON(t *testing.T) {
+	decode := UUIDWithJSON("id", func(id uuid.UUID, body renameBody) renameRequest {
+		return renameRequest{ID: id, Name: body.Name}
+	})
+	id := uuid.New()
+
+	req, err := decode(request(id.String(), `{"name":"payments"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if req.ID != id || req.Name != "payments" {
+		t.Errorf("request = %+v", req)
+	}
+
+	_, err = decode(request("not-a-uuid", `{"name":"payments"}`))
+	assertStatus(t, err, http.StatusBadRequest)
+
+	_, err = decode(request(id.String(), `{"name":`))
+	assertStatus(t, err, http.StatusBadRequest)
+}
+
+func TestUUIDInto(t *testing.T) {
+	decode := UUIDInto("id", func(id uuid.UUID) renameRequest { return renameRequest{ID: id} })
+	id := uuid.New()
+
+	req, err := decode(request(id.String(), ""))
+	if err != nil || req.ID != id {
+		t.Errorf("request = %+v, err = %v", req, err)
+	}
+
+	_, err = decode(request("", ""))
+	assertStatus(t, err, http.StatusBadRequest)
+}
+
+func TestJSON(t *testing.T) {
+	id := uuid.New()
+	req, err := JSON[renameRequest](request("", `{"id":"`+id.String()+`","name":"payments"}`))
+	if err != nil || req.ID != id || req.Name != "payments" {
+		t.Errorf("request = %+v, err = %v", req, err)
+	}
+
+	tooLarge := request("", `{"name":"payments"}`)
+	tooLarge.Body = http.MaxBytesReader(httptest.NewRecorder(), tooLarge.Body, 4)
+	_, err = JSON[renameRequest](tooLarge)
+	assertStatus(t, err, http.StatusRequestEntityTooLarge)
+}
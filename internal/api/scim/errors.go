@@ -0,0 +1,77 @@
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"service-pr-reviewer-assignment/internal/generated/api/dto"
+	"service-pr-reviewer-assignment/internal/pkg/response"
+)
+
+// Значения scimType ошибок (RFC 7644, раздел 3.12).
+const (
+	scimTypeInvalidFilter = "invalidFilter"
+	scimTypeInvalidSyntax = "invalidSyntax"
+	scimTypeInvalidValue  = "invalidValue"
+	scimTypeInvalidPath   = "invalidPath"
+	scimTypeMutability    = "mutability"
+	scimTypeUniqueness    = "uniqueness"
+)
+
+// requestError ошибка запроса SCIM, которую обработчик возвращает сам.
+type requestError struct {
+	status   int
+	scimType string
+	detail   string
+}
+
+func (e *requestError) Error() string {
+	return e.detail
+}
+
+func badRequest(scimType, detail string) error {
+	return &requestError{status: http.StatusBadRequest, scimType: scimType, detail: detail}
+}
+
+type errorResponse struct {
+	Schemas  []string `json:"schemas"`
+	Status   string   `json:"status"`
+	SCIMType string   `json:"scimType,omitempty"`
+	Detail   string   `json:"detail,omitempty"`
+}
+
+// writeError отвечает ошибкой в формате SCIM. Доменные ошибки классифицируются
+// тем же реестром, что и в HTTP API.
+func writeError(w http.ResponseWriter, err error) {
+	body := errorResponse{Schemas: []string{schemaError}}
+	status := http.StatusInternalServerError
+
+	var reqErr *requestError
+	if errors.As(err, &reqErr) {
+		status = reqErr.status
+		body.SCIMType = reqErr.scimType
+		body.Detail = reqErr.detail
+	} else if classified, ok := response.Classify(err); ok {
+		status = classified.Status
+		body.Detail = classified.Message
+		switch {
+		case classified.Code == dto.TEAMEXISTS || classified.Code == dto.USEREXISTS:
+			body.SCIMType = scimTypeUniqueness
+		case status == http.StatusBadRequest:
+			body.SCIMType = scimTypeInvalidValue
+		}
+	} else if errors.Is(err, context.DeadlineExceeded) {
+		status = http.StatusGatewayTimeout
+		body.Detail = "request timed out"
+	} else {
+		body.Detail = "internal server error"
+	}
+
+	body.Status = strconv.Itoa(status)
+	w.Header().Set("Content-Type", ContentType)
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
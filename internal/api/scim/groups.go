@@ -0,0 +1,178 @@
+package scim
+
+import (
+	"net/http"
+
+	"service-pr-reviewer-assignment/internal/service/entities"
+
+	"github.com/gorilla/mux"
+)
+
+// ListGroups обрабатывает маршрут GET /scim/v2/Groups.
+func (h *Handler) ListGroups() http.Handler {
+	return handlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+		ctx := r.Context()
+
+		p, err := decodePage(r)
+		if err != nil {
+			return err
+		}
+		name, err := decodeFilter(r, "displayName")
+		if err != nil {
+			return err
+		}
+
+		teams, total, err := h.service.ListTeams(ctx, entities.TeamFilter{Name: name}, p.offset(), p.count)
+		if err != nil {
+			h.logger.ErrorfContext(ctx, "list teams failed: %v", err)
+			return err
+		}
+
+		resources := make([]groupResource, 0, len(teams))
+		for i := range teams {
+			resources = append(resources, teamToResource(&teams[i]))
+		}
+
+		writeResource(w, http.StatusOK, listOf(resources, total, p))
+		return nil
+	})
+}
+
+// GetGroup обрабатывает маршрут GET /scim/v2/Groups/{id}.
+func (h *Handler) GetGroup() http.Handler {
+	return handlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+		ctx := r.Context()
+
+		team, err := h.service.GetTeam(ctx, mux.Vars(r)["id"])
+		if err != nil {
+			h.logger.ErrorfContext(ctx, "get team failed: %v", err)
+			return err
+		}
+
+		writeResource(w, http.StatusOK, teamToResource(team))
+		return nil
+	})
+}
+
+// CreateGroup обрабатывает маршрут POST /scim/v2/Groups. Участники группы должны
+// быть созданы заранее, они переводятся в новую команду из своих команд.
+func (h *Handler) CreateGroup() http.Handler {
+	return handlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+		ctx := r.Context()
+
+		var req groupResource
+		if err := decode(r, &req); err != nil {
+			return err
+		}
+		if req.DisplayName == "" {
+			return badRequest(scimTypeInvalidValue, "displayName is required")
+		}
+		userIDs, err := memberIDs(req.Members)
+		if err != nil {
+			return err
+		}
+
+		ctx = h.logger.LogCtx(ctx, "team_name", req.DisplayName, "members", userIDs)
+
+		team, err := h.service.CreateTeamWithUsers(ctx, req.DisplayName, userIDs)
+		if err != nil {
+			h.logger.ErrorfContext(ctx, "create team failed: %v", err)
+			return err
+		}
+
+		h.logger.InfoContext(ctx, "scim group created")
+		w.Header().Set("Location", groupLocation(team.Name))
+		writeResource(w, http.StatusCreated, teamToResource(team))
+		return nil
+	})
+}
+
+// ReplaceGroup обрабатывает маршрут PUT /scim/v2/Groups/{id}: состав команды
+// становится равным members.
+func (h *Handler) ReplaceGroup() http.Handler {
+	return handlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+		ctx := r.Context()
+		teamName := mux.Vars(r)["id"]
+
+		var req groupResource
+		if err := decode(r, &req); err != nil {
+			return err
+		}
+		if req.DisplayName != teamName {
+			return badRequest(scimTypeMutability, "displayName is the team name and cannot be changed")
+		}
+		userIDs, err := memberIDs(req.Members)
+		if err != nil {
+			return err
+		}
+
+		ctx = h.logger.LogCtx(ctx, "team_name", teamName, "members", userIDs)
+
+		team, err := h.service.UpdateTeamMembers(ctx, teamName, entities.TeamMembersPatch{Replace: true, Add: userIDs})
+		if err != nil {
+			h.logger.ErrorfContext(ctx, "replace team members failed: %v", err)
+			return err
+		}
+
+		h.logger.InfoContext(ctx, "scim group replaced")
+		writeResource(w, http.StatusOK, teamToResource(team))
+		return nil
+	})
+}
+
+// PatchGroup обрабатывает маршрут PATCH /scim/v2/Groups/{id}.
+func (h *Handler) PatchGroup() http.Handler {
+	return handlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+		ctx := r.Context()
+		teamName := mux.Vars(r)["id"]
+
+		var req patchRequest
+		if err := decode(r, &req); err != nil {
+			return err
+		}
+		if err := decodePatch(&req); err != nil {
+			return err
+		}
+		patch, err := groupPatch(teamName, req.Operations)
+		if err != nil {
+			return err
+		}
+
+		ctx = h.logger.LogCtx(ctx,
+			"team_name", teamName,
+			"replace", patch.Replace,
+			"add", patch.Add,
+			"remove", patch.Remove,
+		)
+
+		team, err := h.service.UpdateTeamMembers(ctx, teamName, patch)
+		if err != nil {
+			h.logger.ErrorfContext(ctx, "patch team members failed: %v", err)
+			return err
+		}
+
+		h.logger.InfoContext(ctx, "scim group patched")
+		writeResource(w, http.StatusOK, teamToResource(team))
+		return nil
+	})
+}
+
+// DeleteGroup обрабатывает маршрут DELETE /scim/v2/Groups/{id}. Участники команды
+// остаются без команды.
+func (h *Handler) DeleteGroup() http.Handler {
+	return handlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+		ctx := r.Context()
+		teamName := mux.Vars(r)["id"]
+
+		ctx = h.logger.LogCtx(ctx, "team_name", teamName)
+
+		if err := h.service.DeleteTeam(ctx, teamName); err != nil {
+			h.logger.ErrorfContext(ctx, "delete team failed: %v", err)
+			return err
+		}
+
+		h.logger.InfoContext(ctx, "scim group deleted")
+		w.WriteHeader(http.StatusNoContent)
+		return nil
+	})
+}
@@ -0,0 +1,175 @@
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"service-pr-reviewer-assignment/internal/pkg/response"
+	"service-pr-reviewer-assignment/internal/service/entities"
+
+	"github.com/google/uuid"
+)
+
+const (
+	defaultCount = 100
+	maxCount     = 100
+)
+
+type Logger interface {
+	InfoContext(ctx context.Context, msg string)
+	ErrorfContext(ctx context.Context, format string, args ...interface{})
+	LogCtx(ctx context.Context, fields ...any) context.Context
+}
+
+type Service interface {
+	ListUsers(ctx context.Context, filter entities.UserFilter, offset, limit uint64) ([]entities.User, int, error)
+	GetUser(ctx context.Context, userID uuid.UUID) (*entities.User, error)
+	CreateUser(ctx context.Context, name string, isActive bool) (*entities.User, error)
+	UpdateUser(ctx context.Context, userID uuid.UUID, update entities.UserUpdate) (*entities.User, error)
+	SetUserActiveStatus(ctx context.Context, userID uuid.UUID, isActive bool) (*entities.User, error)
+
+	ListTeams(ctx context.Context, filter entities.TeamFilter, offset, limit uint64) ([]entities.Team, int, error)
+	GetTeam(ctx context.Context, teamName string) (*entities.Team, error)
+	CreateTeamWithUsers(ctx context.Context, teamName string, userIDs []uuid.UUID) (*entities.Team, error)
+	UpdateTeamMembers(ctx context.Context, teamName string, patch entities.TeamMembersPatch) (*entities.Team, error)
+	DeleteTeam(ctx context.Context, teamName string) error
+}
+
+// Handler обслуживает подмножество SCIM 2.0 (RFC 7643, RFC 7644): ресурсы Users
+// и Groups, фильтр eq по userName и displayName, постраничный вывод и PATCH.
+// Users соответствуют пользователям, Groups — командам.
+type Handler struct {
+	logger  Logger
+	service Service
+}
+
+func NewHandler(logger Logger, service Service) *Handler {
+	return &Handler{
+		logger:  logger,
+		service: service,
+	}
+}
+
+// handlerFunc обработчик SCIM, который возвращает ошибку вместо записи ответа.
+type handlerFunc func(w http.ResponseWriter, r *http.Request) error
+
+func (f handlerFunc) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := f(w, r); err != nil {
+		writeError(w, err)
+	}
+}
+
+// ServiceProviderConfig обрабатывает маршрут GET /scim/v2/ServiceProviderConfig.
+func (h *Handler) ServiceProviderConfig() http.Handler {
+	return handlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+		writeResource(w, http.StatusOK, map[string]any{
+			"schemas":        []string{schemaServiceProviderConfig},
+			"patch":          map[string]any{"supported": true},
+			"bulk":           map[string]any{"supported": false, "maxOperations": 0, "maxPayloadSize": 0},
+			"filter":         map[string]any{"supported": true, "maxResults": maxCount},
+			"changePassword": map[string]any{"supported": false},
+			"sort":           map[string]any{"supported": false},
+			"etag":           map[string]any{"supported": false},
+			"authenticationSchemes": []map[string]any{{
+				"type":        "oauthbearertoken",
+				"name":        "API key",
+				"description": "API-ключ со scope admin в заголовке Authorization: Bearer",
+			}},
+			"meta": meta{ResourceType: "ServiceProviderConfig", Location: Prefix + "/ServiceProviderConfig"},
+		})
+		return nil
+	})
+}
+
+func writeResource(w http.ResponseWriter, status int, resource any) {
+	w.Header().Set("Content-Type", ContentType)
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(resource)
+}
+
+func decode(r *http.Request, v any) error {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return response.PayloadTooLarge(tooLarge.Limit)
+		}
+		return badRequest(scimTypeInvalidSyntax, "decode body failed: "+err.Error())
+	}
+	return nil
+}
+
+// page параметры постраничного вывода: startIndex с единицы и count.
+type page struct {
+	startIndex uint64
+	count      uint64
+}
+
+func (p page) offset() uint64 {
+	return p.startIndex - 1
+}
+
+func decodePage(r *http.Request) (page, error) {
+	p := page{startIndex: 1, count: defaultCount}
+	query := r.URL.Query()
+
+	if value := query.Get("startIndex"); value != "" {
+		startIndex, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return p, badRequest(scimTypeInvalidValue, "invalid startIndex")
+		}
+		// Значения меньше единицы трактуются как единица (RFC 7644, раздел 3.4.2.4).
+		if startIndex > 1 {
+			p.startIndex = uint64(startIndex)
+		}
+	}
+	if value := query.Get("count"); value != "" {
+		count, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return p, badRequest(scimTypeInvalidValue, "invalid count")
+		}
+		p.count = uint64(min(max(count, 0), maxCount))
+	}
+
+	return p, nil
+}
+
+var filterPattern = regexp.MustCompile(`^\s*([A-Za-z][\w.]*)\s+(?i:eq)\s+"((?:[^"\\]|\\.)*)"\s*$`)
+
+// decodeFilter разбирает фильтр вида `<attribute> eq "<value>"`. Другие операторы
+// и атрибуты, кроме attribute, не поддерживаются. Пустой фильтр возвращает nil.
+func decodeFilter(r *http.Request, attribute string) (*string, error) {
+	filter := r.URL.Query().Get("filter")
+	if filter == "" {
+		return nil, nil
+	}
+
+	match := filterPattern.FindStringSubmatch(filter)
+	if match == nil || !strings.EqualFold(match[1], attribute) {
+		return nil, badRequest(scimTypeInvalidFilter, fmt.Sprintf("only filter %s eq \"value\" is supported", attribute))
+	}
+
+	var value string
+	if err := json.Unmarshal([]byte(`"`+match[2]+`"`), &value); err != nil {
+		return nil, badRequest(scimTypeInvalidFilter, "invalid filter value")
+	}
+	return &value, nil
+}
+
+func listOf[T any](resources []T, total int, p page) listResponse[T] {
+	if resources == nil {
+		resources = []T{}
+	}
+	return listResponse[T]{
+		Schemas:      []string{schemaListResponse},
+		TotalResults: total,
+		StartIndex:   p.startIndex,
+		ItemsPerPage: len(resources),
+		Resources:    resources,
+	}
+}
@@ -0,0 +1,395 @@
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"service-pr-reviewer-assignment/internal/service/entities"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+var (
+	aliceID = uuid.MustParse("2819c223-7f76-453a-919d-413861904646")
+	bobID   = uuid.MustParse("902c246b-6245-4190-8e05-00816be7344a")
+)
+
+type noopLogger struct{}
+
+func (noopLogger) InfoContext(context.Context, string) {}
+
+func (noopLogger) ErrorfContext(context.Context, string, ...interface{}) {}
+
+func (noopLogger) LogCtx(ctx context.Context, _ ...any) context.Context { return ctx }
+
+// fakeService хранит пользователей и одну команду в памяти и запоминает вызовы,
+// которые должны делать обработчики.
+type fakeService struct {
+	users map[uuid.UUID]*entities.User
+	team  string
+
+	listFilter  entities.UserFilter
+	update      *entities.UserUpdate
+	patch       *entities.TeamMembersPatch
+	deactivated []uuid.UUID
+}
+
+func newFakeService() *fakeService {
+	return &fakeService{
+		users: map[uuid.UUID]*entities.User{
+			bobID: {ID: bobID, Name: "bob@example.com", TeamName: "payments", IsActive: true},
+		},
+		team: "payments",
+	}
+}
+
+func (s *fakeService) ListUsers(_ context.Context, filter entities.UserFilter, _, _ uint64) ([]entities.User, int, error) {
+	s.listFilter = filter
+
+	var users []entities.User
+	for _, user := range s.users {
+		if filter.Name == nil || *filter.Name == user.Name {
+			users = append(users, *user)
+		}
+	}
+	return users, len(users), nil
+}
+
+func (s *fakeService) GetUser(_ context.Context, userID uuid.UUID) (*entities.User, error) {
+	user, ok := s.users[userID]
+	if !ok {
+		return nil, &entities.ErrUserNotFound{UserID: &userID}
+	}
+	return user, nil
+}
+
+func (s *fakeService) CreateUser(_ context.Context, name string, isActive bool) (*entities.User, error) {
+	for _, user := range s.users {
+		if user.Name == name {
+			return nil, &entities.ErrUserAlreadyExists{Name: name}
+		}
+	}
+
+	user := &entities.User{ID: aliceID, Name: name, IsActive: isActive}
+	s.users[user.ID] = user
+	return user, nil
+}
+
+func (s *fakeService) UpdateUser(ctx context.Context, userID uuid.UUID, update entities.UserUpdate) (*entities.User, error) {
+	s.update = &update
+
+	user, err := s.GetUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if update.Name != nil {
+		user.Name = *update.Name
+	}
+	if update.IsActive != nil {
+		user.IsActive = *update.IsActive
+	}
+	return user, nil
+}
+
+func (s *fakeService) SetUserActiveStatus(ctx context.Context, userID uuid.UUID, isActive bool) (*entities.User, error) {
+	user, err := s.GetUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !isActive {
+		s.deactivated = append(s.deactivated, userID)
+	}
+	user.IsActive = isActive
+	return user, nil
+}
+
+func (s *fakeService) ListTeams(context.Context, entities.TeamFilter, uint64, uint64) ([]entities.Team, int, error) {
+	return nil, 0, nil
+}
+
+func (s *fakeService) GetTeam(_ context.Context, teamName string) (*entities.Team, error) {
+	if teamName != s.team {
+		return nil, &entities.ErrTeamNotFound{Name: teamName}
+	}
+
+	team := &entities.Team{Name: teamName}
+	for _, user := range s.users {
+		if user.TeamName == teamName {
+			team.Members = append(team.Members, *user)
+		}
+	}
+	return team, nil
+}
+
+func (s *fakeService) CreateTeamWithUsers(context.Context, string, []uuid.UUID) (*entities.Team, error) {
+	return nil, nil
+}
+
+func (s *fakeService) UpdateTeamMembers(ctx context.Context, teamName string, patch entities.TeamMembersPatch) (*entities.Team, error) {
+	s.patch = &patch
+
+	for _, id := range patch.Add {
+		if _, ok := s.users[id]; !ok {
+			s.users[id] = &entities.User{ID: id, Name: "alice@example.com", IsActive: true}
+		}
+		s.users[id].TeamName = teamName
+	}
+	for _, id := range patch.Remove {
+		if user, ok := s.users[id]; ok {
+			user.TeamName = ""
+		}
+	}
+	return s.GetTeam(ctx, teamName)
+}
+
+func (s *fakeService) DeleteTeam(context.Context, string) error {
+	return nil
+}
+
+func newTestRouter(service Service) http.Handler {
+	h := NewHandler(noopLogger{}, service)
+
+	router := mux.NewRouter().PathPrefix(Prefix).Subrouter()
+	router.Handle("/Users", h.ListUsers()).Methods(http.MethodGet)
+	router.Handle("/Users", h.CreateUser()).Methods(http.MethodPost)
+	router.Handle("/Users/{id}", h.PatchUser()).Methods(http.MethodPatch)
+	router.Handle("/Users/{id}", h.DeleteUser()).Methods(http.MethodDelete)
+	router.Handle("/Groups/{id}", h.PatchGroup()).Methods(http.MethodPatch)
+	return router
+}
+
+// serve отправляет запрос с телом из testdata и возвращает ответ с разобранным JSON.
+func serve(t *testing.T, router http.Handler, method, target, payload string) (*httptest.ResponseRecorder, map[string]any) {
+	t.Helper()
+
+	var body string
+	if payload != "" {
+		data, err := os.ReadFile(filepath.Join("testdata", payload))
+		if err != nil {
+			t.Fatalf("read payload: %v", err)
+		}
+		body = string(data)
+	}
+
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set("Content-Type", ContentType)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	if rec.Body.Len() == 0 {
+		return rec, nil
+	}
+	if got := rec.Header().Get("Content-Type"); got != ContentType {
+		t.Errorf("Content-Type = %q, want %q", got, ContentType)
+	}
+
+	var resource map[string]any
+	if err := json.Unmarshal(rec.Body.Bytes(), &resource); err != nil {
+		t.Fatalf("decode response %s: %v", rec.Body, err)
+	}
+	return rec, resource
+}
+
+func requireSchema(t *testing.T, resource map[string]any, schema string) {
+	t.Helper()
+
+	schemas, _ := resource["schemas"].([]any)
+	if !slices.Contains(schemas, any(schema)) {
+		t.Errorf("schemas = %v, want %s", resource["schemas"], schema)
+	}
+}
+
+func TestCreateUser(t *testing.T) {
+	tests := []struct {
+		payload  string
+		userName string
+	}{
+		{payload: "okta_create_user.json", userName: "alice@example.com"},
+		{payload: "entra_create_user.json", userName: "alice@contoso.com"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.payload, func(t *testing.T) {
+			router := newTestRouter(newFakeService())
+
+			rec, resource := serve(t, router, http.MethodPost, Prefix+"/Users", tt.payload)
+
+			if rec.Code != http.StatusCreated {
+				t.Fatalf("status = %d, body = %s", rec.Code, rec.Body)
+			}
+			requireSchema(t, resource, schemaUser)
+			if resource["id"] != aliceID.String() || resource["userName"] != tt.userName || resource["active"] != true {
+				t.Errorf("resource = %v", resource)
+			}
+			if got, want := rec.Header().Get("Location"), Prefix+"/Users/"+aliceID.String(); got != want {
+				t.Errorf("Location = %q, want %q", got, want)
+			}
+			meta, _ := resource["meta"].(map[string]any)
+			if meta["resourceType"] != "User" || meta["location"] != rec.Header().Get("Location") {
+				t.Errorf("meta = %v", meta)
+			}
+
+			rec, resource = serve(t, router, http.MethodPost, Prefix+"/Users", tt.payload)
+
+			if rec.Code != http.StatusConflict {
+				t.Fatalf("repeated create: status = %d, want 409", rec.Code)
+			}
+			requireSchema(t, resource, schemaError)
+			if resource["status"] != "409" || resource["scimType"] != scimTypeUniqueness {
+				t.Errorf("error = %v, want status \"409\" and scimType uniqueness", resource)
+			}
+		})
+	}
+}
+
+func TestDeactivateUser(t *testing.T) {
+	for _, payload := range []string{"okta_deactivate_user.json", "entra_deactivate_user.json"} {
+		t.Run(payload, func(t *testing.T) {
+			service := newFakeService()
+			router := newTestRouter(service)
+
+			rec, resource := serve(t, router, http.MethodPatch, Prefix+"/Users/"+bobID.String(), payload)
+
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status = %d, body = %s", rec.Code, rec.Body)
+			}
+			if service.update == nil || service.update.IsActive == nil || *service.update.IsActive || service.update.Name != nil {
+				t.Errorf("update = %+v, want only active=false", service.update)
+			}
+			requireSchema(t, resource, schemaUser)
+			if resource["active"] != false {
+				t.Errorf("active = %v, want false", resource["active"])
+			}
+		})
+	}
+}
+
+func TestDeleteUserDeactivates(t *testing.T) {
+	service := newFakeService()
+	router := newTestRouter(service)
+
+	rec, _ := serve(t, router, http.MethodDelete, Prefix+"/Users/"+bobID.String(), "")
+
+	if rec.Code != http.StatusNoContent || rec.Body.Len() != 0 {
+		t.Fatalf("status = %d, body = %s, want 204 without body", rec.Code, rec.Body)
+	}
+	if !slices.Equal(service.deactivated, []uuid.UUID{bobID}) {
+		t.Errorf("deactivated = %v, want SetUserActiveStatus(%s, false)", service.deactivated, bobID)
+	}
+
+	rec, resource := serve(t, router, http.MethodDelete, Prefix+"/Users/"+aliceID.String(), "")
+
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("unknown user: status = %d, want 404", rec.Code)
+	}
+	requireSchema(t, resource, schemaError)
+	if resource["status"] != "404" {
+		t.Errorf("status = %v, want \"404\"", resource["status"])
+	}
+}
+
+func TestPatchGroupMembers(t *testing.T) {
+	tests := []struct {
+		payload     string
+		wantAdd     []uuid.UUID
+		wantRemove  []uuid.UUID
+		wantMembers []string
+	}{
+		{payload: "okta_group_add_members.json", wantAdd: []uuid.UUID{aliceID}, wantMembers: []string{aliceID.String(), bobID.String()}},
+		{payload: "entra_group_add_members.json", wantAdd: []uuid.UUID{aliceID}, wantMembers: []string{aliceID.String(), bobID.String()}},
+		{payload: "okta_group_remove_member.json", wantRemove: []uuid.UUID{bobID}, wantMembers: []string{}},
+		{payload: "entra_group_remove_members.json", wantRemove: []uuid.UUID{bobID}, wantMembers: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.payload, func(t *testing.T) {
+			service := newFakeService()
+			router := newTestRouter(service)
+
+			rec, resource := serve(t, router, http.MethodPatch, Prefix+"/Groups/payments", tt.payload)
+
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status = %d, body = %s", rec.Code, rec.Body)
+			}
+			if service.patch == nil || service.patch.Replace ||
+				!slices.Equal(service.patch.Add, tt.wantAdd) || !slices.Equal(service.patch.Remove, tt.wantRemove) {
+				t.Errorf("patch = %+v, want add %v remove %v", service.patch, tt.wantAdd, tt.wantRemove)
+			}
+
+			requireSchema(t, resource, schemaGroup)
+			if resource["id"] != "payments" || resource["displayName"] != "payments" {
+				t.Errorf("group = %v", resource)
+			}
+			members, _ := resource["members"].([]any)
+			got := make([]string, 0, len(members))
+			for _, member := range members {
+				got = append(got, member.(map[string]any)["value"].(string))
+			}
+			slices.Sort(got)
+			if !slices.Equal(got, tt.wantMembers) {
+				t.Errorf("members = %v, want %v", got, tt.wantMembers)
+			}
+		})
+	}
+}
+
+func TestListUsersFilter(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		wantName  string
+		wantTotal float64
+	}{
+		{name: "okta", query: "filter=" + url.QueryEscape(`userName eq "bob@example.com"`) + "&startIndex=1&count=100", wantName: "bob@example.com", wantTotal: 1},
+		{name: "entra", query: "filter=" + url.QueryEscape(`userName eq "alice@contoso.com"`), wantName: "alice@contoso.com"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := newFakeService()
+			router := newTestRouter(service)
+
+			rec, resource := serve(t, router, http.MethodGet, Prefix+"/Users?"+tt.query, "")
+
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status = %d, body = %s", rec.Code, rec.Body)
+			}
+			if service.listFilter.Name == nil || *service.listFilter.Name != tt.wantName {
+				t.Errorf("filter = %+v, want name %q", service.listFilter, tt.wantName)
+			}
+
+			requireSchema(t, resource, schemaListResponse)
+			resources, ok := resource["Resources"].([]any)
+			if !ok {
+				t.Fatalf("Resources = %v, want a list", resource["Resources"])
+			}
+			if resource["totalResults"] != tt.wantTotal || resource["startIndex"] != float64(1) ||
+				resource["itemsPerPage"] != float64(len(resources)) {
+				t.Errorf("list = %v", resource)
+			}
+		})
+	}
+
+	t.Run("unsupported filter", func(t *testing.T) {
+		router := newTestRouter(newFakeService())
+
+		rec, resource := serve(t, router, http.MethodGet, Prefix+"/Users?filter="+url.QueryEscape(`emails co "example"`), "")
+
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("status = %d, want 400", rec.Code)
+		}
+		requireSchema(t, resource, schemaError)
+		if resource["status"] != "400" || resource["scimType"] != scimTypeInvalidFilter {
+			t.Errorf("error = %v, want status \"400\" and scimType invalidFilter", resource)
+		}
+	})
+}
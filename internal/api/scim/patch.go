@@ -0,0 +1,245 @@
+package scim
+
+import (
+	"encoding/json"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"service-pr-reviewer-assignment/internal/service/entities"
+
+	"github.com/google/uuid"
+)
+
+// patchRequest тело PATCH (RFC 7644, раздел 3.5.2).
+type patchRequest struct {
+	Schemas    []string         `json:"schemas"`
+	Operations []patchOperation `json:"Operations"`
+}
+
+type patchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	Value json.RawMessage `json:"value"`
+}
+
+func decodePatch(r *patchRequest) error {
+	if !slices.Contains(r.Schemas, schemaPatchOp) {
+		return badRequest(scimTypeInvalidSyntax, "schemas must contain "+schemaPatchOp)
+	}
+	if len(r.Operations) == 0 {
+		return badRequest(scimTypeInvalidSyntax, "Operations must not be empty")
+	}
+	for i := range r.Operations {
+		r.Operations[i].Op = strings.ToLower(r.Operations[i].Op)
+		switch r.Operations[i].Op {
+		case "add", "replace", "remove":
+		default:
+			return badRequest(scimTypeInvalidSyntax, "unsupported op "+r.Operations[i].Op)
+		}
+	}
+	return nil
+}
+
+// userPatch собирает изменения пользователя из операций PATCH. Поддерживаются
+// атрибуты userName и active, остальные атрибуты пользователя сервис не хранит
+// и пропускает.
+func userPatch(operations []patchOperation) (entities.UserUpdate, error) {
+	var update entities.UserUpdate
+
+	apply := func(attribute string, value json.RawMessage) error {
+		switch strings.ToLower(attribute) {
+		case "username":
+			var name string
+			if err := json.Unmarshal(value, &name); err != nil {
+				return badRequest(scimTypeInvalidValue, "userName must be a string")
+			}
+			update.Name = &name
+		case "active":
+			active, err := decodeBool(value)
+			if err != nil {
+				return err
+			}
+			update.IsActive = &active
+		}
+		return nil
+	}
+
+	for _, op := range operations {
+		if op.Op == "remove" {
+			if strings.EqualFold(op.Path, "userName") || strings.EqualFold(op.Path, "active") {
+				return update, badRequest(scimTypeMutability, op.Path+" cannot be removed")
+			}
+			continue
+		}
+
+		if op.Path != "" {
+			if err := apply(op.Path, op.Value); err != nil {
+				return update, err
+			}
+			continue
+		}
+
+		var values map[string]json.RawMessage
+		if err := json.Unmarshal(op.Value, &values); err != nil {
+			return update, badRequest(scimTypeInvalidValue, "value must be an object when path is omitted")
+		}
+		for attribute, value := range values {
+			if err := apply(attribute, value); err != nil {
+				return update, err
+			}
+		}
+	}
+
+	return update, nil
+}
+
+// decodeBool принимает true/false и строки "True"/"False": так active передают некоторые
+// провайдеры, например Microsoft Entra ID.
+func decodeBool(value json.RawMessage) (bool, error) {
+	var b bool
+	if err := json.Unmarshal(value, &b); err == nil {
+		return b, nil
+	}
+
+	var s string
+	if err := json.Unmarshal(value, &s); err == nil {
+		if b, err := strconv.ParseBool(s); err == nil {
+			return b, nil
+		}
+	}
+
+	return false, badRequest(scimTypeInvalidValue, "active must be a boolean")
+}
+
+var memberFilterPath = regexp.MustCompile(`^(?i:members)\[\s*(?i:value)\s+(?i:eq)\s+"([^"]*)"\s*\]$`)
+
+// groupPatch собирает изменение состава команды из операций PATCH. displayName
+// совпадает с именем команды и не меняется.
+func groupPatch(teamName string, operations []patchOperation) (entities.TeamMembersPatch, error) {
+	var patch entities.TeamMembersPatch
+
+	add := func(ids []uuid.UUID) {
+		for _, id := range ids {
+			patch.Remove = slices.DeleteFunc(patch.Remove, func(removed uuid.UUID) bool { return removed == id })
+			if !slices.Contains(patch.Add, id) {
+				patch.Add = append(patch.Add, id)
+			}
+		}
+	}
+	remove := func(ids []uuid.UUID) {
+		for _, id := range ids {
+			patch.Add = slices.DeleteFunc(patch.Add, func(added uuid.UUID) bool { return added == id })
+			if !patch.Replace && !slices.Contains(patch.Remove, id) {
+				patch.Remove = append(patch.Remove, id)
+			}
+		}
+	}
+	replace := func(ids []uuid.UUID) {
+		patch = entities.TeamMembersPatch{Replace: true}
+		add(ids)
+	}
+
+	for _, op := range operations {
+		path := strings.TrimSpace(op.Path)
+
+		if match := memberFilterPath.FindStringSubmatch(path); match != nil {
+			if op.Op != "remove" {
+				return patch, badRequest(scimTypeInvalidPath, "members filter is supported only for remove")
+			}
+			id, err := uuid.Parse(match[1])
+			if err != nil {
+				return patch, badRequest(scimTypeInvalidValue, "invalid member value")
+			}
+			remove([]uuid.UUID{id})
+			continue
+		}
+
+		switch {
+		case strings.EqualFold(path, "members"):
+			if op.Op == "remove" && len(op.Value) == 0 {
+				replace(nil)
+				continue
+			}
+
+			ids, err := decodeMembers(op.Value)
+			if err != nil {
+				return patch, err
+			}
+			switch op.Op {
+			case "add":
+				add(ids)
+			case "remove":
+				remove(ids)
+			case "replace":
+				replace(ids)
+			}
+
+		case strings.EqualFold(path, "displayName"):
+			if err := checkDisplayName(teamName, op.Value); err != nil {
+				return patch, err
+			}
+
+		case path == "" && op.Op != "remove":
+			var values map[string]json.RawMessage
+			if err := json.Unmarshal(op.Value, &values); err != nil {
+				return patch, badRequest(scimTypeInvalidValue, "value must be an object when path is omitted")
+			}
+			for attribute, value := range values {
+				switch strings.ToLower(attribute) {
+				case "displayname":
+					if err := checkDisplayName(teamName, value); err != nil {
+						return patch, err
+					}
+				case "members":
+					ids, err := decodeMembers(value)
+					if err != nil {
+						return patch, err
+					}
+					if op.Op == "add" {
+						add(ids)
+					} else {
+						replace(ids)
+					}
+				}
+			}
+
+		default:
+			return patch, badRequest(scimTypeInvalidPath, "unsupported path "+op.Path)
+		}
+	}
+
+	return patch, nil
+}
+
+func decodeMembers(value json.RawMessage) ([]uuid.UUID, error) {
+	var members []groupMember
+	if err := json.Unmarshal(value, &members); err != nil {
+		return nil, badRequest(scimTypeInvalidValue, "members must be a list of {\"value\": \"<user id>\"}")
+	}
+	return memberIDs(members)
+}
+
+func memberIDs(members []groupMember) ([]uuid.UUID, error) {
+	ids := make([]uuid.UUID, 0, len(members))
+	for _, member := range members {
+		id, err := uuid.Parse(member.Value)
+		if err != nil {
+			return nil, badRequest(scimTypeInvalidValue, "invalid member value "+strconv.Quote(member.Value))
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+func checkDisplayName(teamName string, value json.RawMessage) error {
+	var displayName string
+	if err := json.Unmarshal(value, &displayName); err != nil {
+		return badRequest(scimTypeInvalidValue, "displayName must be a string")
+	}
+	if displayName != teamName {
+		return badRequest(scimTypeMutability, "displayName is the team name and cannot be changed")
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package scim
+
+import (
+	"service-pr-reviewer-assignment/internal/service/entities"
+)
+
+const (
+	ContentType = "application/scim+json"
+
+	Prefix = "/scim/v2"
+
+	schemaUser                  = "urn:ietf:params:scim:schemas:core:2.0:User"
+	schemaGroup                 = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	schemaListResponse          = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	schemaPatchOp               = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	schemaError                 = "urn:ietf:params:scim:api:messages:2.0:Error"
+	schemaServiceProviderConfig = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+)
+
+type meta struct {
+	ResourceType string `json:"resourceType"`
+	Location     string `json:"location"`
+}
+
+// userResource ресурс User (RFC 7643, раздел 4.1). id — идентификатор пользователя,
+// userName — имя пользователя, groups — его команда.
+type userResource struct {
+	Schemas  []string      `json:"schemas"`
+	ID       string        `json:"id,omitempty"`
+	UserName string        `json:"userName"`
+	Active   *bool         `json:"active,omitempty"`
+	Groups   []groupMember `json:"groups,omitempty"`
+	Meta     *meta         `json:"meta,omitempty"`
+}
+
+// groupResource ресурс Group (RFC 7643, раздел 4.2). Группа — команда, её id и
+// displayName — имя команды.
+type groupResource struct {
+	Schemas     []string      `json:"schemas"`
+	ID          string        `json:"id,omitempty"`
+	DisplayName string        `json:"displayName"`
+	Members     []groupMember `json:"members"`
+	Meta        *meta         `json:"meta,omitempty"`
+}
+
+// groupMember ссылка на участника группы или на группу пользователя.
+type groupMember struct {
+	Value   string `json:"value"`
+	Display string `json:"display,omitempty"`
+	Ref     string `json:"$ref,omitempty"`
+}
+
+type listResponse[T any] struct {
+	Schemas      []string `json:"schemas"`
+	TotalResults int      `json:"totalResults"`
+	StartIndex   uint64   `json:"startIndex"`
+	ItemsPerPage int      `json:"itemsPerPage"`
+	Resources    []T      `json:"Resources"`
+}
+
+func userLocation(id string) string {
+	return Prefix + "/Users/" + id
+}
+
+func groupLocation(name string) string {
+	return Prefix + "/Groups/" + name
+}
+
+func userToResource(user *entities.User) userResource {
+	active := user.IsActive
+	resource := userResource{
+		Schemas:  []string{schemaUser},
+		ID:       user.ID.String(),
+		UserName: user.Name,
+		Active:   &active,
+		Meta:     &meta{ResourceType: "User", Location: userLocation(user.ID.String())},
+	}
+	if user.TeamName != "" {
+		resource.Groups = []groupMember{{
+			Value:   user.TeamName,
+			Display: user.TeamName,
+			Ref:     groupLocation(user.TeamName),
+		}}
+	}
+	return resource
+}
+
+func teamToResource(team *entities.Team) groupResource {
+	members := make([]groupMember, 0, len(team.Members))
+	for _, user := range team.Members {
+		members = append(members, groupMember{
+			Value:   user.ID.String(),
+			Display: user.Name,
+			Ref:     userLocation(user.ID.String()),
+		})
+	}
+
+	return groupResource{
+		Schemas:     []string{schemaGroup},
+		ID:          team.Name,
+		DisplayName: team.Name,
+		Members:     members,
+		Meta:        &meta{ResourceType: "Group", Location: groupLocation(team.Name)},
+	}
+}
@@ -0,0 +1,194 @@
+package scim
+
+import (
+	"net/http"
+
+	"service-pr-reviewer-assignment/internal/service/entities"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+// ListUsers обрабатывает маршрут GET /scim/v2/Users.
+func (h *Handler) ListUsers() http.Handler {
+	return handlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+		ctx := r.Context()
+
+		p, err := decodePage(r)
+		if err != nil {
+			return err
+		}
+		name, err := decodeFilter(r, "userName")
+		if err != nil {
+			return err
+		}
+
+		users, total, err := h.service.ListUsers(ctx, entities.UserFilter{Name: name}, p.offset(), p.count)
+		if err != nil {
+			h.logger.ErrorfContext(ctx, "list users failed: %v", err)
+			return err
+		}
+
+		resources := make([]userResource, 0, len(users))
+		for i := range users {
+			resources = append(resources, userToResource(&users[i]))
+		}
+
+		writeResource(w, http.StatusOK, listOf(resources, total, p))
+		return nil
+	})
+}
+
+// GetUser обрабатывает маршрут GET /scim/v2/Users/{id}.
+func (h *Handler) GetUser() http.Handler {
+	return handlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+		ctx := r.Context()
+
+		userID, err := userIDFromPath(r)
+		if err != nil {
+			return err
+		}
+
+		user, err := h.service.GetUser(ctx, userID)
+		if err != nil {
+			h.logger.ErrorfContext(ctx, "get user failed: %v", err)
+			return err
+		}
+
+		writeResource(w, http.StatusOK, userToResource(user))
+		return nil
+	})
+}
+
+// CreateUser обрабатывает маршрут POST /scim/v2/Users. Пользователь создаётся
+// вне команды, в команду его добавляет группа.
+func (h *Handler) CreateUser() http.Handler {
+	return handlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+		ctx := r.Context()
+
+		var req userResource
+		if err := decode(r, &req); err != nil {
+			return err
+		}
+		if req.UserName == "" {
+			return badRequest(scimTypeInvalidValue, "userName is required")
+		}
+
+		ctx = h.logger.LogCtx(ctx, "username", req.UserName)
+
+		active := req.Active == nil || *req.Active
+		user, err := h.service.CreateUser(ctx, req.UserName, active)
+		if err != nil {
+			h.logger.ErrorfContext(ctx, "create user failed: %v", err)
+			return err
+		}
+
+		h.logger.InfoContext(ctx, "scim user created")
+		w.Header().Set("Location", userLocation(user.ID.String()))
+		writeResource(w, http.StatusCreated, userToResource(user))
+		return nil
+	})
+}
+
+// ReplaceUser обрабатывает маршрут PUT /scim/v2/Users/{id}.
+func (h *Handler) ReplaceUser() http.Handler {
+	return handlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+		ctx := r.Context()
+
+		userID, err := userIDFromPath(r)
+		if err != nil {
+			return err
+		}
+
+		var req userResource
+		if err := decode(r, &req); err != nil {
+			return err
+		}
+		if req.UserName == "" {
+			return badRequest(scimTypeInvalidValue, "userName is required")
+		}
+
+		ctx = h.logger.LogCtx(ctx, "user_id", userID, "username", req.UserName)
+
+		// Отсутствующий атрибут при замене принимает значение по умолчанию, для active это true.
+		active := req.Active == nil || *req.Active
+		user, err := h.service.UpdateUser(ctx, userID, entities.UserUpdate{Name: &req.UserName, IsActive: &active})
+		if err != nil {
+			h.logger.ErrorfContext(ctx, "replace user failed: %v", err)
+			return err
+		}
+
+		h.logger.InfoContext(ctx, "scim user replaced")
+		writeResource(w, http.StatusOK, userToResource(user))
+		return nil
+	})
+}
+
+// PatchUser обрабатывает маршрут PATCH /scim/v2/Users/{id}.
+func (h *Handler) PatchUser() http.Handler {
+	return handlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+		ctx := r.Context()
+
+		userID, err := userIDFromPath(r)
+		if err != nil {
+			return err
+		}
+
+		var req patchRequest
+		if err := decode(r, &req); err != nil {
+			return err
+		}
+		if err := decodePatch(&req); err != nil {
+			return err
+		}
+		update, err := userPatch(req.Operations)
+		if err != nil {
+			return err
+		}
+
+		ctx = h.logger.LogCtx(ctx, "user_id", userID, "username", update.Name, "is_active", update.IsActive)
+
+		user, err := h.service.UpdateUser(ctx, userID, update)
+		if err != nil {
+			h.logger.ErrorfContext(ctx, "patch user failed: %v", err)
+			return err
+		}
+
+		h.logger.InfoContext(ctx, "scim user patched")
+		writeResource(w, http.StatusOK, userToResource(user))
+		return nil
+	})
+}
+
+// DeleteUser обрабатывает маршрут DELETE /scim/v2/Users/{id}. На пользователя
+// ссылаются PR и история ревью, поэтому он не удаляется, а деактивируется
+// и снимается с ревью, как при SetUserActiveStatus(false).
+func (h *Handler) DeleteUser() http.Handler {
+	return handlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+		ctx := r.Context()
+
+		userID, err := userIDFromPath(r)
+		if err != nil {
+			return err
+		}
+
+		ctx = h.logger.LogCtx(ctx, "user_id", userID)
+
+		if _, err := h.service.SetUserActiveStatus(ctx, userID, false); err != nil {
+			h.logger.ErrorfContext(ctx, "deprovision user failed: %v", err)
+			return err
+		}
+
+		h.logger.InfoContext(ctx, "scim user deprovisioned")
+		w.WriteHeader(http.StatusNoContent)
+		return nil
+	})
+}
+
+func userIDFromPath(r *http.Request) (uuid.UUID, error) {
+	userID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		return uuid.Nil, &requestError{status: http.StatusNotFound, detail: "user not found"}
+	}
+	return userID, nil
+}
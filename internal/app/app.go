@@ -11,31 +11,71 @@ import (
 	"syscall"
 	"time"
 
+	"service-pr-reviewer-assignment/api"
+	"service-pr-reviewer-assignment/internal/api/handlers/events_stream"
+	"service-pr-reviewer-assignment/internal/api/handlers/readyz"
+	"service-pr-reviewer-assignment/internal/app/events"
+	"service-pr-reviewer-assignment/internal/app/grpc_server"
+	"service-pr-reviewer-assignment/internal/app/readiness"
 	"service-pr-reviewer-assignment/internal/app/router"
+	"service-pr-reviewer-assignment/internal/pkg/authentication"
+	"service-pr-reviewer-assignment/internal/pkg/openapi_validation"
+	"service-pr-reviewer-assignment/internal/pkg/rate_limit"
+	"service-pr-reviewer-assignment/internal/pkg/tenant"
+	"service-pr-reviewer-assignment/migrations"
 
 	"service-pr-reviewer-assignment/pkg/querier"
 	"service-pr-reviewer-assignment/pkg/tx"
 
 	"service-pr-reviewer-assignment/internal/app/config"
+	"service-pr-reviewer-assignment/internal/app/metrics"
+	"service-pr-reviewer-assignment/internal/app/oidc"
 	"service-pr-reviewer-assignment/internal/app/postgres"
+	"service-pr-reviewer-assignment/internal/app/tracing"
 	"service-pr-reviewer-assignment/internal/service"
+	"service-pr-reviewer-assignment/internal/service/entities"
 	"service-pr-reviewer-assignment/internal/storage"
 	"service-pr-reviewer-assignment/pkg/log"
 
 	"github.com/avito-tech/go-transaction-manager/pgxv5"
+	"google.golang.org/grpc"
 )
 
 const (
-	shutdownPeriod      = 15 * time.Second
-	shutdownHardPeriod  = 3 * time.Second
-	readinessDrainDelay = 5 * time.Second
+	idempotencyCleanupInterval = 10 * time.Minute
+	eventsCleanupInterval      = time.Hour
+
+	tracingShutdownTimeout = 5 * time.Second
+
+	bootstrapAPIKeyName = "bootstrap"
 )
 
 func Run(ctx context.Context, cfg *config.Config) error {
 	ctx, stop := signal.NotifyContext(ctx, syscall.SIGTERM, syscall.SIGINT)
 	defer stop()
 
-	logger := log.Must()
+	logger := log.Must(log.Options{
+		Level:         cfg.Log.Level,
+		PackageLevels: cfg.Log.PackageLevels,
+		Format:        log.Format(cfg.Log.Format),
+		Sampling: log.Sampling{
+			Initial:    cfg.Log.Sampling.Initial,
+			Thereafter: cfg.Log.Sampling.Thereafter,
+			Tick:       cfg.Log.Sampling.Tick,
+		},
+	})
+
+	shutdownTracing, err := tracing.Setup(ctx, cfg.Tracing, logger)
+	if err != nil {
+		return fmt.Errorf("setup tracing: %w", err)
+	}
+	defer func() {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), tracingShutdownTimeout)
+		defer cancel()
+		if err := shutdownTracing(shutdownCtx); err != nil {
+			logger.Errorf("tracing shutdown failed: %v", err)
+		}
+	}()
 
 	pg, err := postgres.NewConnPool(ctx, cfg.Postgres, logger)
 	if err != nil {
@@ -43,37 +83,144 @@ func Run(ctx context.Context, cfg *config.Config) error {
 	}
 	defer pg.Close()
 
-	txManager := tx.Must(pg)
+	txManager := tx.Must(pg, logger)
+	metrics := metrics.Must(pg, txManager)
 	querier := querier.Must(pg, pgxv5.DefaultCtxGetter)
 	storage := storage.Must(querier)
-	service := service.Must(storage, txManager)
+	service := service.Must(storage, txManager, metrics, service.AssignmentPolicy{
+		MaxReviewers: cfg.Assignment.MaxReviewers,
+	}, cfg.Features.Events)
+
+	if cfg.Auth.BootstrapAPIKey != "" {
+		// Начальный ключ принадлежит организации по умолчанию.
+		bootstrapCtx := tenant.WithOrgID(ctx, entities.DefaultOrganizationID)
+		err := service.EnsureAPIKey(bootstrapCtx, bootstrapAPIKeyName, cfg.Auth.BootstrapAPIKey, []entities.Scope{entities.ScopeAdmin})
+		if err != nil {
+			return fmt.Errorf("ensure bootstrap api key: %w", err)
+		}
+	}
+	var tokenVerifier authentication.TokenVerifier
+	if cfg.Auth.JWT.JWKSSource != "" {
+		verifier, err := oidc.NewVerifier(ctx, cfg.Auth.JWT)
+		if err != nil {
+			return fmt.Errorf("create jwt verifier: %w", err)
+		}
+		tokenVerifier = verifier
+	}
+	if !cfg.Auth.Enabled {
+		logger.WarnContext(ctx, "authentication is disabled, all requests are served with admin scope")
+	}
+
+	migrationsCheck, err := readiness.Migrations(storage, migrations.FS)
+	if err != nil {
+		return fmt.Errorf("migrations readiness check: %w", err)
+	}
+	readinessChecks := []readyz.Check{
+		readiness.Postgres(pg),
+		migrationsCheck,
+	}
+
+	var rateLimiter *rate_limit.Limiter
+	if cfg.RateLimiting.Enabled {
+		rateLimiter = newRateLimiter(cfg.RateLimiting)
+		metrics.ObserveRateLimiter(rateLimiter.Clients)
+	}
+
+	var specValidator *openapi_validation.Validator
+	if cfg.Features.RequestValidation {
+		specValidator = openapi_validation.Must(api.Spec)
+	}
+
+	var specJSON []byte
+	if cfg.Features.Docs {
+		specJSON, err = api.SpecJSON()
+		if err != nil {
+			return fmt.Errorf("convert openapi spec: %w", err)
+		}
+	}
+
+	// Брокер работает до сигнала остановки и закрывает потоки событий, чтобы они не
+	// задерживали остановку сервера: клиенты переподключатся к другой реплике.
+	var eventBroker events_stream.Broker
+	if cfg.Features.Events {
+		broker := events.Must(pg, storage, logger)
+		go broker.Run(ctx)
+		eventBroker = broker
+	}
+	// Очистка работает и с выключенным потоком: удаляет события, сохранённые, пока он был включён.
+	go runEventsCleanup(ctx, logger, storage, cfg.Events.Retention)
 
 	var isShuttingDown atomic.Bool
 	serverCtx, stopServer := context.WithCancel(context.Background())
 	defer stopServer()
 
+	handler := router.Must(cfg, router.Dependencies{
+		IsShuttingDown:  &isShuttingDown,
+		OngoingCtx:      serverCtx,
+		Logger:          logger,
+		Service:         service,
+		Storage:         storage,
+		Metrics:         metrics,
+		ReadinessChecks: readinessChecks,
+		TokenVerifier:   tokenVerifier,
+		RateLimiter:     rateLimiter,
+		SpecValidator:   specValidator,
+		SpecJSON:        specJSON,
+		EventBroker:     eventBroker,
+	})
+
 	server := &http.Server{
-		Addr:    ":" + cfg.Server.Port,
-		Handler: router.Must(&isShuttingDown, serverCtx, logger, service),
+		Addr:              ":" + cfg.Server.Port,
+		Handler:           handler,
+		ReadHeaderTimeout: cfg.Server.ReadHeaderTimeout,
+		ReadTimeout:       cfg.Server.ReadTimeout,
+		WriteTimeout:      cfg.Server.WriteTimeout,
+		IdleTimeout:       cfg.Server.IdleTimeout,
 		BaseContext: func(net.Listener) context.Context {
 			return serverCtx
 		},
 	}
 
+	if cfg.Features.Idempotency {
+		go runIdempotencyCleanup(ctx, logger, storage)
+	}
+
 	serverErrors := make(chan error, 1)
 	go runServer(ctx, logger, server, cfg.Server.Port, serverErrors)
 
-	if err := waitForShutdown(ctx, serverErrors); err != nil {
+	// Без gRPC канал остаётся nil и не участвует в ожидании.
+	var grpcServer *grpc.Server
+	var grpcErrors chan error
+	if cfg.GRPC.Enabled {
+		grpcServer = grpc_server.Must(logger, service, tokenVerifier, cfg.Auth.Enabled)
+		grpcErrors = make(chan error, 1)
+		go runGRPCServer(ctx, logger, grpcServer, cfg.GRPC.Port, grpcErrors)
+	}
+
+	if err := waitForShutdown(ctx, serverErrors, grpcErrors); err != nil {
 		return fmt.Errorf("wait for shutdown: %w", err)
 	}
 
-	if err := shutdownServer(ctx, logger, server, &isShuttingDown); err != nil {
+	if err := shutdownServer(ctx, logger, server, grpcServer, &isShuttingDown, cfg.Shutdown); err != nil {
 		return fmt.Errorf("shutdown server: %w", err)
 	}
 
 	return nil
 }
 
+func newRateLimiter(cfg config.RateLimiting) *rate_limit.Limiter {
+	routes := make(map[string]rate_limit.Limit, len(cfg.Routes))
+	for route, limit := range cfg.Routes {
+		routes[route] = rate_limit.Limit{RPS: limit.RPS, Burst: limit.Burst}
+	}
+
+	return rate_limit.Must(
+		rate_limit.Limit{RPS: cfg.Default.RPS, Burst: cfg.Default.Burst},
+		routes,
+		rate_limit.Limit{RPS: cfg.IP.RPS, Burst: cfg.IP.Burst},
+	)
+}
+
 func runServer(ctx context.Context, logger *log.Logger, server *http.Server, port string, errorsCh chan<- error) {
 	logger.InfofContext(ctx, "server listening on :%s", port)
 
@@ -85,31 +232,136 @@ func runServer(ctx context.Context, logger *log.Logger, server *http.Server, por
 	close(errorsCh)
 }
 
-func waitForShutdown(ctx context.Context, serverErrors <-chan error) error {
+func runGRPCServer(ctx context.Context, logger *log.Logger, server *grpc.Server, port string, errorsCh chan<- error) {
+	listener, err := net.Listen("tcp", ":"+port)
+	if err != nil {
+		errorsCh <- fmt.Errorf("grpc server listen: %w", err)
+		return
+	}
+
+	logger.InfofContext(ctx, "grpc server listening on :%s", port)
+
+	if err := server.Serve(listener); err != nil {
+		errorsCh <- fmt.Errorf("grpc server serve: %w", err)
+		return
+	}
+
+	close(errorsCh)
+}
+
+func runIdempotencyCleanup(ctx context.Context, logger *log.Logger, storage *storage.Storage) {
+	ticker := time.NewTicker(idempotencyCleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			deleted, err := storage.DeleteExpiredIdempotencyKeys(ctx)
+			if err != nil {
+				logger.ErrorfContext(ctx, "delete expired idempotency keys failed: %v", err)
+				continue
+			}
+			if deleted > 0 {
+				logger.InfofContext(ctx, "deleted %d expired idempotency keys", deleted)
+			}
+		}
+	}
+}
+
+func runEventsCleanup(ctx context.Context, logger *log.Logger, storage *storage.Storage, retention time.Duration) {
+	ticker := time.NewTicker(eventsCleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			deleted, err := storage.DeleteEventsBefore(ctx, time.Now().Add(-retention))
+			if err != nil {
+				logger.ErrorfContext(ctx, "delete expired events failed: %v", err)
+				continue
+			}
+			if deleted > 0 {
+				logger.InfofContext(ctx, "deleted %d expired events", deleted)
+			}
+		}
+	}
+}
+
+func waitForShutdown(ctx context.Context, serverErrors <-chan error, grpcErrors <-chan error) error {
 	select {
 	case <-ctx.Done():
 		return nil
 	case err := <-serverErrors:
 		return fmt.Errorf("server error: %w", err)
+	case err := <-grpcErrors:
+		return fmt.Errorf("grpc server error: %w", err)
 	}
 }
 
-func shutdownServer(ctx context.Context, logger *log.Logger, server *http.Server, isShuttingDown *atomic.Bool) error {
+func shutdownServer(
+	ctx context.Context,
+	logger *log.Logger,
+	server *http.Server,
+	grpcServer *grpc.Server,
+	isShuttingDown *atomic.Bool,
+	cfg config.Shutdown,
+) error {
 	logger.InfoContext(ctx, "shutdown signal received")
 
-	time.Sleep(readinessDrainDelay)
+	// /readyz начинает отвечать 503 сразу, чтобы балансировщик успел снять трафик
+	// до остановки сервера.
 	isShuttingDown.Store(true)
+	time.Sleep(cfg.ReadinessDrainDelay)
 	logger.InfoContext(ctx, "draining ongoing requests...")
 
-	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownPeriod)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.Period)
 	defer cancel()
 
+	// HTTP и gRPC останавливаются параллельно и делят один период ожидания.
+	grpcStopped := make(chan error, 1)
+	go func() {
+		grpcStopped <- stopGRPCServer(shutdownCtx, grpcServer)
+	}()
+
+	var shutdownErr error
 	if err := server.Shutdown(shutdownCtx); err != nil {
-		logger.InfofContext(ctx, "graceful shutdown failed: %v", err)
-		time.Sleep(shutdownHardPeriod)
-		return fmt.Errorf("server shutdown: %w", err)
+		shutdownErr = fmt.Errorf("server shutdown: %w", err)
+	}
+	if err := <-grpcStopped; err != nil {
+		shutdownErr = errors.Join(shutdownErr, fmt.Errorf("grpc server shutdown: %w", err))
+	}
+
+	if shutdownErr != nil {
+		logger.InfofContext(ctx, "graceful shutdown failed: %v", shutdownErr)
+		time.Sleep(cfg.HardPeriod)
+		return shutdownErr
 	}
 
 	logger.InfoContext(ctx, "server stopped gracefully")
 	return nil
 }
+
+// stopGRPCServer ждёт завершения текущих вызовов, а по истечении ctx обрывает их.
+func stopGRPCServer(ctx context.Context, server *grpc.Server) error {
+	if server == nil {
+		return nil
+	}
+
+	stopped := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		server.Stop()
+		return ctx.Err()
+	}
+}
@@ -1,42 +1,240 @@
 package config
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
+	"io"
+	"log/slog"
+	"maps"
 	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
 )
 
+// configFileEnv переменная окружения с путём к YAML-файлу конфигурации.
+const configFileEnv = "CONFIG_FILE"
+
 type (
 	Server struct {
-		Port string
+		Port              string        `yaml:"port"`
+		ReadHeaderTimeout time.Duration `yaml:"read_header_timeout"`
+		ReadTimeout       time.Duration `yaml:"read_timeout"`
+		WriteTimeout      time.Duration `yaml:"write_timeout"`
+		IdleTimeout       time.Duration `yaml:"idle_timeout"`
+		// RequestTimeout дедлайн контекста обработки запроса API.
+		RequestTimeout time.Duration `yaml:"request_timeout"`
+		// MaxBodyBytes лимит тела запроса по умолчанию, BodyLimits — лимиты маршрутов.
+		MaxBodyBytes int64            `yaml:"max_body_bytes"`
+		BodyLimits   map[string]int64 `yaml:"body_limits"`
+	}
+
+	// GRPC gRPC API на отдельном порту.
+	GRPC struct {
+		Enabled bool   `yaml:"enabled"`
+		Port    string `yaml:"port"`
+	}
+
+	Pool struct {
+		MaxConns          int32         `yaml:"max_conns"`
+		MinConns          int32         `yaml:"min_conns"`
+		MaxConnLifetime   time.Duration `yaml:"max_conn_lifetime"`
+		MaxConnIdleTime   time.Duration `yaml:"max_conn_idle_time"`
+		HealthCheckPeriod time.Duration `yaml:"health_check_period"`
+		ConnectTimeout    time.Duration `yaml:"connect_timeout"`
 	}
 
 	Postgres struct {
-		User     string
-		Password string
-		Host     string
-		Port     string
-		DB       string
+		User     string `yaml:"user"`
+		Password string `yaml:"password"`
+		Host     string `yaml:"host"`
+		Port     string `yaml:"port"`
+		DB       string `yaml:"db"`
+		Pool     Pool   `yaml:"pool"`
+	}
+
+	// Shutdown периоды остановки: сначала ReadinessDrainDelay /readyz отвечает 503,
+	// затем до Period ждём завершения текущих запросов, затем ещё HardPeriod перед выходом.
+	Shutdown struct {
+		ReadinessDrainDelay time.Duration `yaml:"readiness_drain_delay"`
+		Period              time.Duration `yaml:"period"`
+		HardPeriod          time.Duration `yaml:"hard_period"`
+	}
+
+	// Assignment политика назначения ревьюверов по умолчанию.
+	Assignment struct {
+		MaxReviewers int `yaml:"max_reviewers"`
+	}
+
+	// Features переключатели необязательных возможностей.
+	Features struct {
+		Idempotency bool `yaml:"idempotency"`
+		// RequestValidation проверяет запросы по api/openapi.yaml, ResponseValidation — ещё и ответы (для тестов).
+		RequestValidation  bool `yaml:"request_validation"`
+		ResponseValidation bool `yaml:"response_validation"`
+		// Docs публикует спецификацию на /openapi.yaml и /openapi.json и страницу документации на /docs/.
+		Docs bool `yaml:"docs"`
+		// Events включает поток событий /events/stream.
+		Events bool `yaml:"events"`
+		// SCIM включает провижининг пользователей и команд по SCIM 2.0 на /scim/v2.
+		SCIM bool `yaml:"scim"`
+	}
+
+	// Events хранение событий: возобновить поток по Last-Event-ID можно в пределах Retention.
+	Events struct {
+		Retention time.Duration `yaml:"retention"`
+	}
+
+	LogSampling struct {
+		Initial    int           `yaml:"initial"`
+		Thereafter int           `yaml:"thereafter"`
+		Tick       time.Duration `yaml:"tick"`
+	}
+
+	Log struct {
+		Level         slog.Level            `yaml:"level"`
+		PackageLevels map[string]slog.Level `yaml:"package_levels"`
+		Format        string                `yaml:"format"`
+		Sampling      LogSampling           `yaml:"sampling"`
+	}
+
+	Tracing struct {
+		Enabled     bool    `yaml:"enabled"`
+		Endpoint    string  `yaml:"otlp_endpoint"`
+		Insecure    bool    `yaml:"otlp_insecure"`
+		SampleRatio float64 `yaml:"sample_ratio"`
+		ServiceName string  `yaml:"service_name"`
+	}
+
+	JWT struct {
+		JWKSSource string `yaml:"jwks"`
+		Issuer     string `yaml:"issuer"`
+		Audience   string `yaml:"audience"`
+		UserClaim  string `yaml:"user_claim"`
+		OrgClaim   string `yaml:"org_claim"`
+	}
+
+	Auth struct {
+		Enabled         bool   `yaml:"enabled"`
+		BootstrapAPIKey string `yaml:"bootstrap_api_key"`
+		JWT             JWT    `yaml:"jwt"`
+	}
+
+	// RateLimit лимит token bucket: RPS токенов в секунду, ёмкость Burst.
+	RateLimit struct {
+		RPS   float64 `yaml:"rps"`
+		Burst int     `yaml:"burst"`
+	}
+
+	RateLimiting struct {
+		Enabled    bool                 `yaml:"enabled"`
+		TrustProxy bool                 `yaml:"trust_proxy"`
+		Default    RateLimit            `yaml:"default"`
+		Routes     map[string]RateLimit `yaml:"routes"`
+		IP         RateLimit            `yaml:"ip"`
 	}
 
 	Config struct {
-		Server   Server
-		Postgres Postgres
+		Server       Server       `yaml:"server"`
+		GRPC         GRPC         `yaml:"grpc"`
+		Postgres     Postgres     `yaml:"postgres"`
+		Shutdown     Shutdown     `yaml:"shutdown"`
+		Assignment   Assignment   `yaml:"assignment"`
+		Features     Features     `yaml:"features"`
+		Events       Events       `yaml:"events"`
+		Log          Log          `yaml:"log"`
+		Tracing      Tracing      `yaml:"tracing"`
+		Auth         Auth         `yaml:"auth"`
+		RateLimiting RateLimiting `yaml:"rate_limiting"`
 	}
 )
 
-func Load() (*Config, error) {
-	cfg := &Config{
+// Default возвращает значения по умолчанию. Они же перечислены в config.example.yaml.
+func Default() *Config {
+	return &Config{
 		Server: Server{
-			Port: os.Getenv("SERVER_PORT"),
+			ReadHeaderTimeout: 5 * time.Second,
+			ReadTimeout:       15 * time.Second,
+			WriteTimeout:      30 * time.Second,
+			IdleTimeout:       2 * time.Minute,
+			RequestTimeout:    10 * time.Second,
+			MaxBodyBytes:      1 << 20,
+		},
+		GRPC: GRPC{
+			Port: "9090",
 		},
 		Postgres: Postgres{
-			User:     os.Getenv("POSTGRES_USER"),
-			Password: os.Getenv("POSTGRES_PASSWORD"),
-			Host:     os.Getenv("POSTGRES_HOST"),
-			Port:     os.Getenv("POSTGRES_PORT"),
-			DB:       os.Getenv("POSTGRES_DB"),
+			Pool: Pool{
+				MaxConns:          12,
+				MinConns:          2,
+				MaxConnLifetime:   5 * time.Minute,
+				MaxConnIdleTime:   30 * time.Minute,
+				HealthCheckPeriod: time.Minute,
+				ConnectTimeout:    5 * time.Second,
+			},
+		},
+		Shutdown: Shutdown{
+			ReadinessDrainDelay: 5 * time.Second,
+			Period:              15 * time.Second,
+			HardPeriod:          3 * time.Second,
+		},
+		Assignment: Assignment{
+			MaxReviewers: 2,
 		},
+		Features: Features{
+			Idempotency:       true,
+			RequestValidation: true,
+			Docs:              true,
+			Events:            true,
+		},
+		Events: Events{
+			Retention: 7 * 24 * time.Hour,
+		},
+		Log: Log{
+			Level:  slog.LevelInfo,
+			Format: "json",
+			Sampling: LogSampling{
+				Thereafter: 100,
+				Tick:       time.Second,
+			},
+		},
+		Tracing: Tracing{
+			Endpoint:    "localhost:4318",
+			Insecure:    true,
+			SampleRatio: 1,
+			ServiceName: "service-pr-reviewer-assignment",
+		},
+		Auth: Auth{
+			JWT: JWT{
+				UserClaim: "sub",
+				OrgClaim:  "org_id",
+			},
+		},
+		RateLimiting: RateLimiting{
+			Enabled: true,
+			Default: RateLimit{RPS: 20, Burst: 40},
+			IP:      RateLimit{RPS: 100, Burst: 200},
+		},
+	}
+}
+
+// Load собирает конфигурацию в порядке возрастания приоритета: значения по умолчанию,
+// YAML-файл из CONFIG_FILE (если задан) и переменные окружения.
+func Load() (*Config, error) {
+	cfg := Default()
+
+	if path := os.Getenv(configFileEnv); path != "" {
+		if err := cfg.loadFile(path); err != nil {
+			return nil, fmt.Errorf("load config file %s: %w", path, err)
+		}
+	}
+
+	if err := cfg.applyEnv(); err != nil {
+		return nil, fmt.Errorf("parse environment variables: %w", err)
 	}
 
 	if err := cfg.validate(); err != nil {
@@ -46,31 +244,422 @@ func Load() (*Config, error) {
 	return cfg, nil
 }
 
+// loadFile накладывает значения из файла поверх текущих. Неизвестные ключи считаются
+// ошибкой, чтобы опечатка не превращалась молча в значение по умолчанию.
+func (c *Config) loadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read file: %w", err)
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(c); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("decode yaml: %w", err)
+	}
+
+	return nil
+}
+
+// applyEnv переопределяет значения заданными переменными окружения.
+func (c *Config) applyEnv() error {
+	var parseErrs error
+
+	c.Server.Port = getEnv("SERVER_PORT", c.Server.Port)
+	c.Server.ReadHeaderTimeout = getEnvDuration(&parseErrs, "SERVER_READ_HEADER_TIMEOUT", c.Server.ReadHeaderTimeout)
+	c.Server.ReadTimeout = getEnvDuration(&parseErrs, "SERVER_READ_TIMEOUT", c.Server.ReadTimeout)
+	c.Server.WriteTimeout = getEnvDuration(&parseErrs, "SERVER_WRITE_TIMEOUT", c.Server.WriteTimeout)
+	c.Server.IdleTimeout = getEnvDuration(&parseErrs, "SERVER_IDLE_TIMEOUT", c.Server.IdleTimeout)
+	c.Server.RequestTimeout = getEnvDuration(&parseErrs, "SERVER_REQUEST_TIMEOUT", c.Server.RequestTimeout)
+	c.Server.MaxBodyBytes = int64(getEnvInt(&parseErrs, "SERVER_MAX_BODY_BYTES", int(c.Server.MaxBodyBytes)))
+	c.Server.BodyLimits = getEnvRouteBodyLimits(&parseErrs, "SERVER_BODY_LIMITS", c.Server.BodyLimits)
+
+	c.GRPC.Enabled = getEnvBool(&parseErrs, "GRPC_ENABLED", c.GRPC.Enabled)
+	c.GRPC.Port = getEnv("GRPC_PORT", c.GRPC.Port)
+
+	c.Postgres.User = getEnv("POSTGRES_USER", c.Postgres.User)
+	c.Postgres.Password = getEnv("POSTGRES_PASSWORD", c.Postgres.Password)
+	c.Postgres.Host = getEnv("POSTGRES_HOST", c.Postgres.Host)
+	c.Postgres.Port = getEnv("POSTGRES_PORT", c.Postgres.Port)
+	c.Postgres.DB = getEnv("POSTGRES_DB", c.Postgres.DB)
+	c.Postgres.Pool.MaxConns = int32(getEnvInt(&parseErrs, "POSTGRES_POOL_MAX_CONNS", int(c.Postgres.Pool.MaxConns)))
+	c.Postgres.Pool.MinConns = int32(getEnvInt(&parseErrs, "POSTGRES_POOL_MIN_CONNS", int(c.Postgres.Pool.MinConns)))
+	c.Postgres.Pool.MaxConnLifetime = getEnvDuration(&parseErrs, "POSTGRES_POOL_MAX_CONN_LIFETIME", c.Postgres.Pool.MaxConnLifetime)
+	c.Postgres.Pool.MaxConnIdleTime = getEnvDuration(&parseErrs, "POSTGRES_POOL_MAX_CONN_IDLE_TIME", c.Postgres.Pool.MaxConnIdleTime)
+	c.Postgres.Pool.HealthCheckPeriod = getEnvDuration(&parseErrs, "POSTGRES_POOL_HEALTH_CHECK_PERIOD", c.Postgres.Pool.HealthCheckPeriod)
+	c.Postgres.Pool.ConnectTimeout = getEnvDuration(&parseErrs, "POSTGRES_POOL_CONNECT_TIMEOUT", c.Postgres.Pool.ConnectTimeout)
+
+	c.Shutdown.ReadinessDrainDelay = getEnvDuration(&parseErrs, "SHUTDOWN_READINESS_DRAIN_DELAY", c.Shutdown.ReadinessDrainDelay)
+	c.Shutdown.Period = getEnvDuration(&parseErrs, "SHUTDOWN_PERIOD", c.Shutdown.Period)
+	c.Shutdown.HardPeriod = getEnvDuration(&parseErrs, "SHUTDOWN_HARD_PERIOD", c.Shutdown.HardPeriod)
+
+	c.Assignment.MaxReviewers = getEnvInt(&parseErrs, "ASSIGNMENT_MAX_REVIEWERS", c.Assignment.MaxReviewers)
+
+	c.Features.Idempotency = getEnvBool(&parseErrs, "FEATURE_IDEMPOTENCY", c.Features.Idempotency)
+	c.Features.RequestValidation = getEnvBool(&parseErrs, "FEATURE_REQUEST_VALIDATION", c.Features.RequestValidation)
+	c.Features.ResponseValidation = getEnvBool(&parseErrs, "FEATURE_RESPONSE_VALIDATION", c.Features.ResponseValidation)
+	c.Features.Docs = getEnvBool(&parseErrs, "FEATURE_DOCS", c.Features.Docs)
+	c.Features.Events = getEnvBool(&parseErrs, "FEATURE_EVENTS", c.Features.Events)
+	c.Features.SCIM = getEnvBool(&parseErrs, "FEATURE_SCIM", c.Features.SCIM)
+
+	c.Events.Retention = getEnvDuration(&parseErrs, "EVENTS_RETENTION", c.Events.Retention)
+
+	c.Log.Level = getEnvLogLevel(&parseErrs, "LOG_LEVEL", c.Log.Level)
+	c.Log.PackageLevels = getEnvPackageLogLevels(&parseErrs, "LOG_PACKAGE_LEVELS", c.Log.PackageLevels)
+	c.Log.Format = getEnv("LOG_FORMAT", c.Log.Format)
+	c.Log.Sampling.Initial = getEnvInt(&parseErrs, "LOG_SAMPLING_INITIAL", c.Log.Sampling.Initial)
+	c.Log.Sampling.Thereafter = getEnvInt(&parseErrs, "LOG_SAMPLING_THEREAFTER", c.Log.Sampling.Thereafter)
+	c.Log.Sampling.Tick = getEnvDuration(&parseErrs, "LOG_SAMPLING_TICK", c.Log.Sampling.Tick)
+
+	c.Tracing.Enabled = getEnvBool(&parseErrs, "TRACING_ENABLED", c.Tracing.Enabled)
+	c.Tracing.Endpoint = getEnv("TRACING_OTLP_ENDPOINT", c.Tracing.Endpoint)
+	c.Tracing.Insecure = getEnvBool(&parseErrs, "TRACING_OTLP_INSECURE", c.Tracing.Insecure)
+	c.Tracing.SampleRatio = getEnvFloat(&parseErrs, "TRACING_SAMPLE_RATIO", c.Tracing.SampleRatio)
+	c.Tracing.ServiceName = getEnv("TRACING_SERVICE_NAME", c.Tracing.ServiceName)
+
+	c.Auth.Enabled = getEnvBool(&parseErrs, "AUTH_ENABLED", c.Auth.Enabled)
+	c.Auth.BootstrapAPIKey = getEnv("AUTH_BOOTSTRAP_API_KEY", c.Auth.BootstrapAPIKey)
+	c.Auth.JWT.JWKSSource = getEnv("AUTH_JWT_JWKS", c.Auth.JWT.JWKSSource)
+	c.Auth.JWT.Issuer = getEnv("AUTH_JWT_ISSUER", c.Auth.JWT.Issuer)
+	c.Auth.JWT.Audience = getEnv("AUTH_JWT_AUDIENCE", c.Auth.JWT.Audience)
+	c.Auth.JWT.UserClaim = getEnv("AUTH_JWT_USER_CLAIM", c.Auth.JWT.UserClaim)
+	c.Auth.JWT.OrgClaim = getEnv("AUTH_JWT_ORG_CLAIM", c.Auth.JWT.OrgClaim)
+
+	c.RateLimiting.Enabled = getEnvBool(&parseErrs, "RATE_LIMIT_ENABLED", c.RateLimiting.Enabled)
+	c.RateLimiting.TrustProxy = getEnvBool(&parseErrs, "RATE_LIMIT_TRUST_PROXY", c.RateLimiting.TrustProxy)
+	c.RateLimiting.Default = getEnvRateLimit(&parseErrs, "RATE_LIMIT_DEFAULT", c.RateLimiting.Default)
+	c.RateLimiting.Routes = getEnvRouteRateLimits(&parseErrs, "RATE_LIMIT_ROUTES", c.RateLimiting.Routes)
+	c.RateLimiting.IP = getEnvRateLimit(&parseErrs, "RATE_LIMIT_IP", c.RateLimiting.IP)
+
+	return parseErrs
+}
+
 func (c *Config) validate() error {
-	var errs error
+	var missing error
 
 	if c.Server.Port == "" {
-		errs = errors.Join(errs, errors.New("SERVER_PORT environment variable"))
+		missing = errors.Join(missing, errors.New("server.port (SERVER_PORT)"))
+	}
+	if c.GRPC.Enabled && c.GRPC.Port == "" {
+		missing = errors.Join(missing, errors.New("grpc.port (GRPC_PORT)"))
 	}
 	if c.Postgres.User == "" {
-		errs = errors.Join(errs, errors.New("POSTGRES_USER environment variable"))
+		missing = errors.Join(missing, errors.New("postgres.user (POSTGRES_USER)"))
 	}
 	if c.Postgres.Password == "" {
-		errs = errors.Join(errs, errors.New("POSTGRES_PASSWORD environment variable"))
+		missing = errors.Join(missing, errors.New("postgres.password (POSTGRES_PASSWORD)"))
 	}
 	if c.Postgres.Host == "" {
-		errs = errors.Join(errs, errors.New("POSTGRES_HOST environment variable"))
+		missing = errors.Join(missing, errors.New("postgres.host (POSTGRES_HOST)"))
 	}
 	if c.Postgres.Port == "" {
-		errs = errors.Join(errs, errors.New("POSTGRES_PORT environment variable"))
+		missing = errors.Join(missing, errors.New("postgres.port (POSTGRES_PORT)"))
 	}
 	if c.Postgres.DB == "" {
-		errs = errors.Join(errs, errors.New("POSTGRES_DB environment variable"))
+		missing = errors.Join(missing, errors.New("postgres.db (POSTGRES_DB)"))
 	}
 
-	if errs != nil {
-		return fmt.Errorf("missing required environment variables: %w", errs)
+	var invalid error
+
+	// Срез, а не map: ошибки выводятся в порядке настроек в файле.
+	for _, timeout := range []struct {
+		name  string
+		value time.Duration
+	}{
+		{name: "server.read_header_timeout (SERVER_READ_HEADER_TIMEOUT)", value: c.Server.ReadHeaderTimeout},
+		{name: "server.read_timeout (SERVER_READ_TIMEOUT)", value: c.Server.ReadTimeout},
+		{name: "server.write_timeout (SERVER_WRITE_TIMEOUT)", value: c.Server.WriteTimeout},
+		{name: "server.idle_timeout (SERVER_IDLE_TIMEOUT)", value: c.Server.IdleTimeout},
+		{name: "server.request_timeout (SERVER_REQUEST_TIMEOUT)", value: c.Server.RequestTimeout},
+		{name: "postgres.pool.connect_timeout (POSTGRES_POOL_CONNECT_TIMEOUT)", value: c.Postgres.Pool.ConnectTimeout},
+		{name: "shutdown.period (SHUTDOWN_PERIOD)", value: c.Shutdown.Period},
+		{name: "events.retention (EVENTS_RETENTION)", value: c.Events.Retention},
+	} {
+		if timeout.value <= 0 {
+			invalid = errors.Join(invalid, fmt.Errorf("%s must be positive", timeout.name))
+		}
+	}
+	// Иначе сервер оборвёт соединение раньше, чем обработчик успеет ответить TIMEOUT.
+	if c.Server.RequestTimeout >= c.Server.WriteTimeout {
+		invalid = errors.Join(invalid, errors.New("server.request_timeout (SERVER_REQUEST_TIMEOUT) must be less than server.write_timeout (SERVER_WRITE_TIMEOUT)"))
+	}
+	if c.Server.MaxBodyBytes <= 0 {
+		invalid = errors.Join(invalid, errors.New("server.max_body_bytes (SERVER_MAX_BODY_BYTES) must be positive"))
+	}
+	for _, route := range slices.Sorted(maps.Keys(c.Server.BodyLimits)) {
+		if c.Server.BodyLimits[route] <= 0 {
+			invalid = errors.Join(invalid, fmt.Errorf("server.body_limits (SERVER_BODY_LIMITS) of route %s must be positive", route))
+		}
 	}
 
-	return nil
+	if c.GRPC.Enabled && c.GRPC.Port == c.Server.Port {
+		invalid = errors.Join(invalid, errors.New("grpc.port (GRPC_PORT) must differ from server.port (SERVER_PORT)"))
+	}
+
+	if c.Shutdown.ReadinessDrainDelay < 0 || c.Shutdown.HardPeriod < 0 {
+		invalid = errors.Join(invalid, errors.New("shutdown.readiness_drain_delay (SHUTDOWN_READINESS_DRAIN_DELAY) and shutdown.hard_period (SHUTDOWN_HARD_PERIOD) must not be negative"))
+	}
+
+	if c.Postgres.Pool.MaxConns <= 0 {
+		invalid = errors.Join(invalid, errors.New("postgres.pool.max_conns (POSTGRES_POOL_MAX_CONNS) must be positive"))
+	}
+	if c.Postgres.Pool.MinConns < 0 || c.Postgres.Pool.MinConns > c.Postgres.Pool.MaxConns {
+		invalid = errors.Join(invalid, errors.New("postgres.pool.min_conns (POSTGRES_POOL_MIN_CONNS) must be within [0, postgres.pool.max_conns]"))
+	}
+
+	if c.Features.ResponseValidation && !c.Features.RequestValidation {
+		invalid = errors.Join(invalid, errors.New("features.response_validation (FEATURE_RESPONSE_VALIDATION) requires features.request_validation (FEATURE_REQUEST_VALIDATION)"))
+	}
+
+	if c.Assignment.MaxReviewers <= 0 {
+		invalid = errors.Join(invalid, errors.New("assignment.max_reviewers (ASSIGNMENT_MAX_REVIEWERS) must be positive"))
+	}
+
+	if c.Log.Format != "json" && c.Log.Format != "text" {
+		invalid = errors.Join(invalid, errors.New("log.format (LOG_FORMAT) must be json or text"))
+	}
+	if c.Log.Sampling.Initial < 0 || c.Log.Sampling.Thereafter < 0 {
+		invalid = errors.Join(invalid, errors.New("log.sampling.initial (LOG_SAMPLING_INITIAL) and log.sampling.thereafter (LOG_SAMPLING_THEREAFTER) must not be negative"))
+	}
+	if c.Log.Sampling.Tick <= 0 {
+		invalid = errors.Join(invalid, errors.New("log.sampling.tick (LOG_SAMPLING_TICK) must be positive"))
+	}
+
+	if c.Tracing.Enabled && c.Tracing.Endpoint == "" {
+		invalid = errors.Join(invalid, errors.New("tracing.otlp_endpoint (TRACING_OTLP_ENDPOINT) must be set when tracing.enabled (TRACING_ENABLED) is true"))
+	}
+	if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+		invalid = errors.Join(invalid, errors.New("tracing.sample_ratio (TRACING_SAMPLE_RATIO) must be within [0, 1]"))
+	}
+
+	// Без начального ключа и JWKS к сервису с включённой аутентификацией некому обратиться.
+	if c.Auth.Enabled && c.Auth.BootstrapAPIKey == "" && c.Auth.JWT.JWKSSource == "" {
+		invalid = errors.Join(invalid, errors.New("auth.enabled (AUTH_ENABLED) requires auth.bootstrap_api_key (AUTH_BOOTSTRAP_API_KEY) or auth.jwt.jwks (AUTH_JWT_JWKS)"))
+	}
+	if c.Auth.BootstrapAPIKey != "" && len(c.Auth.BootstrapAPIKey) < 32 {
+		invalid = errors.Join(invalid, errors.New("auth.bootstrap_api_key (AUTH_BOOTSTRAP_API_KEY) must be at least 32 characters long"))
+	}
+
+	if c.Auth.JWT.JWKSSource != "" && c.Auth.JWT.UserClaim == "" {
+		invalid = errors.Join(invalid, errors.New("auth.jwt.user_claim (AUTH_JWT_USER_CLAIM) must not be empty when auth.jwt.jwks (AUTH_JWT_JWKS) is set"))
+	}
+
+	for _, route := range slices.Sorted(maps.Keys(c.RateLimiting.Routes)) {
+		if limit := c.RateLimiting.Routes[route]; limit.RPS <= 0 || limit.Burst <= 0 {
+			invalid = errors.Join(invalid, fmt.Errorf("rate_limiting.routes (RATE_LIMIT_ROUTES) of route %s must have positive rps and burst", route))
+		}
+	}
+	if c.RateLimiting.Default.RPS <= 0 || c.RateLimiting.Default.Burst <= 0 {
+		invalid = errors.Join(invalid, errors.New("rate_limiting.default (RATE_LIMIT_DEFAULT) must have positive rps and burst"))
+	}
+	if c.RateLimiting.IP.RPS <= 0 || c.RateLimiting.IP.Burst <= 0 {
+		invalid = errors.Join(invalid, errors.New("rate_limiting.ip (RATE_LIMIT_IP) must have positive rps and burst"))
+	}
+
+	var errs error
+	if missing != nil {
+		errs = errors.Join(errs, fmt.Errorf("missing required settings: %w", missing))
+	}
+	if invalid != nil {
+		errs = errors.Join(errs, fmt.Errorf("invalid settings: %w", invalid))
+	}
+
+	return errs
+}
+
+func getEnv(key, def string) string {
+	if value, ok := os.LookupEnv(key); ok {
+		return value
+	}
+	return def
+}
+
+func getEnvBool(errs *error, key string, def bool) bool {
+	value, ok := os.LookupEnv(key)
+	if !ok || value == "" {
+		return def
+	}
+
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		*errs = errors.Join(*errs, fmt.Errorf("%s: %w", key, err))
+		return def
+	}
+	return parsed
+}
+
+func getEnvFloat(errs *error, key string, def float64) float64 {
+	value, ok := os.LookupEnv(key)
+	if !ok || value == "" {
+		return def
+	}
+
+	parsed, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		*errs = errors.Join(*errs, fmt.Errorf("%s: %w", key, err))
+		return def
+	}
+	return parsed
+}
+
+func getEnvInt(errs *error, key string, def int) int {
+	value, ok := os.LookupEnv(key)
+	if !ok || value == "" {
+		return def
+	}
+
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		*errs = errors.Join(*errs, fmt.Errorf("%s: %w", key, err))
+		return def
+	}
+	return parsed
+}
+
+func getEnvDuration(errs *error, key string, def time.Duration) time.Duration {
+	value, ok := os.LookupEnv(key)
+	if !ok || value == "" {
+		return def
+	}
+
+	parsed, err := time.ParseDuration(value)
+	if err != nil {
+		*errs = errors.Join(*errs, fmt.Errorf("%s: %w", key, err))
+		return def
+	}
+	return parsed
+}
+
+// getEnvLogLevel разбирает уровень slog: debug, info, warn, error (регистр не важен).
+func getEnvLogLevel(errs *error, key string, def slog.Level) slog.Level {
+	value, ok := os.LookupEnv(key)
+	if !ok || value == "" {
+		return def
+	}
+
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(value)); err != nil {
+		*errs = errors.Join(*errs, fmt.Errorf("%s: %w", key, err))
+		return def
+	}
+	return level
+}
+
+// getEnvPackageLogLevels разбирает уровни пакетов в формате
+// "internal/storage=debug,pkg/tx=warn". Заданная переменная заменяет уровни из файла целиком.
+func getEnvPackageLogLevels(errs *error, key string, def map[string]slog.Level) map[string]slog.Level {
+	value := os.Getenv(key)
+	if value == "" {
+		return def
+	}
+
+	levels := make(map[string]slog.Level)
+
+	for _, item := range strings.Split(value, ",") {
+		pkg, raw, ok := strings.Cut(strings.TrimSpace(item), "=")
+		if !ok || pkg == "" {
+			*errs = errors.Join(*errs, fmt.Errorf("%s: invalid item %q, expected package=level", key, item))
+			continue
+		}
+
+		var level slog.Level
+		if err := level.UnmarshalText([]byte(raw)); err != nil {
+			*errs = errors.Join(*errs, fmt.Errorf("%s: package %s: %w", key, pkg, err))
+			continue
+		}
+		levels[pkg] = level
+	}
+
+	return levels
+}
+
+// getEnvRouteBodyLimits разбирает лимиты тела запроса маршрутов в байтах в формате
+// "/team/add=4194304". Заданная переменная заменяет лимиты из файла целиком.
+func getEnvRouteBodyLimits(errs *error, key string, def map[string]int64) map[string]int64 {
+	value := os.Getenv(key)
+	if value == "" {
+		return def
+	}
+
+	limits := make(map[string]int64)
+	for _, item := range strings.Split(value, ",") {
+		route, raw, ok := strings.Cut(strings.TrimSpace(item), "=")
+		if !ok || route == "" {
+			*errs = errors.Join(*errs, fmt.Errorf("%s: invalid item %q, expected route=bytes", key, item))
+			continue
+		}
+
+		limit, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			*errs = errors.Join(*errs, fmt.Errorf("%s: route %s: %w", key, route, err))
+			continue
+		}
+		limits[route] = limit
+	}
+
+	return limits
+}
+
+// getEnvRateLimit разбирает лимит в формате "rps:burst", например "5:10".
+func getEnvRateLimit(errs *error, key string, def RateLimit) RateLimit {
+	value, ok := os.LookupEnv(key)
+	if !ok || value == "" {
+		return def
+	}
+
+	limit, err := parseRateLimit(value)
+	if err != nil {
+		*errs = errors.Join(*errs, fmt.Errorf("%s: %w", key, err))
+		return def
+	}
+	return limit
+}
+
+// getEnvRouteRateLimits разбирает лимиты маршрутов в формате
+// "/pullRequest/create=2:5,/team/add=1:2". Заданная переменная заменяет лимиты из файла целиком.
+func getEnvRouteRateLimits(errs *error, key string, def map[string]RateLimit) map[string]RateLimit {
+	value := os.Getenv(key)
+	if value == "" {
+		return def
+	}
+
+	limits := make(map[string]RateLimit)
+
+	for _, item := range strings.Split(value, ",") {
+		route, raw, ok := strings.Cut(strings.TrimSpace(item), "=")
+		if !ok || route == "" {
+			*errs = errors.Join(*errs, fmt.Errorf("%s: invalid item %q, expected route=rps:burst", key, item))
+			continue
+		}
+
+		limit, err := parseRateLimit(raw)
+		if err != nil {
+			*errs = errors.Join(*errs, fmt.Errorf("%s: route %s: %w", key, route, err))
+			continue
+		}
+		limits[route] = limit
+	}
+
+	return limits
+}
+
+func parseRateLimit(value string) (RateLimit, error) {
+	rawRPS, rawBurst, ok := strings.Cut(value, ":")
+	if !ok {
+		return RateLimit{}, fmt.Errorf("invalid rate limit %q, expected rps:burst", value)
+	}
+
+	rps, err := strconv.ParseFloat(rawRPS, 64)
+	if err != nil {
+		return RateLimit{}, fmt.Errorf("parse rps: %w", err)
+	}
+	burst, err := strconv.Atoi(rawBurst)
+	if err != nil {
+		return RateLimit{}, fmt.Errorf("parse burst: %w", err)
+	}
+	if rps <= 0 || burst <= 0 {
+		return RateLimit{}, fmt.Errorf("rps and burst must be positive in %q", value)
+	}
+
+	return RateLimit{RPS: rps, Burst: burst}, nil
 }
@@ -0,0 +1,198 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// setRequiredEnv задаёт обязательные настройки, которые нельзя взять по умолчанию.
+func setRequiredEnv(t *testing.T) {
+	t.Helper()
+
+	t.Setenv(configFileEnv, "")
+	t.Setenv("SERVER_PORT", "8080")
+	t.Setenv("POSTGRES_USER", "reviewer")
+	t.Setenv("POSTGRES_PASSWORD", "secret")
+	t.Setenv("POSTGRES_HOST", "localhost")
+	t.Setenv("POSTGRES_PORT", "5432")
+	t.Setenv("POSTGRES_DB", "reviewer")
+	t.Setenv("AUTH_ENABLED", "")
+	t.Setenv("AUTH_BOOTSTRAP_API_KEY", "")
+	t.Setenv("AUTH_JWT_JWKS", "")
+}
+
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadDefaults(t *testing.T) {
+	setRequiredEnv(t)
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if cfg.Server.RequestTimeout != 10*time.Second || cfg.Assignment.MaxReviewers != 2 {
+		t.Errorf("defaults are not applied: %+v", cfg.Server)
+	}
+	if cfg.Auth.Enabled {
+		t.Error("auth is enabled by default")
+	}
+}
+
+func TestLoadPrecedence(t *testing.T) {
+	setRequiredEnv(t)
+	t.Setenv(configFileEnv, writeConfig(t, `
+server:
+  port: "8081"
+  request_timeout: 5s
+log:
+  format: text
+assignment:
+  max_reviewers: 3
+`))
+	t.Setenv("SERVER_PORT", "9000")
+	t.Setenv("ASSIGNMENT_MAX_REVIEWERS", "4")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if cfg.Server.Port != "9000" {
+		t.Errorf("server.port = %q, want the environment value", cfg.Server.Port)
+	}
+	if cfg.Assignment.MaxReviewers != 4 {
+		t.Errorf("assignment.max_reviewers = %d, want the environment value", cfg.Assignment.MaxReviewers)
+	}
+	if cfg.Server.RequestTimeout != 5*time.Second || cfg.Log.Format != "text" {
+		t.Errorf("file values are not applied: request_timeout %s, log.format %s", cfg.Server.RequestTimeout, cfg.Log.Format)
+	}
+	if cfg.Server.WriteTimeout != 30*time.Second {
+		t.Errorf("server.write_timeout = %s, want the default", cfg.Server.WriteTimeout)
+	}
+}
+
+func TestLoadRejectsUnknownKeys(t *testing.T) {
+	setRequiredEnv(t)
+	t.Setenv(configFileEnv, writeConfig(t, `
+server:
+  request_timout: 5s
+`))
+
+	_, err := Load()
+	if err == nil || !strings.Contains(err.Error(), "request_timout") {
+		t.Errorf("err = %v, want the unknown key named", err)
+	}
+}
+
+func TestLoadReportsEnvironmentErrors(t *testing.T) {
+	setRequiredEnv(t)
+	t.Setenv("SERVER_READ_TIMEOUT", "fast")
+	t.Setenv("GRPC_ENABLED", "sure")
+
+	_, err := Load()
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	for _, want := range []string{"SERVER_READ_TIMEOUT", "GRPC_ENABLED"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("err = %v, want %s named", err, want)
+		}
+	}
+}
+
+func TestValidateCombinesErrors(t *testing.T) {
+	setRequiredEnv(t)
+	t.Setenv("POSTGRES_USER", "")
+	t.Setenv("POSTGRES_DB", "")
+	t.Setenv("SERVER_READ_TIMEOUT", "0s")
+	t.Setenv("SHUTDOWN_PERIOD", "-1s")
+	t.Setenv("EVENTS_RETENTION", "0s")
+	t.Setenv("LOG_FORMAT", "xml")
+
+	_, err := Load()
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	want := strings.Join([]string{
+		"validate config: missing required settings: postgres.user (POSTGRES_USER)",
+		"postgres.db (POSTGRES_DB)",
+		"invalid settings: server.read_timeout (SERVER_READ_TIMEOUT) must be positive",
+		"shutdown.period (SHUTDOWN_PERIOD) must be positive",
+		"events.retention (EVENTS_RETENTION) must be positive",
+		"log.format (LOG_FORMAT) must be json or text",
+	}, "\n")
+	if err.Error() != want {
+		t.Errorf("err =\n%s\nwant\n%s", err, want)
+	}
+}
+
+func TestValidateOrderIsStable(t *testing.T) {
+	cfg := Default()
+	cfg.Server.BodyLimits = map[string]int64{"/team/add": 0, "/team/import": -1, "/pullRequest/create": 0}
+	cfg.RateLimiting.Routes = map[string]RateLimit{"/team/add": {}, "/team/get": {}, "/users/getReview": {}}
+
+	first := cfg.validate().Error()
+	for range 20 {
+		if got := cfg.validate().Error(); got != first {
+			t.Fatalf("validation errors changed order:\n%s\nthen\n%s", first, got)
+		}
+	}
+}
+
+func TestValidateAuth(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		wantErr string
+	}{
+		{name: "disabled", env: map[string]string{"AUTH_ENABLED": "false"}},
+		{
+			name:    "enabled without credentials",
+			env:     map[string]string{"AUTH_ENABLED": "true"},
+			wantErr: "auth.enabled (AUTH_ENABLED) requires auth.bootstrap_api_key (AUTH_BOOTSTRAP_API_KEY) or auth.jwt.jwks (AUTH_JWT_JWKS)",
+		},
+		{
+			name: "enabled with bootstrap key",
+			env:  map[string]string{"AUTH_ENABLED": "true", "AUTH_BOOTSTRAP_API_KEY": strings.Repeat("k", 32)},
+		},
+		{
+			name: "enabled with jwks",
+			env:  map[string]string{"AUTH_ENABLED": "true", "AUTH_JWT_JWKS": "/etc/reviewer/jwks.json"},
+		},
+		{
+			name:    "short bootstrap key",
+			env:     map[string]string{"AUTH_ENABLED": "true", "AUTH_BOOTSTRAP_API_KEY": "short"},
+			wantErr: "auth.bootstrap_api_key (AUTH_BOOTSTRAP_API_KEY) must be at least 32 characters long",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setRequiredEnv(t)
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+
+			_, err := Load()
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Errorf("err = %v, want none", err)
+			case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+				t.Errorf("err = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,222 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"service-pr-reviewer-assignment/internal/pkg/tenant"
+	"service-pr-reviewer-assignment/internal/service/entities"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	// channel канал NOTIFY, в который пишет триггер events_notify.
+	channel = "events"
+
+	// subscriptionBuffer сколько событий ждёт медленного подписчика, прежде чем подписка закроется.
+	subscriptionBuffer = 64
+
+	minReconnectDelay = time.Second
+	maxReconnectDelay = 30 * time.Second
+)
+
+type Logger interface {
+	InfoContext(ctx context.Context, msg string)
+	ErrorfContext(ctx context.Context, format string, args ...interface{})
+}
+
+type Storage interface {
+	GetEventByID(ctx context.Context, id int64) (*entities.Event, error)
+}
+
+// notification полезная нагрузка NOTIFY: только ключ события, само событие читается из таблицы.
+type notification struct {
+	ID    int64     `json:"id"`
+	OrgID uuid.UUID `json:"org_id"`
+}
+
+type subscription struct {
+	orgID  uuid.UUID
+	filter entities.EventFilter
+	events chan entities.Event
+}
+
+// Broker слушает LISTEN events на выделенном соединении и раздаёт события подписчикам
+// своей реплики. Любой пропуск (обрыв соединения, медленный подписчик) закрывает
+// затронутые подписки: клиент переподключается с Last-Event-ID и дочитывает события из таблицы.
+type Broker struct {
+	pool    *pgxpool.Pool
+	storage Storage
+	logger  Logger
+
+	mu            sync.Mutex
+	subscriptions map[*subscription]struct{}
+	stopped       bool
+}
+
+func Must(pool *pgxpool.Pool, storage Storage, logger Logger) *Broker {
+	return &Broker{
+		pool:          pool,
+		storage:       storage,
+		logger:        logger,
+		subscriptions: make(map[*subscription]struct{}),
+	}
+}
+
+// Subscribe подписывает на события организации, подходящие под filter. Канал закрывается
+// при вызове unsubscribe, остановке Run или пропуске событий.
+func (b *Broker) Subscribe(orgID uuid.UUID, filter entities.EventFilter) (<-chan entities.Event, func()) {
+	sub := &subscription{
+		orgID:  orgID,
+		filter: filter,
+		events: make(chan entities.Event, subscriptionBuffer),
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.stopped {
+		close(sub.events)
+		return sub.events, func() {}
+	}
+	b.subscriptions[sub] = struct{}{}
+
+	return sub.events, func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		b.remove(sub)
+	}
+}
+
+// Run слушает уведомления до отмены ctx, переподключаясь с нарастающей паузой.
+// После выхода все подписки закрыты, а новые закрываются сразу.
+func (b *Broker) Run(ctx context.Context) {
+	defer b.stop()
+
+	delay := minReconnectDelay
+	for {
+		listening, err := b.listen(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		if listening {
+			delay = minReconnectDelay
+		}
+
+		b.logger.ErrorfContext(ctx, "listen for events failed, retrying in %s: %v", delay, err)
+		// Уведомления, пришедшие без соединения, потеряны.
+		b.dropWhere(func(*subscription) bool { return true })
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+		delay = min(delay*2, maxReconnectDelay)
+	}
+}
+
+// listen держит соединение вне пула, чтобы LISTEN не достался другому запросу.
+// listening сообщает, успела ли подписка на канал оформиться.
+func (b *Broker) listen(ctx context.Context) (listening bool, _ error) {
+	pooled, err := b.pool.Acquire(ctx)
+	if err != nil {
+		return false, fmt.Errorf("acquire connection: %w", err)
+	}
+	conn := pooled.Hijack()
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+channel); err != nil {
+		return false, fmt.Errorf("listen: %w", err)
+	}
+	b.logger.InfoContext(ctx, "listening for events")
+
+	for {
+		n, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return true, fmt.Errorf("wait for notification: %w", err)
+		}
+		b.dispatch(ctx, n.Payload)
+	}
+}
+
+func (b *Broker) dispatch(ctx context.Context, payload string) {
+	var n notification
+	if err := json.Unmarshal([]byte(payload), &n); err != nil {
+		b.logger.ErrorfContext(ctx, "decode event notification %q failed: %v", payload, err)
+		return
+	}
+
+	if !b.hasSubscribers(n.OrgID) {
+		return
+	}
+
+	event, err := b.storage.GetEventByID(tenant.WithOrgID(ctx, n.OrgID), n.ID)
+	if err != nil {
+		b.logger.ErrorfContext(ctx, "get event %d failed: %v", n.ID, err)
+		b.dropWhere(func(sub *subscription) bool { return sub.orgID == n.OrgID })
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for sub := range b.subscriptions {
+		if sub.orgID != n.OrgID || !sub.filter.Matches(event) {
+			continue
+		}
+
+		select {
+		case sub.events <- *event:
+		default:
+			b.remove(sub)
+		}
+	}
+}
+
+func (b *Broker) hasSubscribers(orgID uuid.UUID) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for sub := range b.subscriptions {
+		if sub.orgID == orgID {
+			return true
+		}
+	}
+	return false
+}
+
+func (b *Broker) dropWhere(match func(*subscription) bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for sub := range b.subscriptions {
+		if match(sub) {
+			b.remove(sub)
+		}
+	}
+}
+
+func (b *Broker) stop() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.stopped = true
+	for sub := range b.subscriptions {
+		b.remove(sub)
+	}
+}
+
+// remove закрывает канал подписки ровно один раз. Вызывается под b.mu.
+func (b *Broker) remove(sub *subscription) {
+	if _, ok := b.subscriptions[sub]; !ok {
+		return
+	}
+	delete(b.subscriptions, sub)
+	close(sub.events)
+}
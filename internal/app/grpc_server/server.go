@@ -0,0 +1,56 @@
+package grpc_server
+
+import (
+	reviewerapi "service-pr-reviewer-assignment/internal/api/grpc/reviewer"
+	reviewerv1 "service-pr-reviewer-assignment/internal/generated/api/grpc/reviewer/v1"
+	"service-pr-reviewer-assignment/internal/pkg/access_log"
+	"service-pr-reviewer-assignment/internal/pkg/authentication"
+	"service-pr-reviewer-assignment/internal/pkg/grpc_status"
+	"service-pr-reviewer-assignment/internal/pkg/panic_recover"
+	"service-pr-reviewer-assignment/internal/pkg/request_logging_context"
+	"service-pr-reviewer-assignment/internal/pkg/tenant"
+	"service-pr-reviewer-assignment/internal/service"
+	"service-pr-reviewer-assignment/internal/service/entities"
+	"service-pr-reviewer-assignment/pkg/log"
+
+	"google.golang.org/grpc"
+)
+
+// scopes права, которых требуют методы ReviewerService, те же, что у соответствующих маршрутов HTTP.
+var scopes = map[string]entities.Scope{
+	reviewerv1.ReviewerService_GetTeam_FullMethodName:        entities.ScopeRead,
+	reviewerv1.ReviewerService_GetUserReviews_FullMethodName: entities.ScopeRead,
+
+	reviewerv1.ReviewerService_CreateTeam_FullMethodName:    entities.ScopeWriteTeams,
+	reviewerv1.ReviewerService_SetUserActive_FullMethodName: entities.ScopeWriteTeams,
+	reviewerv1.ReviewerService_SetUserRole_FullMethodName:   entities.ScopeWriteTeams,
+
+	reviewerv1.ReviewerService_CreatePullRequest_FullMethodName: entities.ScopeWritePullRequests,
+	reviewerv1.ReviewerService_MergePullRequest_FullMethodName:  entities.ScopeWritePullRequests,
+	reviewerv1.ReviewerService_ReassignReviewer_FullMethodName:  entities.ScopeWritePullRequests,
+}
+
+// Must собирает gRPC-сервер с теми же аутентификацией, определением организации, логированием
+// и восстановлением после паники, что и HTTP-роутер.
+func Must(
+	logger *log.Logger,
+	service *service.Service,
+	tokenVerifier authentication.TokenVerifier,
+	authEnabled bool,
+) *grpc.Server {
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(
+			request_logging_context.UnaryServerInterceptor(logger),
+			access_log.UnaryServerInterceptor(logger),
+			panic_recover.UnaryServerInterceptor(logger),
+			authentication.UnaryServerInterceptor(logger, service, tokenVerifier, authEnabled),
+			authentication.RequireScopeInterceptor(logger, scopes),
+			tenant.UnaryServerInterceptor(logger, service),
+			grpc_status.UnaryServerInterceptor(),
+		),
+	)
+
+	reviewerv1.RegisterReviewerServiceServer(server, reviewerapi.NewServer(logger, service))
+
+	return server
+}
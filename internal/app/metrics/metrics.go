@@ -0,0 +1,136 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"service-pr-reviewer-assignment/pkg/tx"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "pr_reviewer"
+
+// Metrics собирает метрики сервиса в собственный реестр.
+type Metrics struct {
+	registry *prometheus.Registry
+
+	httpRequests *prometheus.CounterVec
+	httpDuration *prometheus.HistogramVec
+	rateLimited  *prometheus.CounterVec
+
+	reviewersAssigned  prometheus.Counter
+	noCandidate        prometheus.Counter
+	pullRequestsMerged prometheus.Counter
+}
+
+// Must создаёт метрики и регистрирует коллекторы пула соединений и менеджера транзакций.
+func Must(pool *pgxpool.Pool, txManager *tx.Manager) *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "requests_total",
+			Help:      "Number of HTTP requests by route, method and status.",
+		}, []string{"route", "method", "status"}),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "request_duration_seconds",
+			Help:      "HTTP request latency by route and method.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"route", "method"}),
+		rateLimited: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "rate_limit",
+			Name:      "rejected_total",
+			Help:      "Number of requests rejected with RATE_LIMITED by route.",
+		}, []string{"route"}),
+		reviewersAssigned: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "reviewers_assigned_total",
+			Help:      "Number of reviewers assigned to pull requests, including reassignments.",
+		}),
+		noCandidate: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "no_candidate_total",
+			Help:      "Number of reassignments rejected with NO_CANDIDATE.",
+		}),
+		pullRequestsMerged: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "pull_requests_merged_total",
+			Help:      "Number of pull requests transitioned to MERGED.",
+		}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.httpRequests,
+		m.httpDuration,
+		m.rateLimited,
+		m.reviewersAssigned,
+		m.noCandidate,
+		m.pullRequestsMerged,
+		newPoolCollector(pool),
+		prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "tx",
+			Name:      "retries_total",
+			Help:      "Number of transaction retries after serialization failures and deadlocks.",
+		}, func() float64 { return float64(txManager.Stats().Retries) }),
+		prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "tx",
+			Name:      "failures_total",
+			Help:      "Number of transactions that failed with a database or connection error, including exhausted retries.",
+		}, func() float64 { return float64(txManager.Stats().Failures) }),
+	)
+
+	return m
+}
+
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
+
+// Registerer позволяет другим компонентам регистрировать собственные метрики.
+func (m *Metrics) Registerer() prometheus.Registerer {
+	return m.registry
+}
+
+func (m *Metrics) ObserveHTTPRequest(route, method string, status int, duration time.Duration) {
+	m.httpRequests.WithLabelValues(route, method, strconv.Itoa(status)).Inc()
+	m.httpDuration.WithLabelValues(route, method).Observe(duration.Seconds())
+}
+
+func (m *Metrics) RateLimited(route string) {
+	m.rateLimited.WithLabelValues(route).Inc()
+}
+
+// ObserveRateLimiter регистрирует число клиентов, отслеживаемых лимитером.
+func (m *Metrics) ObserveRateLimiter(clients func() int) {
+	m.registry.MustRegister(prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "rate_limit",
+		Name:      "tracked_clients",
+		Help:      "Number of route and client pairs with an active token bucket.",
+	}, func() float64 { return float64(clients()) }))
+}
+
+func (m *Metrics) ReviewersAssigned(count int) {
+	m.reviewersAssigned.Add(float64(count))
+}
+
+func (m *Metrics) NoReplacementCandidate() {
+	m.noCandidate.Inc()
+}
+
+func (m *Metrics) PullRequestMerged() {
+	m.pullRequestsMerged.Inc()
+}
@@ -0,0 +1,131 @@
+package metrics
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"service-pr-reviewer-assignment/pkg/log"
+	"service-pr-reviewer-assignment/pkg/tx"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// newMetrics создаёт метрики поверх пула, который не подключается к базе до первого запроса.
+func newMetrics(t *testing.T) *Metrics {
+	t.Helper()
+
+	pool, err := pgxpool.New(context.Background(), "postgres://user@127.0.0.1:1/db")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(pool.Close)
+
+	return Must(pool, tx.Must(pool, log.Must(log.Options{})))
+}
+
+func TestMustRegistersCollectors(t *testing.T) {
+	m := newMetrics(t)
+	m.ObserveHTTPRequest("/team/get", http.MethodGet, http.StatusOK, 10*time.Millisecond)
+	m.RateLimited("/team/get")
+
+	families, err := m.registry.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	names := make(map[string]bool, len(families))
+	for _, family := range families {
+		names[family.GetName()] = true
+	}
+
+	for _, want := range []string{
+		"go_goroutines",
+		"process_start_time_seconds",
+		"pr_reviewer_http_requests_total",
+		"pr_reviewer_http_request_duration_seconds",
+		"pr_reviewer_rate_limit_rejected_total",
+		"pr_reviewer_reviewers_assigned_total",
+		"pr_reviewer_no_candidate_total",
+		"pr_reviewer_pull_requests_merged_total",
+		"pr_reviewer_pgxpool_max_conns",
+		"pr_reviewer_pgxpool_acquire_total",
+		"pr_reviewer_tx_retries_total",
+		"pr_reviewer_tx_failures_total",
+	} {
+		if !names[want] {
+			t.Errorf("metric %s is not registered", want)
+		}
+	}
+}
+
+func TestMetricsPassLint(t *testing.T) {
+	m := newMetrics(t)
+	m.ObserveHTTPRequest("/team/get", http.MethodGet, http.StatusOK, time.Millisecond)
+	m.RateLimited("/team/get")
+
+	problems, err := testutil.GatherAndLint(m.registry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, problem := range problems {
+		if strings.HasPrefix(problem.Metric, namespace) {
+			t.Errorf("%s: %s", problem.Metric, problem.Text)
+		}
+	}
+}
+
+func TestObserveHTTPRequest(t *testing.T) {
+	m := newMetrics(t)
+	m.ObserveHTTPRequest("/team/get", http.MethodGet, http.StatusOK, time.Millisecond)
+	m.ObserveHTTPRequest("/team/get", http.MethodGet, http.StatusOK, time.Millisecond)
+	m.ObserveHTTPRequest("/team/get", http.MethodGet, http.StatusNotFound, time.Millisecond)
+
+	if got := testutil.ToFloat64(m.httpRequests.WithLabelValues("/team/get", http.MethodGet, "200")); got != 2 {
+		t.Errorf("requests with 200 = %v, want 2", got)
+	}
+	if got := testutil.ToFloat64(m.httpRequests.WithLabelValues("/team/get", http.MethodGet, "404")); got != 1 {
+		t.Errorf("requests with 404 = %v, want 1", got)
+	}
+	if got := testutil.CollectAndCount(m.httpDuration); got != 1 {
+		t.Errorf("duration series = %d, want 1", got)
+	}
+}
+
+func TestDomainCounters(t *testing.T) {
+	m := newMetrics(t)
+	m.ReviewersAssigned(2)
+	m.ReviewersAssigned(1)
+	m.NoReplacementCandidate()
+	m.PullRequestMerged()
+
+	if got := testutil.ToFloat64(m.reviewersAssigned); got != 3 {
+		t.Errorf("reviewers assigned = %v, want 3", got)
+	}
+	if got := testutil.ToFloat64(m.noCandidate); got != 1 {
+		t.Errorf("no candidate = %v, want 1", got)
+	}
+	if got := testutil.ToFloat64(m.pullRequestsMerged); got != 1 {
+		t.Errorf("merged = %v, want 1", got)
+	}
+}
+
+func TestHandlerExposesRateLimiterGauge(t *testing.T) {
+	m := newMetrics(t)
+	m.ObserveRateLimiter(func() int { return 7 })
+
+	rec := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	body, _ := io.ReadAll(rec.Body)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d", rec.Code)
+	}
+	if !strings.Contains(string(body), "pr_reviewer_rate_limit_tracked_clients 7") {
+		t.Errorf("tracked clients gauge missing from:\n%s", body)
+	}
+}
@@ -0,0 +1,78 @@
+package metrics
+
+import (
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// poolCollector публикует pgxpool.Stat при каждом сборе метрик.
+type poolCollector struct {
+	pool *pgxpool.Pool
+
+	acquiredConns           *prometheus.Desc
+	idleConns               *prometheus.Desc
+	constructingConns       *prometheus.Desc
+	totalConns              *prometheus.Desc
+	maxConns                *prometheus.Desc
+	acquireCount            *prometheus.Desc
+	acquireDuration         *prometheus.Desc
+	canceledAcquireCount    *prometheus.Desc
+	emptyAcquireCount       *prometheus.Desc
+	newConnsCount           *prometheus.Desc
+	maxLifetimeDestroyCount *prometheus.Desc
+	maxIdleDestroyCount     *prometheus.Desc
+}
+
+func newPoolCollector(pool *pgxpool.Pool) *poolCollector {
+	desc := func(name, help string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName(namespace, "pgxpool", name), help, nil, nil)
+	}
+
+	return &poolCollector{
+		pool:                    pool,
+		acquiredConns:           desc("acquired_conns", "Number of currently acquired connections."),
+		idleConns:               desc("idle_conns", "Number of currently idle connections."),
+		constructingConns:       desc("constructing_conns", "Number of connections being established."),
+		totalConns:              desc("total_conns", "Total number of connections in the pool."),
+		maxConns:                desc("max_conns", "Maximum size of the pool."),
+		acquireCount:            desc("acquire_total", "Number of successful acquires."),
+		acquireDuration:         desc("acquire_duration_seconds_total", "Total time spent on successful acquires."),
+		canceledAcquireCount:    desc("canceled_acquire_total", "Number of acquires canceled by context."),
+		emptyAcquireCount:       desc("empty_acquire_total", "Number of acquires that waited for a connection."),
+		newConnsCount:           desc("new_conns_total", "Number of new connections opened."),
+		maxLifetimeDestroyCount: desc("max_lifetime_destroy_total", "Number of connections closed due to MaxConnLifetime."),
+		maxIdleDestroyCount:     desc("max_idle_destroy_total", "Number of connections closed due to MaxConnIdleTime."),
+	}
+}
+
+func (c *poolCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.acquiredConns
+	ch <- c.idleConns
+	ch <- c.constructingConns
+	ch <- c.totalConns
+	ch <- c.maxConns
+	ch <- c.acquireCount
+	ch <- c.acquireDuration
+	ch <- c.canceledAcquireCount
+	ch <- c.emptyAcquireCount
+	ch <- c.newConnsCount
+	ch <- c.maxLifetimeDestroyCount
+	ch <- c.maxIdleDestroyCount
+}
+
+func (c *poolCollector) Collect(ch chan<- prometheus.Metric) {
+	stat := c.pool.Stat()
+
+	ch <- prometheus.MustNewConstMetric(c.acquiredConns, prometheus.GaugeValue, float64(stat.AcquiredConns()))
+	ch <- prometheus.MustNewConstMetric(c.idleConns, prometheus.GaugeValue, float64(stat.IdleConns()))
+	ch <- prometheus.MustNewConstMetric(c.constructingConns, prometheus.GaugeValue, float64(stat.ConstructingConns()))
+	ch <- prometheus.MustNewConstMetric(c.totalConns, prometheus.GaugeValue, float64(stat.TotalConns()))
+	ch <- prometheus.MustNewConstMetric(c.maxConns, prometheus.GaugeValue, float64(stat.MaxConns()))
+	ch <- prometheus.MustNewConstMetric(c.acquireCount, prometheus.CounterValue, float64(stat.AcquireCount()))
+	ch <- prometheus.MustNewConstMetric(c.acquireDuration, prometheus.CounterValue, stat.AcquireDuration().Seconds())
+	ch <- prometheus.MustNewConstMetric(c.canceledAcquireCount, prometheus.CounterValue, float64(stat.CanceledAcquireCount()))
+	ch <- prometheus.MustNewConstMetric(c.emptyAcquireCount, prometheus.CounterValue, float64(stat.EmptyAcquireCount()))
+	ch <- prometheus.MustNewConstMetric(c.newConnsCount, prometheus.CounterValue, float64(stat.NewConnsCount()))
+	ch <- prometheus.MustNewConstMetric(c.maxLifetimeDestroyCount, prometheus.CounterValue, float64(stat.MaxLifetimeDestroyCount()))
+	ch <- prometheus.MustNewConstMetric(c.maxIdleDestroyCount, prometheus.CounterValue, float64(stat.MaxIdleDestroyCount()))
+}
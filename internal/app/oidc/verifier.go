@@ -0,0 +1,127 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"service-pr-reviewer-assignment/internal/app/config"
+	"service-pr-reviewer-assignment/internal/service/entities"
+
+	"github.com/AlekSi/pointer"
+	"github.com/MicahParks/keyfunc/v3"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+// jwtLeeway допустимое расхождение часов с издателем токенов.
+const jwtLeeway = 30 * time.Second
+
+// Verifier проверяет bearer-токены по JWKS и сопоставляет их claim-ы с вызывающим.
+type Verifier struct {
+	keyfunc   keyfunc.Keyfunc
+	parser    *jwt.Parser
+	userClaim string
+	orgClaim  string
+}
+
+// NewVerifier загружает JWKS из файла или по URL. Ключи, полученные по URL,
+// периодически обновляются в фоне до отмены ctx.
+func NewVerifier(ctx context.Context, cfg config.JWT) (*Verifier, error) {
+	kf, err := newKeyfunc(ctx, cfg.JWKSSource)
+	if err != nil {
+		return nil, fmt.Errorf("load jwks: %w", err)
+	}
+
+	options := []jwt.ParserOption{
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(jwtLeeway),
+	}
+	if cfg.Issuer != "" {
+		options = append(options, jwt.WithIssuer(cfg.Issuer))
+	}
+	if cfg.Audience != "" {
+		options = append(options, jwt.WithAudience(cfg.Audience))
+	}
+
+	return &Verifier{
+		keyfunc:   kf,
+		parser:    jwt.NewParser(options...),
+		userClaim: cfg.UserClaim,
+		orgClaim:  cfg.OrgClaim,
+	}, nil
+}
+
+func newKeyfunc(ctx context.Context, source string) (keyfunc.Keyfunc, error) {
+	if strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://") {
+		return keyfunc.NewDefaultCtx(ctx, []string{source})
+	}
+
+	raw, err := os.ReadFile(source)
+	if err != nil {
+		return nil, fmt.Errorf("read jwks file: %w", err)
+	}
+	return keyfunc.NewJWKSetJSON(json.RawMessage(raw))
+}
+
+// Verify проверяет подпись и стандартные claim-ы токена. Для невалидного токена
+// возвращает ошибку, оборачивающую entities.ErrInvalidCredentials.
+func (v *Verifier) Verify(ctx context.Context, token string) (*entities.Identity, error) {
+	claims := jwt.MapClaims{}
+	if _, err := v.parser.ParseWithClaims(token, claims, v.keyfunc.KeyfuncCtx(ctx)); err != nil {
+		return nil, fmt.Errorf("%w: %w", entities.ErrInvalidCredentials, err)
+	}
+
+	subject, _ := claims[v.userClaim].(string)
+	if subject == "" {
+		return nil, fmt.Errorf("%w: claim %s is missing", entities.ErrInvalidCredentials, v.userClaim)
+	}
+
+	// Токен без claim-а организации относится к организации по умолчанию.
+	orgID := entities.DefaultOrganizationID
+	if raw, ok := claims[v.orgClaim].(string); ok && raw != "" {
+		parsed, err := uuid.Parse(raw)
+		if err != nil {
+			return nil, fmt.Errorf("%w: claim %s is not a uuid", entities.ErrInvalidCredentials, v.orgClaim)
+		}
+		orgID = parsed
+	}
+
+	identity := &entities.Identity{
+		Subject: "user:" + subject,
+		OrgID:   pointer.To(orgID),
+		Scopes:  scopesFromClaims(claims),
+	}
+	if userID, err := uuid.Parse(subject); err == nil {
+		identity.UserID = pointer.To(userID)
+	}
+
+	return identity, nil
+}
+
+// scopesFromClaims читает scope-ы из claim-а scope (строка через пробел, RFC 8693)
+// или scp (массив строк). Неизвестные scope-ы отбрасываются.
+func scopesFromClaims(claims jwt.MapClaims) []entities.Scope {
+	var raw []string
+	if scope, ok := claims["scope"].(string); ok {
+		raw = strings.Fields(scope)
+	}
+	if scp, ok := claims["scp"].([]any); ok {
+		for _, s := range scp {
+			if str, ok := s.(string); ok {
+				raw = append(raw, str)
+			}
+		}
+	}
+
+	var scopes []entities.Scope
+	for _, s := range raw {
+		if scope := entities.Scope(s); scope.IsValid() {
+			scopes = append(scopes, scope)
+		}
+	}
+	return scopes
+}
@@ -0,0 +1,229 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"service-pr-reviewer-assignment/internal/app/config"
+	"service-pr-reviewer-assignment/internal/service/entities"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+const (
+	testKID      = "test-key"
+	testIssuer   = "https://idp.example.com"
+	testAudience = "pr-reviewer"
+)
+
+var testUserID = uuid.MustParse("550e8400-e29b-41d4-a716-446655440001")
+
+// signingKey ключ издателя токенов и его JWKS.
+type signingKey struct {
+	private *rsa.PrivateKey
+	jwks    []byte
+}
+
+func newSigningKey(t *testing.T) signingKey {
+	t.Helper()
+
+	private, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	jwks, err := json.Marshal(map[string]any{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": testKID,
+			"alg": "RS256",
+			"use": "sig",
+			"n":   base64.RawURLEncoding.EncodeToString(private.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(private.E)).Bytes()),
+		}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return signingKey{private: private, jwks: jwks}
+}
+
+func (k signingKey) sign(t *testing.T, kid string, claims jwt.MapClaims) string {
+	t.Helper()
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = kid
+	signed, err := token.SignedString(k.private)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signed
+}
+
+// validClaims claim-ы токена, который принимает verifier из newServerVerifier.
+func validClaims() jwt.MapClaims {
+	return jwt.MapClaims{
+		"iss":   testIssuer,
+		"aud":   testAudience,
+		"sub":   testUserID.String(),
+		"exp":   time.Now().Add(time.Hour).Unix(),
+		"scope": "read write:prs unknown",
+	}
+}
+
+func testConfig(source string) config.JWT {
+	return config.JWT{
+		JWKSSource: source,
+		Issuer:     testIssuer,
+		Audience:   testAudience,
+		UserClaim:  "sub",
+		OrgClaim:   "org_id",
+	}
+}
+
+// newServerVerifier создаёт verifier, который загружает JWKS с httptest-сервера.
+func newServerVerifier(t *testing.T, key signingKey) *Verifier {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(key.jwks)
+	}))
+	t.Cleanup(server.Close)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	verifier, err := NewVerifier(ctx, testConfig(server.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return verifier
+}
+
+func TestVerifyValidToken(t *testing.T) {
+	key := newSigningKey(t)
+	verifier := newServerVerifier(t, key)
+
+	caller, err := verifier.Verify(context.Background(), key.sign(t, testKID, validClaims()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if caller.Subject != "user:"+testUserID.String() {
+		t.Errorf("subject = %q", caller.Subject)
+	}
+	if caller.UserID == nil || *caller.UserID != testUserID {
+		t.Errorf("user id = %v, want %s", caller.UserID, testUserID)
+	}
+	if caller.OrgID == nil || *caller.OrgID != entities.DefaultOrganizationID {
+		t.Errorf("org id = %v, want the default organization", caller.OrgID)
+	}
+	if len(caller.Scopes) != 2 || !caller.HasScope(entities.ScopeRead) || !caller.HasScope(entities.ScopeWritePullRequests) {
+		t.Errorf("scopes = %v, want read and write:prs", caller.Scopes)
+	}
+}
+
+func TestVerifyOrganizationClaim(t *testing.T) {
+	key := newSigningKey(t)
+	verifier := newServerVerifier(t, key)
+	orgID := uuid.MustParse("750e8400-e29b-41d4-a716-446655440001")
+
+	claims := validClaims()
+	claims["org_id"] = orgID.String()
+	caller, err := verifier.Verify(context.Background(), key.sign(t, testKID, claims))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if caller.OrgID == nil || *caller.OrgID != orgID {
+		t.Errorf("org id = %v, want %s", caller.OrgID, orgID)
+	}
+
+	claims["org_id"] = "acme"
+	if _, err := verifier.Verify(context.Background(), key.sign(t, testKID, claims)); !errors.Is(err, entities.ErrInvalidCredentials) {
+		t.Errorf("non-uuid org claim: err = %v, want ErrInvalidCredentials", err)
+	}
+}
+
+func TestVerifyRejectsInvalidTokens(t *testing.T) {
+	key := newSigningKey(t)
+	verifier := newServerVerifier(t, key)
+
+	tests := []struct {
+		name   string
+		kid    string
+		modify func(claims jwt.MapClaims)
+	}{
+		{name: "wrong issuer", modify: func(c jwt.MapClaims) { c["iss"] = "https://evil.example.com" }},
+		{name: "wrong audience", modify: func(c jwt.MapClaims) { c["aud"] = "other-service" }},
+		{name: "expired", modify: func(c jwt.MapClaims) { c["exp"] = time.Now().Add(-time.Hour).Unix() }},
+		{name: "without expiration", modify: func(c jwt.MapClaims) { delete(c, "exp") }},
+		{name: "unknown kid", kid: "rotated-key"},
+		{name: "missing user claim", modify: func(c jwt.MapClaims) { delete(c, "sub") }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims := validClaims()
+			if tt.modify != nil {
+				tt.modify(claims)
+			}
+			kid := testKID
+			if tt.kid != "" {
+				kid = tt.kid
+			}
+
+			_, err := verifier.Verify(context.Background(), key.sign(t, kid, claims))
+			if !errors.Is(err, entities.ErrInvalidCredentials) {
+				t.Errorf("err = %v, want ErrInvalidCredentials", err)
+			}
+		})
+	}
+}
+
+func TestVerifyRejectsForeignSignature(t *testing.T) {
+	verifier := newServerVerifier(t, newSigningKey(t))
+	foreign := newSigningKey(t)
+
+	_, err := verifier.Verify(context.Background(), foreign.sign(t, testKID, validClaims()))
+	if !errors.Is(err, entities.ErrInvalidCredentials) {
+		t.Errorf("err = %v, want ErrInvalidCredentials", err)
+	}
+}
+
+func TestNewVerifierFromFile(t *testing.T) {
+	key := newSigningKey(t)
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(path, key.jwks, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	verifier, err := NewVerifier(context.Background(), testConfig(path))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := verifier.Verify(context.Background(), key.sign(t, testKID, validClaims())); err != nil {
+		t.Errorf("verify: %v", err)
+	}
+}
+
+func TestNewVerifierMissingFile(t *testing.T) {
+	_, err := NewVerifier(context.Background(), testConfig(filepath.Join(t.TempDir(), "missing.json")))
+	if err == nil {
+		t.Error("expected an error for a missing jwks file")
+	}
+}
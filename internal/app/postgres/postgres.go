@@ -26,12 +26,12 @@ func NewConnPool(ctx context.Context, cfg config.Postgres, logger *log.Logger) (
 		return nil, fmt.Errorf("parse connection string: %w", err)
 	}
 
-	pgxCfg.MaxConns = 12
-	pgxCfg.MinConns = 2
-	pgxCfg.MaxConnLifetime = 5 * time.Minute
-	pgxCfg.MaxConnIdleTime = 30 * time.Minute
-	pgxCfg.HealthCheckPeriod = time.Minute
-	pgxCfg.ConnConfig.ConnectTimeout = 5 * time.Second
+	pgxCfg.MaxConns = cfg.Pool.MaxConns
+	pgxCfg.MinConns = cfg.Pool.MinConns
+	pgxCfg.MaxConnLifetime = cfg.Pool.MaxConnLifetime
+	pgxCfg.MaxConnIdleTime = cfg.Pool.MaxConnIdleTime
+	pgxCfg.HealthCheckPeriod = cfg.Pool.HealthCheckPeriod
+	pgxCfg.ConnConfig.ConnectTimeout = cfg.Pool.ConnectTimeout
 	pgxCfg.ConnConfig.DefaultQueryExecMode = pgx.QueryExecModeSimpleProtocol
 
 	for attempt := 0; attempt < maxRetries; attempt++ {
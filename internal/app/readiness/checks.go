@@ -0,0 +1,67 @@
+package readiness
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+
+	"service-pr-reviewer-assignment/internal/api/handlers/readyz"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/pressly/goose/v3"
+)
+
+type MigrationStorage interface {
+	IsMigrationApplied(ctx context.Context, version int64) (bool, error)
+}
+
+func Postgres(pool *pgxpool.Pool) readyz.Check {
+	return readyz.Check{
+		Name: "postgres",
+		Func: pool.Ping,
+	}
+}
+
+// Migrations проверяет, что в базе применена последняя миграция из встроенного набора.
+func Migrations(storage MigrationStorage, migrations fs.FS) (readyz.Check, error) {
+	latest, err := latestVersion(migrations)
+	if err != nil {
+		return readyz.Check{}, fmt.Errorf("latest migration version: %w", err)
+	}
+
+	return readyz.Check{
+		Name: "migrations",
+		Func: func(ctx context.Context) error {
+			applied, err := storage.IsMigrationApplied(ctx, latest)
+			if err != nil {
+				return err
+			}
+			if !applied {
+				return fmt.Errorf("migration %d is not applied", latest)
+			}
+			return nil
+		},
+	}, nil
+}
+
+func latestVersion(migrations fs.FS) (int64, error) {
+	files, err := fs.Glob(migrations, "*.sql")
+	if err != nil {
+		return 0, fmt.Errorf("list migrations: %w", err)
+	}
+
+	var latest int64
+	for _, file := range files {
+		version, err := goose.NumericComponent(file)
+		if err != nil {
+			return 0, fmt.Errorf("parse migration %s: %w", file, err)
+		}
+		latest = max(latest, version)
+	}
+
+	if latest == 0 {
+		return 0, fmt.Errorf("no migrations found")
+	}
+
+	return latest, nil
+}
@@ -0,0 +1,119 @@
+package readiness
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"service-pr-reviewer-assignment/migrations"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// fakeStorage отвечает, что применены только перечисленные версии.
+type fakeStorage struct {
+	applied map[int64]bool
+	err     error
+	asked   int64
+}
+
+func (s *fakeStorage) IsMigrationApplied(_ context.Context, version int64) (bool, error) {
+	s.asked = version
+	return s.applied[version], s.err
+}
+
+var testMigrations = fstest.MapFS{
+	"20250101000000_init.sql":    {},
+	"20250301000000_events.sql":  {},
+	"20250201000000_indexes.sql": {},
+	"README.md":                  {},
+}
+
+func TestMigrationsChecksLatestVersion(t *testing.T) {
+	storage := &fakeStorage{applied: map[int64]bool{20250301000000: true}}
+	check, err := Migrations(storage, testMigrations)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := check.Func(context.Background()); err != nil {
+		t.Errorf("check failed: %v", err)
+	}
+	if storage.asked != 20250301000000 {
+		t.Errorf("asked version %d, want the latest 20250301000000", storage.asked)
+	}
+}
+
+func TestMigrationsNotApplied(t *testing.T) {
+	storage := &fakeStorage{applied: map[int64]bool{20250201000000: true}}
+	check, err := Migrations(storage, testMigrations)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = check.Func(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "20250301000000") {
+		t.Errorf("err = %v, want the missing version named", err)
+	}
+}
+
+func TestMigrationsStorageError(t *testing.T) {
+	storageErr := errors.New("connection refused")
+	check, err := Migrations(&fakeStorage{err: storageErr}, testMigrations)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := check.Func(context.Background()); !errors.Is(err, storageErr) {
+		t.Errorf("err = %v, want %v", err, storageErr)
+	}
+}
+
+func TestMigrationsInvalidSet(t *testing.T) {
+	tests := []struct {
+		name string
+		fs   fstest.MapFS
+	}{
+		{name: "empty", fs: fstest.MapFS{}},
+		{name: "unversioned file", fs: fstest.MapFS{"init.sql": {}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Migrations(&fakeStorage{}, tt.fs); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestLatestVersionOfEmbeddedMigrations(t *testing.T) {
+	if _, err := latestVersion(migrations.FS); err != nil {
+		t.Errorf("embedded migrations: %v", err)
+	}
+}
+
+func TestPostgresPingRespectsDeadline(t *testing.T) {
+	// 192.0.2.0/24 зарезервирована для документации, подключение к ней не завершается.
+	pool, err := pgxpool.New(context.Background(), "postgres://user@192.0.2.1:5432/db?connect_timeout=30")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(pool.Close)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err = Postgres(pool).Func(ctx)
+
+	if err == nil {
+		t.Fatal("ping of an unreachable database succeeded")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("ping took %s, want it bounded by the context deadline", elapsed)
+	}
+}
@@ -0,0 +1,45 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"service-pr-reviewer-assignment/internal/app/config"
+	"service-pr-reviewer-assignment/internal/pkg/deprecation"
+)
+
+func TestDeprecationHeaderOnLegacyRoutesOnly(t *testing.T) {
+	cfg := config.Default()
+	cfg.Auth.Enabled = true
+	cfg.Auth.BootstrapAPIKey = "bootstrap-key"
+	handler := newOfflineHandler(t, cfg)
+
+	tests := []struct {
+		method         string
+		target         string
+		wantDeprecated bool
+	}{
+		{method: http.MethodGet, target: "/team/get?team_name=payments", wantDeprecated: true},
+		{method: http.MethodPost, target: "/pullRequest/reassign", wantDeprecated: true},
+		{method: http.MethodGet, target: "/api/v1/teams/payments"},
+		{method: http.MethodPost, target: "/team/import"},
+		{method: http.MethodPost, target: "/users/setRole"},
+		{method: http.MethodPost, target: "/admin/apiKeys/create"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.target, nil))
+
+			// Без учётных данных запрос отклоняется аутентификацией, заголовок нужен и в 401.
+			if rec.Code != http.StatusUnauthorized {
+				t.Fatalf("status = %d, want 401", rec.Code)
+			}
+			if got := rec.Header().Get(deprecation.Header) != ""; got != tt.wantDeprecated {
+				t.Errorf("deprecated = %t, want %t", got, tt.wantDeprecated)
+			}
+		})
+	}
+}
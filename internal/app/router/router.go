@@ -4,50 +4,237 @@ import (
 	"context"
 	"net/http"
 	"sync/atomic"
+	"time"
 
+	"service-pr-reviewer-assignment/api"
 	"service-pr-reviewer-assignment/internal/api/handlers/not_found"
+	"service-pr-reviewer-assignment/internal/api/scim"
+	"service-pr-reviewer-assignment/internal/app/config"
+	"service-pr-reviewer-assignment/internal/app/metrics"
 
+	"service-pr-reviewer-assignment/internal/pkg/access_log"
+	"service-pr-reviewer-assignment/internal/pkg/authentication"
+	"service-pr-reviewer-assignment/internal/pkg/body_limit"
+	"service-pr-reviewer-assignment/internal/pkg/deprecation"
 	"service-pr-reviewer-assignment/internal/pkg/graceful_shutdown"
+	"service-pr-reviewer-assignment/internal/pkg/http_metrics"
+	"service-pr-reviewer-assignment/internal/pkg/idempotency"
+	"service-pr-reviewer-assignment/internal/pkg/openapi_validation"
 
+	"service-pr-reviewer-assignment/internal/api/handlers/apikey_create"
+	"service-pr-reviewer-assignment/internal/api/handlers/apikey_list"
+	"service-pr-reviewer-assignment/internal/api/handlers/apikey_revoke"
+	"service-pr-reviewer-assignment/internal/api/handlers/docs"
+	"service-pr-reviewer-assignment/internal/api/handlers/events_stream"
 	"service-pr-reviewer-assignment/internal/api/handlers/healthcheck"
+	"service-pr-reviewer-assignment/internal/api/handlers/livez"
+	"service-pr-reviewer-assignment/internal/api/handlers/loglevels_get"
+	"service-pr-reviewer-assignment/internal/api/handlers/loglevels_set"
+	"service-pr-reviewer-assignment/internal/api/handlers/openapi_spec"
+	"service-pr-reviewer-assignment/internal/api/handlers/organization_create"
 	"service-pr-reviewer-assignment/internal/api/handlers/pullrequest_create"
 	"service-pr-reviewer-assignment/internal/api/handlers/pullrequest_merge"
+	"service-pr-reviewer-assignment/internal/api/handlers/pullrequest_reassign"
+	"service-pr-reviewer-assignment/internal/api/handlers/readyz"
 	"service-pr-reviewer-assignment/internal/api/handlers/team_add"
 	"service-pr-reviewer-assignment/internal/api/handlers/team_get"
+	"service-pr-reviewer-assignment/internal/api/handlers/team_import"
 	"service-pr-reviewer-assignment/internal/api/handlers/users_getreview"
 	"service-pr-reviewer-assignment/internal/api/handlers/users_setisactive"
+	"service-pr-reviewer-assignment/internal/api/handlers/users_setrole"
 	"service-pr-reviewer-assignment/internal/pkg/panic_recover"
+	"service-pr-reviewer-assignment/internal/pkg/problem_details"
+	"service-pr-reviewer-assignment/internal/pkg/rate_limit"
 	"service-pr-reviewer-assignment/internal/pkg/request_logging_context"
+	"service-pr-reviewer-assignment/internal/pkg/request_timeout"
+	"service-pr-reviewer-assignment/internal/pkg/tenant"
+	"service-pr-reviewer-assignment/internal/pkg/tracing"
 	"service-pr-reviewer-assignment/internal/service"
+	"service-pr-reviewer-assignment/internal/service/entities"
+	"service-pr-reviewer-assignment/internal/storage"
 	"service-pr-reviewer-assignment/pkg/log"
 
 	"github.com/gorilla/mux"
 )
 
-func Must(
-	isShuttingDown *atomic.Bool,
-	ongoingCtx context.Context,
-	logger *log.Logger,
-	service *service.Service,
-) http.Handler {
+// legacyRoutesDeprecatedAt дата, с которой RPC-маршруты вида /team/add заменены
+// ресурсными маршрутами /api/v1 и отвечают с заголовком Deprecation.
+var legacyRoutesDeprecatedAt = time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC)
+
+// legacyRoutes RPC-маршруты исходного API, у которых есть замена в /api/v1. RPC-маршруты,
+// добавленные позже (/team/import, /users/setRole, /admin/...), устаревшими не помечаются.
+var legacyRoutes = []string{
+	"/team/add",
+	"/team/get",
+	"/users/getReview",
+	"/users/setIsActive",
+	"/pullRequest/create",
+	"/pullRequest/merge",
+	"/pullRequest/reassign",
+}
+
+// Dependencies компоненты, которые обслуживают маршруты. Необязательные компоненты
+// равны nil, если соответствующая возможность выключена.
+type Dependencies struct {
+	IsShuttingDown  *atomic.Bool
+	OngoingCtx      context.Context
+	Logger          *log.Logger
+	Service         *service.Service
+	Storage         *storage.Storage
+	Metrics         *metrics.Metrics
+	ReadinessChecks []readyz.Check
+	// TokenVerifier проверяет JWT, nil — JWT не принимаются.
+	TokenVerifier authentication.TokenVerifier
+	// RateLimiter nil — лимиты запросов выключены.
+	RateLimiter *rate_limit.Limiter
+	// SpecValidator nil — запросы не проверяются по спецификации.
+	SpecValidator *openapi_validation.Validator
+	// SpecJSON nil — спецификация и документация не публикуются.
+	SpecJSON []byte
+	// EventBroker nil — поток событий выключен.
+	EventBroker events_stream.Broker
+}
+
+// Must собирает маршруты API. Переключатели и лимиты берутся из cfg.
+func Must(cfg *config.Config, deps Dependencies) http.Handler {
+	logger := deps.Logger
+	service := deps.Service
+
 	router := mux.NewRouter()
 
-	router.Use(panic_recover.Middleware(logger))
+	router.Use(problem_details.Middleware())
 	router.Use(request_logging_context.Middleware(logger))
-	router.Use(graceful_shutdown.Middleware(isShuttingDown, ongoingCtx))
+	router.Use(access_log.Middleware(logger))
+	router.Use(http_metrics.Middleware(deps.Metrics))
+	router.Use(tracing.Middleware(logger))
+	router.Use(panic_recover.Middleware(logger))
+	router.Use(graceful_shutdown.Middleware(deps.IsShuttingDown, deps.OngoingCtx))
+	router.Use(deprecation.Middleware(legacyRoutesDeprecatedAt, legacyRoutes))
+
+	router.Handle("/healthcheck", healthcheck.NewHandler(deps.IsShuttingDown, logger)).Methods(http.MethodHead)
+	router.Handle("/livez", livez.NewHandler()).Methods(http.MethodGet, http.MethodHead)
+	router.Handle("/readyz", readyz.NewHandler(deps.IsShuttingDown, logger, deps.ReadinessChecks)).Methods(http.MethodGet, http.MethodHead)
+	router.Handle("/metrics", deps.Metrics.Handler()).Methods(http.MethodGet)
+
+	// Спецификация и документация публичны, как и пробы: без них нельзя узнать, как получить ключ.
+	if deps.SpecJSON != nil {
+		router.Handle("/openapi.yaml", openapi_spec.NewHandler("application/yaml", api.Spec)).Methods(http.MethodGet, http.MethodHead)
+		router.Handle("/openapi.json", openapi_spec.NewHandler("application/json", deps.SpecJSON)).Methods(http.MethodGet, http.MethodHead)
+		router.Handle("/docs", http.RedirectHandler(docs.Prefix, http.StatusMovedPermanently)).Methods(http.MethodGet, http.MethodHead)
+		router.PathPrefix(docs.Prefix).Handler(docs.NewHandler(api.Docs)).Methods(http.MethodGet, http.MethodHead)
+	}
+
+	// newAPIRouter собирает цепочку middleware API. Дедлайн запроса не ставится только
+	// потоку событий, который открыт, пока подключён клиент. По спецификации не проверяются
+	// только маршруты SCIM: их формат задан RFC 7644.
+	newAPIRouter := func(withRequestTimeout bool, withSpecValidation bool) *mux.Router {
+		api := router.NewRoute().Subrouter()
+		if withRequestTimeout {
+			api.Use(request_timeout.Middleware(cfg.Server.RequestTimeout))
+		}
+		api.Use(body_limit.Middleware(logger, cfg.Server.MaxBodyBytes, cfg.Server.BodyLimits))
+		if deps.RateLimiter != nil {
+			api.Use(rate_limit.IPMiddleware(logger, deps.RateLimiter, deps.Metrics, cfg.RateLimiting.TrustProxy))
+		}
+		api.Use(authentication.Middleware(logger, service, deps.TokenVerifier, cfg.Auth.Enabled))
+		if deps.RateLimiter != nil {
+			api.Use(rate_limit.Middleware(logger, deps.RateLimiter, deps.Metrics, cfg.RateLimiting.TrustProxy))
+		}
+		api.Use(tenant.Middleware(logger, service))
+		if deps.SpecValidator != nil && withSpecValidation {
+			api.Use(openapi_validation.Middleware(logger, deps.SpecValidator, cfg.Features.ResponseValidation))
+		}
+		return api
+	}
+
+	authenticated := newAPIRouter(true, true)
+
+	read := authenticated.NewRoute().Subrouter()
+	read.Use(authentication.RequireScope(logger, entities.ScopeRead))
+	read.Handle("/api/v1/teams/{name}", team_get.NewResourceHandler(logger, service)).Methods(http.MethodGet)
+	read.Handle("/api/v1/users/{id}/reviews", users_getreview.NewResourceHandler(logger, service)).Methods(http.MethodGet)
+	read.Handle("/team/get", team_get.NewHandler(logger, service)).Methods(http.MethodGet)
+	read.Handle("/users/getReview", users_getreview.NewHandler(logger, service)).Methods(http.MethodGet)
+
+	writeTeams := authenticated.NewRoute().Subrouter()
+	writeTeams.Use(authentication.RequireScope(logger, entities.ScopeWriteTeams))
+	if cfg.Features.Idempotency {
+		writeTeams.Use(idempotency.Middleware(logger, deps.Storage))
+	}
+	writeTeams.Handle("/api/v1/teams", team_add.NewHandler(logger, service)).Methods(http.MethodPost)
+	writeTeams.Handle("/api/v1/teams/import", team_import.NewHandler(logger, service)).Methods(http.MethodPost)
+	writeTeams.Handle("/api/v1/users/{id}/active", users_setisactive.NewResourceHandler(logger, service)).Methods(http.MethodPut)
+	writeTeams.Handle("/api/v1/users/{id}/role", users_setrole.NewResourceHandler(logger, service)).Methods(http.MethodPut)
+	writeTeams.Handle("/team/add", team_add.NewHandler(logger, service)).Methods(http.MethodPost)
+	writeTeams.Handle("/team/import", team_import.NewHandler(logger, service)).Methods(http.MethodPost)
+	writeTeams.Handle("/users/setIsActive", users_setisactive.NewHandler(logger, service)).Methods(http.MethodPost)
+	writeTeams.Handle("/users/setRole", users_setrole.NewHandler(logger, service)).Methods(http.MethodPost)
 
-	router.Handle("/healthcheck", healthcheck.NewHandler(isShuttingDown, logger)).Methods(http.MethodHead)
+	writePullRequests := authenticated.NewRoute().Subrouter()
+	writePullRequests.Use(authentication.RequireScope(logger, entities.ScopeWritePullRequests))
+	if cfg.Features.Idempotency {
+		writePullRequests.Use(idempotency.Middleware(logger, deps.Storage))
+	}
+	writePullRequests.Handle("/api/v1/pull-requests", pullrequest_create.NewHandler(logger, service)).Methods(http.MethodPost)
+	writePullRequests.Handle("/api/v1/pull-requests/{id}/merge", pullrequest_merge.NewResourceHandler(logger, service)).Methods(http.MethodPost)
+	writePullRequests.Handle("/api/v1/pull-requests/{id}/reassign", pullrequest_reassign.NewResourceHandler(logger, service)).Methods(http.MethodPost)
+	writePullRequests.Handle("/pullRequest/create", pullrequest_create.NewHandler(logger, service)).Methods(http.MethodPost)
+	writePullRequests.Handle("/pullRequest/merge", pullrequest_merge.NewHandler(logger, service)).Methods(http.MethodPost)
+	writePullRequests.Handle("/pullRequest/reassign", pullrequest_reassign.NewHandler(logger, service)).Methods(http.MethodPost)
 
-	router.Handle("/team/add", team_add.NewHandler(logger, service)).Methods(http.MethodPost)
-	router.Handle("/team/get", team_get.NewHandler(logger, service)).Methods(http.MethodGet)
+	// Ответы на создание ключа и организации содержат секрет, поэтому admin-маршруты не проходят
+	// через idempotency и секрет не попадает в базу.
+	admin := authenticated.NewRoute().Subrouter()
+	admin.Use(authentication.RequireScope(logger, entities.ScopeAdmin))
+	admin.Handle("/api/v1/admin/api-keys", apikey_create.NewHandler(logger, service)).Methods(http.MethodPost)
+	admin.Handle("/api/v1/admin/api-keys", apikey_list.NewHandler(logger, service)).Methods(http.MethodGet)
+	admin.Handle("/api/v1/admin/api-keys/{id}/revoke", apikey_revoke.NewResourceHandler(logger, service)).Methods(http.MethodPost)
+	admin.Handle("/api/v1/admin/organizations", organization_create.NewHandler(logger, service)).Methods(http.MethodPost)
+	admin.Handle("/api/v1/admin/log-levels", loglevels_get.NewHandler(logger)).Methods(http.MethodGet)
+	admin.Handle("/api/v1/admin/log-levels", loglevels_set.NewHandler(logger)).Methods(http.MethodPut)
+	admin.Handle("/admin/apiKeys/create", apikey_create.NewHandler(logger, service)).Methods(http.MethodPost)
+	admin.Handle("/admin/apiKeys/list", apikey_list.NewHandler(logger, service)).Methods(http.MethodGet)
+	admin.Handle("/admin/apiKeys/revoke", apikey_revoke.NewHandler(logger, service)).Methods(http.MethodPost)
+	admin.Handle("/admin/organizations/create", organization_create.NewHandler(logger, service)).Methods(http.MethodPost)
+	admin.Handle("/admin/logLevels/get", loglevels_get.NewHandler(logger)).Methods(http.MethodGet)
+	admin.Handle("/admin/logLevels/set", loglevels_set.NewHandler(logger)).Methods(http.MethodPost)
 
-	router.Handle("/users/getReview", users_getreview.NewHandler(logger, service)).Methods(http.MethodGet)
-	router.Handle("/users/setIsActive", users_setisactive.NewHandler(logger, service)).Methods(http.MethodPost)
+	if deps.EventBroker != nil {
+		streaming := newAPIRouter(false, true)
+		streaming.Use(authentication.RequireScope(logger, entities.ScopeRead))
+		streaming.Handle("/events/stream", events_stream.NewHandler(logger, service, deps.EventBroker)).Methods(http.MethodGet)
+	}
 
-	router.Handle("/pullRequest/create", pullrequest_create.NewHandler(logger, service)).Methods(http.MethodPost)
-	router.Handle("/pullRequest/merge", pullrequest_merge.NewHandler(logger, service)).Methods(http.MethodPost)
+	// IdP управляет пользователями и командами всех команд организации, поэтому SCIM
+	// требует scope admin.
+	if cfg.Features.SCIM {
+		scimHandler := scim.NewHandler(logger, service)
+		provisioning := newAPIRouter(true, false).PathPrefix(scim.Prefix).Subrouter()
+		provisioning.Use(authentication.RequireScope(logger, entities.ScopeAdmin))
+		provisioning.Handle("/ServiceProviderConfig", scimHandler.ServiceProviderConfig()).Methods(http.MethodGet)
+		provisioning.Handle("/Users", scimHandler.ListUsers()).Methods(http.MethodGet)
+		provisioning.Handle("/Users", scimHandler.CreateUser()).Methods(http.MethodPost)
+		provisioning.Handle("/Users/{id}", scimHandler.GetUser()).Methods(http.MethodGet)
+		provisioning.Handle("/Users/{id}", scimHandler.ReplaceUser()).Methods(http.MethodPut)
+		provisioning.Handle("/Users/{id}", scimHandler.PatchUser()).Methods(http.MethodPatch)
+		provisioning.Handle("/Users/{id}", scimHandler.DeleteUser()).Methods(http.MethodDelete)
+		provisioning.Handle("/Groups", scimHandler.ListGroups()).Methods(http.MethodGet)
+		provisioning.Handle("/Groups", scimHandler.CreateGroup()).Methods(http.MethodPost)
+		provisioning.Handle("/Groups/{id}", scimHandler.GetGroup()).Methods(http.MethodGet)
+		provisioning.Handle("/Groups/{id}", scimHandler.ReplaceGroup()).Methods(http.MethodPut)
+		provisioning.Handle("/Groups/{id}", scimHandler.PatchGroup()).Methods(http.MethodPatch)
+		provisioning.Handle("/Groups/{id}", scimHandler.DeleteGroup()).Methods(http.MethodDelete)
+	}
 
-	router.NotFoundHandler = not_found.NewHandler(logger)
+	// NotFoundHandler и MethodNotAllowedHandler вызываются роутером без middleware, поэтому
+	// формат ошибки и идентификатор запроса назначаются отдельно.
+	unmatched := func(handler http.Handler) http.Handler {
+		return problem_details.Middleware()(request_logging_context.Middleware(logger)(handler))
+	}
+	router.NotFoundHandler = unmatched(not_found.NewHandler(logger))
+	router.MethodNotAllowedHandler = unmatched(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}))
 
 	return router
 }
@@ -0,0 +1,124 @@
+package router
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"service-pr-reviewer-assignment/internal/app/config"
+	"service-pr-reviewer-assignment/internal/app/metrics"
+	"service-pr-reviewer-assignment/internal/pkg/pgtest"
+	"service-pr-reviewer-assignment/internal/pkg/tenant"
+	"service-pr-reviewer-assignment/internal/service"
+	"service-pr-reviewer-assignment/internal/service/entities"
+	"service-pr-reviewer-assignment/internal/storage"
+	"service-pr-reviewer-assignment/pkg/log"
+	"service-pr-reviewer-assignment/pkg/querier"
+	"service-pr-reviewer-assignment/pkg/tx"
+
+	"github.com/avito-tech/go-transaction-manager/pgxv5"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TestTracingSpanHierarchy проверяет, что один запрос даёт цепочку span-ов
+// обработчик → Service.* → tx → Querier в трассе из входящего traceparent.
+func TestTracingSpanHierarchy(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	pool := pgtest.New(t)
+	logger := log.Must(log.Options{})
+	txManager := tx.Must(pool, logger)
+	storage := storage.Must(querier.Must(pool, pgxv5.DefaultCtxGetter))
+	metrics := metrics.Must(pool, txManager)
+	service := service.Must(storage, txManager, metrics, service.AssignmentPolicy{MaxReviewers: 2}, true)
+
+	ctx := tenant.WithOrgID(context.Background(), entities.DefaultOrganizationID)
+	members := []entities.User{{ID: uuid.New(), Name: "Alice", IsActive: true}}
+	if _, err := service.CreateTeam(ctx, "payments", members); err != nil {
+		t.Fatalf("create team: %v", err)
+	}
+
+	cfg := config.Default()
+	cfg.Auth.Enabled = false
+	handler := Must(cfg, Dependencies{
+		IsShuttingDown: &atomic.Bool{},
+		OngoingCtx:     context.Background(),
+		Logger:         logger,
+		Service:        service,
+		Storage:        storage,
+		Metrics:        metrics,
+	})
+
+	const (
+		incomingTraceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+		incomingSpanID  = "00f067aa0ba902b7"
+	)
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/teams/payments", nil)
+	req.Header.Set("traceparent", "00-"+incomingTraceID+"-"+incomingSpanID+"-01")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, body = %s", rec.Code, rec.Body)
+	}
+
+	var spans []sdktrace.ReadOnlySpan
+	for _, span := range recorder.Ended() {
+		if span.SpanContext().TraceID().String() == incomingTraceID {
+			spans = append(spans, span)
+		}
+	}
+
+	server := findSpan(t, spans, "GET /api/v1/teams/{name}", trace.SpanID{})
+	if got := server.Parent().SpanID().String(); got != incomingSpanID {
+		t.Errorf("server span parent = %s, want %s from traceparent", got, incomingSpanID)
+	}
+
+	serviceSpan := findSpan(t, spans, "Service.GetTeam", server.SpanContext().SpanID())
+	txSpan := findSpan(t, spans, "tx.Read", serviceSpan.SpanContext().SpanID())
+
+	queries := 0
+	for _, span := range spans {
+		if span.Parent().SpanID() != txSpan.SpanContext().SpanID() || !strings.HasPrefix(span.Name(), "db.") {
+			continue
+		}
+		queries++
+
+		attributes := attribute.NewSet(span.Attributes()...)
+		statement, ok := attributes.Value("db.statement")
+		if !ok || strings.TrimSpace(statement.AsString()) == "" {
+			t.Errorf("span %s has no db.statement", span.Name())
+		}
+	}
+	if queries == 0 {
+		t.Errorf("no db spans under tx.Read")
+	}
+}
+
+// findSpan ищет span по имени; непустой parent требует, чтобы span был его прямым потомком.
+func findSpan(t *testing.T, spans []sdktrace.ReadOnlySpan, name string, parent trace.SpanID) sdktrace.ReadOnlySpan {
+	t.Helper()
+
+	for _, span := range spans {
+		if span.Name() != name {
+			continue
+		}
+		if parent.IsValid() && span.Parent().SpanID() != parent {
+			t.Fatalf("span %s has parent %s, want %s", name, span.Parent().SpanID(), parent)
+		}
+		return span
+	}
+
+	t.Fatalf("span %s not found", name)
+	return nil
+}
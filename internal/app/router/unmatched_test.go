@@ -0,0 +1,100 @@
+package router
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"service-pr-reviewer-assignment/internal/app/config"
+	"service-pr-reviewer-assignment/internal/app/metrics"
+	"service-pr-reviewer-assignment/internal/generated/api/dto"
+	"service-pr-reviewer-assignment/internal/pkg/request_id"
+	"service-pr-reviewer-assignment/internal/pkg/response"
+	"service-pr-reviewer-assignment/internal/service"
+	"service-pr-reviewer-assignment/internal/storage"
+	"service-pr-reviewer-assignment/pkg/log"
+	"service-pr-reviewer-assignment/pkg/querier"
+	"service-pr-reviewer-assignment/pkg/tx"
+
+	"github.com/avito-tech/go-transaction-manager/pgxv5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// newOfflineHandler собирает маршруты поверх пула, который не подключается к базе до
+// первого запроса: запросы, не дошедшие до обработчиков, базу не используют.
+func newOfflineHandler(t *testing.T, cfg *config.Config) http.Handler {
+	t.Helper()
+
+	pool, err := pgxpool.New(context.Background(), "postgres://user@127.0.0.1:1/db")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(pool.Close)
+
+	logger := log.Must(log.Options{})
+	txManager := tx.Must(pool, logger)
+	storage := storage.Must(querier.Must(pool, pgxv5.DefaultCtxGetter))
+	metrics := metrics.Must(pool, txManager)
+
+	return Must(cfg, Dependencies{
+		IsShuttingDown: &atomic.Bool{},
+		OngoingCtx:     context.Background(),
+		Logger:         logger,
+		Service:        service.Must(storage, txManager, metrics, service.AssignmentPolicy{MaxReviewers: 2}, true),
+		Storage:        storage,
+		Metrics:        metrics,
+	})
+}
+
+func TestUnmatchedRoutesCarryRequestID(t *testing.T) {
+	handler := newOfflineHandler(t, config.Default())
+
+	tests := []struct {
+		name       string
+		method     string
+		target     string
+		accept     string
+		wantStatus int
+	}{
+		{name: "not found", method: http.MethodGet, target: "/missing", wantStatus: http.StatusNotFound},
+		{name: "not found as problem", method: http.MethodGet, target: "/missing", accept: response.ProblemContentType, wantStatus: http.StatusNotFound},
+		{name: "method not allowed", method: http.MethodDelete, target: "/livez", wantStatus: http.StatusMethodNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.target, nil)
+			req.Header.Set(request_id.Header, "client-request-1")
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if got := rec.Header().Get(request_id.Header); got != "client-request-1" {
+				t.Errorf("%s = %q, want the client's request ID", request_id.Header, got)
+			}
+			if tt.accept == "" {
+				return
+			}
+
+			var problem dto.Problem
+			if err := json.NewDecoder(rec.Body).Decode(&problem); err != nil {
+				t.Fatal(err)
+			}
+			if problem.Instance == nil || *problem.Instance != "client-request-1" {
+				t.Errorf("instance = %v, want the request ID", problem.Instance)
+			}
+			if problem.Type != "urn:pr-reviewer:problem:not-found" {
+				t.Errorf("type = %q", problem.Type)
+			}
+		})
+	}
+}
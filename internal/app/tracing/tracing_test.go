@@ -0,0 +1,98 @@
+package tracing
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"service-pr-reviewer-assignment/internal/app/config"
+
+	"go.opentelemetry.io/otel"
+	collectortrace "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+type logger struct {
+	t *testing.T
+}
+
+func (l logger) Errorf(format string, args ...any) {
+	l.t.Errorf(format, args...)
+}
+
+// collectorStub принимает экспорт OTLP/HTTP и запоминает имена span-ов.
+type collectorStub struct {
+	mu    sync.Mutex
+	spans map[string]string
+}
+
+func (c *collectorStub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil || r.URL.Path != "/v1/traces" {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	var req collectortrace.ExportTraceServiceRequest
+	if err := proto.Unmarshal(body, &req); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, resourceSpans := range req.GetResourceSpans() {
+		serviceName := ""
+		for _, attr := range resourceSpans.GetResource().GetAttributes() {
+			if attr.GetKey() == "service.name" {
+				serviceName = attr.GetValue().GetStringValue()
+			}
+		}
+		for _, scopeSpans := range resourceSpans.GetScopeSpans() {
+			for _, span := range scopeSpans.GetSpans() {
+				c.spans[span.GetName()] = serviceName
+			}
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/x-protobuf")
+	w.WriteHeader(http.StatusOK)
+}
+
+func TestSetupExportsToCollector(t *testing.T) {
+	collector := &collectorStub{spans: map[string]string{}}
+	server := httptest.NewServer(collector)
+	defer server.Close()
+
+	cfg := config.Default().Tracing
+	cfg.Enabled = true
+	cfg.Endpoint = server.URL
+	cfg.Insecure = true
+
+	ctx := context.Background()
+	shutdown, err := Setup(ctx, cfg, logger{t: t})
+	if err != nil {
+		t.Fatalf("setup: %v", err)
+	}
+
+	_, span := otel.Tracer("test").Start(ctx, "Service.GetTeam")
+	span.End()
+
+	// Shutdown отправляет накопленные span-ы.
+	if err := shutdown(ctx); err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+
+	collector.mu.Lock()
+	defer collector.mu.Unlock()
+	serviceName, ok := collector.spans["Service.GetTeam"]
+	if !ok {
+		t.Fatalf("collector received %v, want span Service.GetTeam", collector.spans)
+	}
+	if serviceName != cfg.ServiceName {
+		t.Errorf("service.name = %q, want %q", serviceName, cfg.ServiceName)
+	}
+}
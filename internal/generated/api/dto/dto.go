@@ -4,22 +4,69 @@
 package dto
 
 import (
+	"encoding/json"
+	"fmt"
 	"time"
 
 	openapi_types "github.com/oapi-codegen/runtime/types"
 )
 
+const (
+	ApiKeyAuthScopes = "ApiKeyAuth.Scopes"
+	BearerAuthScopes = "BearerAuth.Scopes"
+)
+
+// Defines values for APIKeyScope.
+const (
+	APIKeyScopeAdmin      APIKeyScope = "admin"
+	APIKeyScopeRead       APIKeyScope = "read"
+	APIKeyScopeWritePrs   APIKeyScope = "write:prs"
+	APIKeyScopeWriteTeams APIKeyScope = "write:teams"
+)
+
 // Defines values for ErrorResponseErrorCode.
 const (
-	BADREQUEST      ErrorResponseErrorCode = "BAD_REQUEST"
-	DUPLICATEUSERID ErrorResponseErrorCode = "DUPLICATE_USER_ID"
-	INTERNALERROR   ErrorResponseErrorCode = "INTERNAL_ERROR"
-	NOCANDIDATE     ErrorResponseErrorCode = "NO_CANDIDATE"
-	NOTASSIGNED     ErrorResponseErrorCode = "NOT_ASSIGNED"
-	NOTFOUND        ErrorResponseErrorCode = "NOT_FOUND"
-	PREXISTS        ErrorResponseErrorCode = "PR_EXISTS"
-	PRMERGED        ErrorResponseErrorCode = "PR_MERGED"
-	TEAMEXISTS      ErrorResponseErrorCode = "TEAM_EXISTS"
+	BADREQUEST               ErrorResponseErrorCode = "BAD_REQUEST"
+	DUPLICATEUSERID          ErrorResponseErrorCode = "DUPLICATE_USER_ID"
+	FORBIDDEN                ErrorResponseErrorCode = "FORBIDDEN"
+	IDEMPOTENCYKEYINPROGRESS ErrorResponseErrorCode = "IDEMPOTENCY_KEY_IN_PROGRESS"
+	IDEMPOTENCYKEYREUSED     ErrorResponseErrorCode = "IDEMPOTENCY_KEY_REUSED"
+	INSUFFICIENTSCOPE        ErrorResponseErrorCode = "INSUFFICIENT_SCOPE"
+	INTERNALERROR            ErrorResponseErrorCode = "INTERNAL_ERROR"
+	NOCANDIDATE              ErrorResponseErrorCode = "NO_CANDIDATE"
+	NOTASSIGNED              ErrorResponseErrorCode = "NOT_ASSIGNED"
+	NOTFOUND                 ErrorResponseErrorCode = "NOT_FOUND"
+	ORGEXISTS                ErrorResponseErrorCode = "ORG_EXISTS"
+	PAYLOADTOOLARGE          ErrorResponseErrorCode = "PAYLOAD_TOO_LARGE"
+	PREXISTS                 ErrorResponseErrorCode = "PR_EXISTS"
+	PRMERGED                 ErrorResponseErrorCode = "PR_MERGED"
+	RATELIMITED              ErrorResponseErrorCode = "RATE_LIMITED"
+	TEAMEXISTS               ErrorResponseErrorCode = "TEAM_EXISTS"
+	TIMEOUT                  ErrorResponseErrorCode = "TIMEOUT"
+	UNAUTHORIZED             ErrorResponseErrorCode = "UNAUTHORIZED"
+	USEREXISTS               ErrorResponseErrorCode = "USER_EXISTS"
+)
+
+// Defines values for EventType.
+const (
+	EventTypePullRequestMerged   EventType = "pull_request.merged"
+	EventTypeReviewerReassigned  EventType = "pull_request.reviewer_reassigned"
+	EventTypeReviewersAssigned   EventType = "pull_request.reviewers_assigned"
+	EventTypeUserActivityChanged EventType = "user.activity_changed"
+)
+
+// Defines values for HealthStatus.
+const (
+	HealthStatusFail HealthStatus = "fail"
+	HealthStatusOk   HealthStatus = "ok"
+)
+
+// Defines values for LogLevel.
+const (
+	LogLevelDebug LogLevel = "debug"
+	LogLevelError LogLevel = "error"
+	LogLevelInfo  LogLevel = "info"
+	LogLevelWarn  LogLevel = "warn"
 )
 
 // Defines values for PullRequestStatus.
@@ -34,29 +81,170 @@ const (
 	PullRequestShortStatusOPEN   PullRequestShortStatus = "OPEN"
 )
 
+// Defines values for UserRole.
+const (
+	UserRoleMember   UserRole = "member"
+	UserRoleOrgAdmin UserRole = "org_admin"
+	UserRoleTeamLead UserRole = "team_lead"
+)
+
+// APIKey defines model for APIKey.
+type APIKey struct {
+	CreatedAt time.Time          `json:"created_at"`
+	Id        openapi_types.UUID `json:"id"`
+	Name      string             `json:"name"`
+	RevokedAt *time.Time         `json:"revoked_at"`
+	Scopes    []APIKeyScope      `json:"scopes"`
+}
+
+// APIKeyListResponse defines model for APIKeyListResponse.
+type APIKeyListResponse struct {
+	ApiKeys []APIKey `json:"api_keys"`
+}
+
+// APIKeyScope defines model for APIKeyScope.
+type APIKeyScope string
+
+// CreateAPIKeyRequest defines model for CreateAPIKeyRequest.
+type CreateAPIKeyRequest struct {
+	Name   string        `json:"name"`
+	Scopes []APIKeyScope `json:"scopes"`
+}
+
+// CreateAPIKeyResponse defines model for CreateAPIKeyResponse.
+type CreateAPIKeyResponse struct {
+	ApiKey APIKey `json:"api_key"`
+
+	// Key Секрет ключа. Показывается только один раз, в базе хранится его хэш.
+	Key string `json:"key"`
+}
+
+// CreateOrganizationRequest defines model for CreateOrganizationRequest.
+type CreateOrganizationRequest struct {
+	Name string `json:"name"`
+}
+
+// CreateOrganizationResponse defines model for CreateOrganizationResponse.
+type CreateOrganizationResponse struct {
+	ApiKey APIKey `json:"api_key"`
+
+	// Key Секрет первого admin-ключа новой организации. Показывается только один раз.
+	Key          string       `json:"key"`
+	Organization Organization `json:"organization"`
+}
+
 // CreatePullRequestRequest defines model for CreatePullRequestRequest.
 type CreatePullRequestRequest struct {
 	AuthorId        openapi_types.UUID `json:"author_id"`
 	PullRequestId   openapi_types.UUID `json:"pull_request_id"`
 	PullRequestName string             `json:"pull_request_name"`
+
+	// ReturnExisting Если PR с таким id уже существует и совпадают название и автор, вернуть существующий PR с его ревьюверами вместо ошибки PR_EXISTS
+	ReturnExisting *bool `json:"return_existing,omitempty"`
+}
+
+// ErrorDetail defines model for ErrorDetail.
+type ErrorDetail struct {
+	// Location Где найдено нарушение: body с путём до поля (body.members.0.user_id), query.<имя>, header.<имя> или, при импорте команд, row.<номер строки>.<поле>
+	Location string `json:"location"`
+	Message  string `json:"message"`
 }
 
 // ErrorResponse defines model for ErrorResponse.
 type ErrorResponse struct {
 	Error struct {
-		Code    ErrorResponseErrorCode `json:"code"`
-		Message string                 `json:"message"`
+		Code ErrorResponseErrorCode `json:"code"`
+
+		// Details Подробности ошибки валидации запроса, по одной на каждое нарушение
+		Details *[]ErrorDetail `json:"details,omitempty"`
+		Message string         `json:"message"`
+
+		// RequestId Идентификатор запроса из заголовка X-Request-ID. Заполняется для ответов 5xx, чтобы его можно было передать при обращении в поддержку
+		RequestId *string `json:"request_id,omitempty"`
 	} `json:"error"`
 }
 
 // ErrorResponseErrorCode defines model for ErrorResponse.Error.Code.
 type ErrorResponseErrorCode string
 
+// Event Событие потока /events/stream, передаётся в поле data. Поля PR заполнены для событий pull_request.*, user_id и is_active — для user.activity_changed.
+type Event struct {
+	AuthorId  *openapi_types.UUID `json:"author_id,omitempty"`
+	CreatedAt time.Time           `json:"created_at"`
+
+	// Id Номер события, он же id в потоке и значение Last-Event-ID
+	Id            int64               `json:"id"`
+	IsActive      *bool               `json:"is_active,omitempty"`
+	NewReviewerId *openapi_types.UUID `json:"new_reviewer_id,omitempty"`
+	OldReviewerId *openapi_types.UUID `json:"old_reviewer_id,omitempty"`
+	PullRequestId *openapi_types.UUID `json:"pull_request_id,omitempty"`
+
+	// ReviewerIds Ревьюверы PR после изменения
+	ReviewerIds *[]openapi_types.UUID `json:"reviewer_ids,omitempty"`
+
+	// TeamName Команда автора PR или пользователя
+	TeamName string              `json:"team_name"`
+	Type     EventType           `json:"type"`
+	UserId   *openapi_types.UUID `json:"user_id,omitempty"`
+}
+
+// EventType defines model for EventType.
+type EventType string
+
+// HealthStatus defines model for HealthStatus.
+type HealthStatus string
+
+// LivenessResponse defines model for LivenessResponse.
+type LivenessResponse struct {
+	Status HealthStatus `json:"status"`
+}
+
+// LogLevel defines model for LogLevel.
+type LogLevel string
+
+// LogLevels defines model for LogLevels.
+type LogLevels struct {
+	Level LogLevel `json:"level"`
+
+	// Packages Уровни отдельных пакетов. Ключ — путь пакета или его суффикс, например internal/storage или pkg/tx.
+	Packages map[string]LogLevel `json:"packages"`
+}
+
 // MergePullRequestRequest defines model for MergePullRequestRequest.
 type MergePullRequestRequest struct {
 	PullRequestId openapi_types.UUID `json:"pull_request_id"`
 }
 
+// Organization defines model for Organization.
+type Organization struct {
+	CreatedAt time.Time          `json:"created_at"`
+	Id        openapi_types.UUID `json:"id"`
+	Name      string             `json:"name"`
+}
+
+// Problem Ошибка в формате RFC 7807 (application/problem+json). Отдаётся вместо ErrorResponse, если клиент явно указал application/problem+json в заголовке Accept. Помимо стандартных полей содержит код ошибки и поля из доменной ошибки (например, user_ids для DUPLICATE_USER_ID).
+type Problem struct {
+	// Code Код ошибки, тот же, что в ErrorResponse
+	Code string `json:"code"`
+
+	// Detail Описание конкретной ошибки
+	Detail *string `json:"detail,omitempty"`
+
+	// Errors Подробности ошибки валидации запроса, по одной на каждое нарушение
+	Errors *[]ErrorDetail `json:"errors,omitempty"`
+
+	// Instance Идентификатор запроса из заголовка X-Request-ID
+	Instance *string `json:"instance,omitempty"`
+	Status   int     `json:"status"`
+
+	// Title Краткое описание типа ошибки, одинаковое для всех ошибок этого типа
+	Title string `json:"title"`
+
+	// Type URI типа ошибки вида urn:pr-reviewer:problem:<код>, описания типов — на странице /docs/problems.html по якорю с тем же кодом
+	Type                 string                 `json:"type"`
+	AdditionalProperties map[string]interface{} `json:"-"`
+}
+
 // PullRequest defines model for PullRequest.
 type PullRequest struct {
 	// AssignedReviewers user_id назначенных ревьюверов (0..2)
@@ -83,10 +271,25 @@ type PullRequestShort struct {
 // PullRequestShortStatus defines model for PullRequestShort.Status.
 type PullRequestShortStatus string
 
+// ReadinessCheck defines model for ReadinessCheck.
+type ReadinessCheck struct {
+	DurationMs int64        `json:"duration_ms"`
+	Error      *string      `json:"error,omitempty"`
+	Status     HealthStatus `json:"status"`
+}
+
+// ReadinessResponse defines model for ReadinessResponse.
+type ReadinessResponse struct {
+	Checks map[string]ReadinessCheck `json:"checks"`
+	Status HealthStatus              `json:"status"`
+}
+
 // ReassignPullRequestRequest defines model for ReassignPullRequestRequest.
 type ReassignPullRequestRequest struct {
-	OldUserId     openapi_types.UUID `json:"old_user_id"`
-	PullRequestId openapi_types.UUID `json:"pull_request_id"`
+	// NewUserId Явно выбранный новый ревьювер. Доступно только лиду команды ревьювера и администраторам; без поля кандидат выбирается автоматически
+	NewUserId     *openapi_types.UUID `json:"new_user_id,omitempty"`
+	OldUserId     openapi_types.UUID  `json:"old_user_id"`
+	PullRequestId openapi_types.UUID  `json:"pull_request_id"`
 }
 
 // ReassignPullRequestResponse defines model for ReassignPullRequestResponse.
@@ -97,18 +300,73 @@ type ReassignPullRequestResponse struct {
 	ReplacedBy openapi_types.UUID `json:"replaced_by"`
 }
 
+// ReassignReviewerBody defines model for ReassignReviewerBody.
+type ReassignReviewerBody struct {
+	// NewUserId Явно выбранный новый ревьювер. Доступно только лиду команды ревьювера и администраторам; без поля кандидат выбирается автоматически
+	NewUserId *openapi_types.UUID `json:"new_user_id,omitempty"`
+	OldUserId openapi_types.UUID  `json:"old_user_id"`
+}
+
+// RevokeAPIKeyRequest defines model for RevokeAPIKeyRequest.
+type RevokeAPIKeyRequest struct {
+	Id openapi_types.UUID `json:"id"`
+}
+
+// SetUserActiveBody defines model for SetUserActiveBody.
+type SetUserActiveBody struct {
+	IsActive bool `json:"is_active"`
+}
+
 // SetUserActiveRequest defines model for SetUserActiveRequest.
 type SetUserActiveRequest struct {
 	IsActive bool               `json:"is_active"`
 	UserId   openapi_types.UUID `json:"user_id"`
 }
 
+// SetUserRoleBody defines model for SetUserRoleBody.
+type SetUserRoleBody struct {
+	// Role member — обычный участник; team_lead — может менять состав и активность участников своей команды и выбирать ревьювера при переназначении; org_admin — те же права во всех командах и управление ролями
+	Role UserRole `json:"role"`
+}
+
+// SetUserRoleRequest defines model for SetUserRoleRequest.
+type SetUserRoleRequest struct {
+	// Role member — обычный участник; team_lead — может менять состав и активность участников своей команды и выбирать ревьювера при переназначении; org_admin — те же права во всех командах и управление ролями
+	Role   UserRole           `json:"role"`
+	UserId openapi_types.UUID `json:"user_id"`
+}
+
 // Team defines model for Team.
 type Team struct {
 	Members  []TeamMember `json:"members"`
 	TeamName string       `json:"team_name"`
 }
 
+// TeamImportResult defines model for TeamImportResult.
+type TeamImportResult struct {
+	DryRun bool             `json:"dry_run"`
+	Teams  []TeamImportTeam `json:"teams"`
+}
+
+// TeamImportRow defines model for TeamImportRow.
+type TeamImportRow struct {
+	// IsActive По умолчанию true
+	IsActive *bool              `json:"is_active,omitempty"`
+	Team     string             `json:"team"`
+	UserId   openapi_types.UUID `json:"user_id"`
+	Username string             `json:"username"`
+}
+
+// TeamImportTeam defines model for TeamImportTeam.
+type TeamImportTeam struct {
+	// Created Команда создана импортом; false — существующая команда дополнена (upsert)
+	Created bool `json:"created"`
+
+	// Members Участники команды из файла
+	Members  []TeamMember `json:"members"`
+	TeamName string       `json:"team_name"`
+}
+
 // TeamMember defines model for TeamMember.
 type TeamMember struct {
 	IsActive bool               `json:"is_active"`
@@ -118,7 +376,10 @@ type TeamMember struct {
 
 // User defines model for User.
 type User struct {
-	IsActive bool               `json:"is_active"`
+	IsActive bool `json:"is_active"`
+
+	// Role member — обычный участник; team_lead — может менять состав и активность участников своей команды и выбирать ревьювера при переназначении; org_admin — те же права во всех командах и управление ролями
+	Role     UserRole           `json:"role"`
 	TeamName string             `json:"team_name"`
 	UserId   openapi_types.UUID `json:"user_id"`
 	Username string             `json:"username"`
@@ -130,24 +391,252 @@ type UserReviewResponse struct {
 	UserId       openapi_types.UUID `json:"user_id"`
 }
 
+// UserRole member — обычный участник; team_lead — может менять состав и активность участников своей команды и выбирать ревьювера при переназначении; org_admin — те же права во всех командах и управление ролями
+type UserRole string
+
+// APIKeyIdPath defines model for APIKeyIdPath.
+type APIKeyIdPath = openapi_types.UUID
+
+// DryRunQuery defines model for DryRunQuery.
+type DryRunQuery = bool
+
+// IdempotencyKeyHeader defines model for IdempotencyKeyHeader.
+type IdempotencyKeyHeader = string
+
+// PullRequestIdPath defines model for PullRequestIdPath.
+type PullRequestIdPath = openapi_types.UUID
+
+// TeamNamePath defines model for TeamNamePath.
+type TeamNamePath = string
+
 // TeamNameQuery defines model for TeamNameQuery.
 type TeamNameQuery = string
 
+// UpsertQuery defines model for UpsertQuery.
+type UpsertQuery = bool
+
+// UserIdPath defines model for UserIdPath.
+type UserIdPath = openapi_types.UUID
+
 // UserIdQuery defines model for UserIdQuery.
 type UserIdQuery = openapi_types.UUID
 
+// BadRequestApplicationJSON defines model for BadRequest.
+type BadRequestApplicationJSON = ErrorResponse
+
+// BadRequestApplicationProblemPlusJSON Ошибка в формате RFC 7807 (application/problem+json). Отдаётся вместо ErrorResponse, если клиент явно указал application/problem+json в заголовке Accept. Помимо стандартных полей содержит код ошибки и поля из доменной ошибки (например, user_ids для DUPLICATE_USER_ID).
+type BadRequestApplicationProblemPlusJSON = Problem
+
+// ForbiddenApplicationJSON defines model for Forbidden.
+type ForbiddenApplicationJSON = ErrorResponse
+
+// ForbiddenApplicationProblemPlusJSON Ошибка в формате RFC 7807 (application/problem+json). Отдаётся вместо ErrorResponse, если клиент явно указал application/problem+json в заголовке Accept. Помимо стандартных полей содержит код ошибки и поля из доменной ошибки (например, user_ids для DUPLICATE_USER_ID).
+type ForbiddenApplicationProblemPlusJSON = Problem
+
+// IdempotencyKeyInProgressApplicationJSON defines model for IdempotencyKeyInProgress.
+type IdempotencyKeyInProgressApplicationJSON = ErrorResponse
+
+// IdempotencyKeyInProgressApplicationProblemPlusJSON Ошибка в формате RFC 7807 (application/problem+json). Отдаётся вместо ErrorResponse, если клиент явно указал application/problem+json в заголовке Accept. Помимо стандартных полей содержит код ошибки и поля из доменной ошибки (например, user_ids для DUPLICATE_USER_ID).
+type IdempotencyKeyInProgressApplicationProblemPlusJSON = Problem
+
+// IdempotencyKeyReusedApplicationJSON defines model for IdempotencyKeyReused.
+type IdempotencyKeyReusedApplicationJSON = ErrorResponse
+
+// IdempotencyKeyReusedApplicationProblemPlusJSON Ошибка в формате RFC 7807 (application/problem+json). Отдаётся вместо ErrorResponse, если клиент явно указал application/problem+json в заголовке Accept. Помимо стандартных полей содержит код ошибки и поля из доменной ошибки (например, user_ids для DUPLICATE_USER_ID).
+type IdempotencyKeyReusedApplicationProblemPlusJSON = Problem
+
+// InsufficientScopeApplicationJSON defines model for InsufficientScope.
+type InsufficientScopeApplicationJSON = ErrorResponse
+
+// InsufficientScopeApplicationProblemPlusJSON Ошибка в формате RFC 7807 (application/problem+json). Отдаётся вместо ErrorResponse, если клиент явно указал application/problem+json в заголовке Accept. Помимо стандартных полей содержит код ошибки и поля из доменной ошибки (например, user_ids для DUPLICATE_USER_ID).
+type InsufficientScopeApplicationProblemPlusJSON = Problem
+
+// InternalErrorApplicationJSON defines model for InternalError.
+type InternalErrorApplicationJSON = ErrorResponse
+
+// InternalErrorApplicationProblemPlusJSON Ошибка в формате RFC 7807 (application/problem+json). Отдаётся вместо ErrorResponse, если клиент явно указал application/problem+json в заголовке Accept. Помимо стандартных полей содержит код ошибки и поля из доменной ошибки (например, user_ids для DUPLICATE_USER_ID).
+type InternalErrorApplicationProblemPlusJSON = Problem
+
+// NotFoundApplicationJSON defines model for NotFound.
+type NotFoundApplicationJSON = ErrorResponse
+
+// NotFoundApplicationProblemPlusJSON Ошибка в формате RFC 7807 (application/problem+json). Отдаётся вместо ErrorResponse, если клиент явно указал application/problem+json в заголовке Accept. Помимо стандартных полей содержит код ошибки и поля из доменной ошибки (например, user_ids для DUPLICATE_USER_ID).
+type NotFoundApplicationProblemPlusJSON = Problem
+
+// PayloadTooLargeApplicationJSON defines model for PayloadTooLarge.
+type PayloadTooLargeApplicationJSON = ErrorResponse
+
+// PayloadTooLargeApplicationProblemPlusJSON Ошибка в формате RFC 7807 (application/problem+json). Отдаётся вместо ErrorResponse, если клиент явно указал application/problem+json в заголовке Accept. Помимо стандартных полей содержит код ошибки и поля из доменной ошибки (например, user_ids для DUPLICATE_USER_ID).
+type PayloadTooLargeApplicationProblemPlusJSON = Problem
+
+// TimeoutApplicationJSON defines model for Timeout.
+type TimeoutApplicationJSON = ErrorResponse
+
+// TimeoutApplicationProblemPlusJSON Ошибка в формате RFC 7807 (application/problem+json). Отдаётся вместо ErrorResponse, если клиент явно указал application/problem+json в заголовке Accept. Помимо стандартных полей содержит код ошибки и поля из доменной ошибки (например, user_ids для DUPLICATE_USER_ID).
+type TimeoutApplicationProblemPlusJSON = Problem
+
+// TooManyRequestsApplicationJSON defines model for TooManyRequests.
+type TooManyRequestsApplicationJSON = ErrorResponse
+
+// TooManyRequestsApplicationProblemPlusJSON Ошибка в формате RFC 7807 (application/problem+json). Отдаётся вместо ErrorResponse, если клиент явно указал application/problem+json в заголовке Accept. Помимо стандартных полей содержит код ошибки и поля из доменной ошибки (например, user_ids для DUPLICATE_USER_ID).
+type TooManyRequestsApplicationProblemPlusJSON = Problem
+
+// UnauthorizedApplicationJSON defines model for Unauthorized.
+type UnauthorizedApplicationJSON = ErrorResponse
+
+// UnauthorizedApplicationProblemPlusJSON Ошибка в формате RFC 7807 (application/problem+json). Отдаётся вместо ErrorResponse, если клиент явно указал application/problem+json в заголовке Accept. Помимо стандартных полей содержит код ошибки и поля из доменной ошибки (например, user_ids для DUPLICATE_USER_ID).
+type UnauthorizedApplicationProblemPlusJSON = Problem
+
+// PostApiV1PullRequestsParams defines parameters for PostApiV1PullRequests.
+type PostApiV1PullRequestsParams struct {
+	// IdempotencyKey Ключ идемпотентности. Повторный запрос с тем же ключом и телом получает сохранённый ответ первого запроса (с заголовком Idempotent-Replayed: true). Ключ хранится 24 часа.
+	IdempotencyKey *IdempotencyKeyHeader `json:"Idempotency-Key,omitempty"`
+}
+
+// PostApiV1PullRequestsIdMergeParams defines parameters for PostApiV1PullRequestsIdMerge.
+type PostApiV1PullRequestsIdMergeParams struct {
+	// IdempotencyKey Ключ идемпотентности. Повторный запрос с тем же ключом и телом получает сохранённый ответ первого запроса (с заголовком Idempotent-Replayed: true). Ключ хранится 24 часа.
+	IdempotencyKey *IdempotencyKeyHeader `json:"Idempotency-Key,omitempty"`
+}
+
+// PostApiV1PullRequestsIdReassignParams defines parameters for PostApiV1PullRequestsIdReassign.
+type PostApiV1PullRequestsIdReassignParams struct {
+	// IdempotencyKey Ключ идемпотентности. Повторный запрос с тем же ключом и телом получает сохранённый ответ первого запроса (с заголовком Idempotent-Replayed: true). Ключ хранится 24 часа.
+	IdempotencyKey *IdempotencyKeyHeader `json:"Idempotency-Key,omitempty"`
+}
+
+// PostApiV1TeamsParams defines parameters for PostApiV1Teams.
+type PostApiV1TeamsParams struct {
+	// IdempotencyKey Ключ идемпотентности. Повторный запрос с тем же ключом и телом получает сохранённый ответ первого запроса (с заголовком Idempotent-Replayed: true). Ключ хранится 24 часа.
+	IdempotencyKey *IdempotencyKeyHeader `json:"Idempotency-Key,omitempty"`
+}
+
+// PostApiV1TeamsImportParams defines parameters for PostApiV1TeamsImport.
+type PostApiV1TeamsImportParams struct {
+	// DryRun Только проверить запрос, ничего не меняя
+	DryRun *DryRunQuery `form:"dry_run,omitempty" json:"dry_run,omitempty"`
+
+	// Upsert Дополнять существующие команды вместо ошибки TEAM_EXISTS
+	Upsert *UpsertQuery `form:"upsert,omitempty" json:"upsert,omitempty"`
+
+	// IdempotencyKey Ключ идемпотентности. Повторный запрос с тем же ключом и телом получает сохранённый ответ первого запроса (с заголовком Idempotent-Replayed: true). Ключ хранится 24 часа.
+	IdempotencyKey *IdempotencyKeyHeader `json:"Idempotency-Key,omitempty"`
+}
+
+// GetEventsStreamParams defines parameters for GetEventsStream.
+type GetEventsStreamParams struct {
+	// UserId Только события, где пользователь автор, ревьювер или субъект изменения активности
+	UserId *openapi_types.UUID `form:"user_id,omitempty" json:"user_id,omitempty"`
+
+	// TeamName Только события команды
+	TeamName *string `form:"team_name,omitempty" json:"team_name,omitempty"`
+
+	// PullRequestId Только события PR
+	PullRequestId *openapi_types.UUID `form:"pull_request_id,omitempty" json:"pull_request_id,omitempty"`
+
+	// LastEventId Номер последнего полученного события, если заголовок Last-Event-ID не задан
+	LastEventId *int64 `form:"last_event_id,omitempty" json:"last_event_id,omitempty"`
+
+	// LastEventID Номер последнего полученного события, его подставляет EventSource при переподключении
+	LastEventID *string `json:"Last-Event-ID,omitempty"`
+}
+
+// PostPullRequestCreateParams defines parameters for PostPullRequestCreate.
+type PostPullRequestCreateParams struct {
+	// IdempotencyKey Ключ идемпотентности. Повторный запрос с тем же ключом и телом получает сохранённый ответ первого запроса (с заголовком Idempotent-Replayed: true). Ключ хранится 24 часа.
+	IdempotencyKey *IdempotencyKeyHeader `json:"Idempotency-Key,omitempty"`
+}
+
+// PostPullRequestMergeParams defines parameters for PostPullRequestMerge.
+type PostPullRequestMergeParams struct {
+	// IdempotencyKey Ключ идемпотентности. Повторный запрос с тем же ключом и телом получает сохранённый ответ первого запроса (с заголовком Idempotent-Replayed: true). Ключ хранится 24 часа.
+	IdempotencyKey *IdempotencyKeyHeader `json:"Idempotency-Key,omitempty"`
+}
+
+// PostPullRequestReassignParams defines parameters for PostPullRequestReassign.
+type PostPullRequestReassignParams struct {
+	// IdempotencyKey Ключ идемпотентности. Повторный запрос с тем же ключом и телом получает сохранённый ответ первого запроса (с заголовком Idempotent-Replayed: true). Ключ хранится 24 часа.
+	IdempotencyKey *IdempotencyKeyHeader `json:"Idempotency-Key,omitempty"`
+}
+
+// PostTeamAddParams defines parameters for PostTeamAdd.
+type PostTeamAddParams struct {
+	// IdempotencyKey Ключ идемпотентности. Повторный запрос с тем же ключом и телом получает сохранённый ответ первого запроса (с заголовком Idempotent-Replayed: true). Ключ хранится 24 часа.
+	IdempotencyKey *IdempotencyKeyHeader `json:"Idempotency-Key,omitempty"`
+}
+
 // GetTeamGetParams defines parameters for GetTeamGet.
 type GetTeamGetParams struct {
 	// TeamName Уникальное имя команды
 	TeamName TeamNameQuery `form:"team_name" json:"team_name"`
 }
 
+// PostTeamImportParams defines parameters for PostTeamImport.
+type PostTeamImportParams struct {
+	// DryRun Только проверить запрос, ничего не меняя
+	DryRun *DryRunQuery `form:"dry_run,omitempty" json:"dry_run,omitempty"`
+
+	// Upsert Дополнять существующие команды вместо ошибки TEAM_EXISTS
+	Upsert *UpsertQuery `form:"upsert,omitempty" json:"upsert,omitempty"`
+
+	// IdempotencyKey Ключ идемпотентности. Повторный запрос с тем же ключом и телом получает сохранённый ответ первого запроса (с заголовком Idempotent-Replayed: true). Ключ хранится 24 часа.
+	IdempotencyKey *IdempotencyKeyHeader `json:"Idempotency-Key,omitempty"`
+}
+
 // GetUsersGetReviewParams defines parameters for GetUsersGetReview.
 type GetUsersGetReviewParams struct {
 	// UserId Идентификатор пользователя
 	UserId UserIdQuery `form:"user_id" json:"user_id"`
 }
 
+// PostUsersSetIsActiveParams defines parameters for PostUsersSetIsActive.
+type PostUsersSetIsActiveParams struct {
+	// IdempotencyKey Ключ идемпотентности. Повторный запрос с тем же ключом и телом получает сохранённый ответ первого запроса (с заголовком Idempotent-Replayed: true). Ключ хранится 24 часа.
+	IdempotencyKey *IdempotencyKeyHeader `json:"Idempotency-Key,omitempty"`
+}
+
+// PostUsersSetRoleParams defines parameters for PostUsersSetRole.
+type PostUsersSetRoleParams struct {
+	// IdempotencyKey Ключ идемпотентности. Повторный запрос с тем же ключом и телом получает сохранённый ответ первого запроса (с заголовком Idempotent-Replayed: true). Ключ хранится 24 часа.
+	IdempotencyKey *IdempotencyKeyHeader `json:"Idempotency-Key,omitempty"`
+}
+
+// PostAdminApiKeysCreateJSONRequestBody defines body for PostAdminApiKeysCreate for application/json ContentType.
+type PostAdminApiKeysCreateJSONRequestBody = CreateAPIKeyRequest
+
+// PostAdminApiKeysRevokeJSONRequestBody defines body for PostAdminApiKeysRevoke for application/json ContentType.
+type PostAdminApiKeysRevokeJSONRequestBody = RevokeAPIKeyRequest
+
+// PostAdminLogLevelsSetJSONRequestBody defines body for PostAdminLogLevelsSet for application/json ContentType.
+type PostAdminLogLevelsSetJSONRequestBody = LogLevels
+
+// PostAdminOrganizationsCreateJSONRequestBody defines body for PostAdminOrganizationsCreate for application/json ContentType.
+type PostAdminOrganizationsCreateJSONRequestBody = CreateOrganizationRequest
+
+// PostApiV1AdminApiKeysJSONRequestBody defines body for PostApiV1AdminApiKeys for application/json ContentType.
+type PostApiV1AdminApiKeysJSONRequestBody = CreateAPIKeyRequest
+
+// PutApiV1AdminLogLevelsJSONRequestBody defines body for PutApiV1AdminLogLevels for application/json ContentType.
+type PutApiV1AdminLogLevelsJSONRequestBody = LogLevels
+
+// PostApiV1AdminOrganizationsJSONRequestBody defines body for PostApiV1AdminOrganizations for application/json ContentType.
+type PostApiV1AdminOrganizationsJSONRequestBody = CreateOrganizationRequest
+
+// PostApiV1PullRequestsJSONRequestBody defines body for PostApiV1PullRequests for application/json ContentType.
+type PostApiV1PullRequestsJSONRequestBody = CreatePullRequestRequest
+
+// PostApiV1PullRequestsIdReassignJSONRequestBody defines body for PostApiV1PullRequestsIdReassign for application/json ContentType.
+type PostApiV1PullRequestsIdReassignJSONRequestBody = ReassignReviewerBody
+
+// PostApiV1TeamsJSONRequestBody defines body for PostApiV1Teams for application/json ContentType.
+type PostApiV1TeamsJSONRequestBody = Team
+
+// PutApiV1UsersIdActiveJSONRequestBody defines body for PutApiV1UsersIdActive for application/json ContentType.
+type PutApiV1UsersIdActiveJSONRequestBody = SetUserActiveBody
+
+// PutApiV1UsersIdRoleJSONRequestBody defines body for PutApiV1UsersIdRole for application/json ContentType.
+type PutApiV1UsersIdRoleJSONRequestBody = SetUserRoleBody
+
 // PostPullRequestCreateJSONRequestBody defines body for PostPullRequestCreate for application/json ContentType.
 type PostPullRequestCreateJSONRequestBody = CreatePullRequestRequest
 
@@ -162,3 +651,156 @@ type PostTeamAddJSONRequestBody = Team
 
 // PostUsersSetIsActiveJSONRequestBody defines body for PostUsersSetIsActive for application/json ContentType.
 type PostUsersSetIsActiveJSONRequestBody = SetUserActiveRequest
+
+// PostUsersSetRoleJSONRequestBody defines body for PostUsersSetRole for application/json ContentType.
+type PostUsersSetRoleJSONRequestBody = SetUserRoleRequest
+
+// Getter for additional properties for Problem. Returns the specified
+// element and whether it was found
+func (a Problem) Get(fieldName string) (value interface{}, found bool) {
+	if a.AdditionalProperties != nil {
+		value, found = a.AdditionalProperties[fieldName]
+	}
+	return
+}
+
+// Setter for additional properties for Problem
+func (a *Problem) Set(fieldName string, value interface{}) {
+	if a.AdditionalProperties == nil {
+		a.AdditionalProperties = make(map[string]interface{})
+	}
+	a.AdditionalProperties[fieldName] = value
+}
+
+// Override default JSON handling for Problem to handle AdditionalProperties
+func (a *Problem) UnmarshalJSON(b []byte) error {
+	object := make(map[string]json.RawMessage)
+	err := json.Unmarshal(b, &object)
+	if err != nil {
+		return err
+	}
+
+	if raw, found := object["code"]; found {
+		err = json.Unmarshal(raw, &a.Code)
+		if err != nil {
+			return fmt.Errorf("error reading 'code': %w", err)
+		}
+		delete(object, "code")
+	}
+
+	if raw, found := object["detail"]; found {
+		err = json.Unmarshal(raw, &a.Detail)
+		if err != nil {
+			return fmt.Errorf("error reading 'detail': %w", err)
+		}
+		delete(object, "detail")
+	}
+
+	if raw, found := object["errors"]; found {
+		err = json.Unmarshal(raw, &a.Errors)
+		if err != nil {
+			return fmt.Errorf("error reading 'errors': %w", err)
+		}
+		delete(object, "errors")
+	}
+
+	if raw, found := object["instance"]; found {
+		err = json.Unmarshal(raw, &a.Instance)
+		if err != nil {
+			return fmt.Errorf("error reading 'instance': %w", err)
+		}
+		delete(object, "instance")
+	}
+
+	if raw, found := object["status"]; found {
+		err = json.Unmarshal(raw, &a.Status)
+		if err != nil {
+			return fmt.Errorf("error reading 'status': %w", err)
+		}
+		delete(object, "status")
+	}
+
+	if raw, found := object["title"]; found {
+		err = json.Unmarshal(raw, &a.Title)
+		if err != nil {
+			return fmt.Errorf("error reading 'title': %w", err)
+		}
+		delete(object, "title")
+	}
+
+	if raw, found := object["type"]; found {
+		err = json.Unmarshal(raw, &a.Type)
+		if err != nil {
+			return fmt.Errorf("error reading 'type': %w", err)
+		}
+		delete(object, "type")
+	}
+
+	if len(object) != 0 {
+		a.AdditionalProperties = make(map[string]interface{})
+		for fieldName, fieldBuf := range object {
+			var fieldVal interface{}
+			err := json.Unmarshal(fieldBuf, &fieldVal)
+			if err != nil {
+				return fmt.Errorf("error unmarshaling field %s: %w", fieldName, err)
+			}
+			a.AdditionalProperties[fieldName] = fieldVal
+		}
+	}
+	return nil
+}
+
+// Override default JSON handling for Problem to handle AdditionalProperties
+func (a Problem) MarshalJSON() ([]byte, error) {
+	var err error
+	object := make(map[string]json.RawMessage)
+
+	object["code"], err = json.Marshal(a.Code)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling 'code': %w", err)
+	}
+
+	if a.Detail != nil {
+		object["detail"], err = json.Marshal(a.Detail)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling 'detail': %w", err)
+		}
+	}
+
+	if a.Errors != nil {
+		object["errors"], err = json.Marshal(a.Errors)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling 'errors': %w", err)
+		}
+	}
+
+	if a.Instance != nil {
+		object["instance"], err = json.Marshal(a.Instance)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling 'instance': %w", err)
+		}
+	}
+
+	object["status"], err = json.Marshal(a.Status)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling 'status': %w", err)
+	}
+
+	object["title"], err = json.Marshal(a.Title)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling 'title': %w", err)
+	}
+
+	object["type"], err = json.Marshal(a.Type)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling 'type': %w", err)
+	}
+
+	for fieldName, field := range a.AdditionalProperties {
+		object[fieldName], err = json.Marshal(field)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling '%s': %w", fieldName, err)
+		}
+	}
+	return json.Marshal(object)
+}
@@ -0,0 +1,34 @@
+package access_log
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// UnaryServerInterceptor пишет одну запись access log на вызов gRPC. Поля request_id и grpc_method
+// берутся из контекста, поэтому interceptor подключается после request_logging_context.
+func UnaryServerInterceptor(logger Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+
+		resp, err := handler(ctx, req)
+
+		var remoteAddr string
+		if p, ok := peer.FromContext(ctx); ok {
+			remoteAddr = p.Addr.String()
+		}
+
+		ctx = logger.LogCtx(logger.WithoutSampling(ctx),
+			"code", status.Code(err).String(),
+			"latency_ms", float64(time.Since(start).Microseconds())/1000,
+			"remote_addr", remoteAddr,
+		)
+		logger.InfoContext(ctx, "grpc request")
+
+		return resp, err
+	}
+}
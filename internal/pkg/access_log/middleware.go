@@ -0,0 +1,52 @@
+package access_log
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"service-pr-reviewer-assignment/internal/pkg/response_writer"
+)
+
+type Logger interface {
+	LogCtx(ctx context.Context, fields ...any) context.Context
+	WithoutSampling(ctx context.Context) context.Context
+	DebugContext(ctx context.Context, msg string)
+	InfoContext(ctx context.Context, msg string)
+}
+
+// probePaths пути проб и метрик. Их опрашивают каждые несколько секунд, поэтому
+// записи о них пишутся на уровне DEBUG, чтобы не вытеснять запросы к API.
+var probePaths = map[string]bool{
+	"/healthcheck": true,
+	"/livez":       true,
+	"/readyz":      true,
+	"/metrics":     true,
+}
+
+// Middleware пишет одну запись access log на запрос. Поля request_id, path и method
+// берутся из контекста, поэтому middleware подключается после request_logging_context.
+// Записи access log не сэмплируются: все они идут из одного места вызова.
+func Middleware(logger Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			rw := response_writer.Wrap(w)
+
+			next.ServeHTTP(rw, r)
+
+			ctx := logger.LogCtx(logger.WithoutSampling(r.Context()),
+				"status", rw.Status(),
+				"bytes", rw.Size(),
+				"latency_ms", float64(time.Since(start).Microseconds())/1000,
+				"remote_addr", r.RemoteAddr,
+				"user_agent", r.UserAgent(),
+			)
+			if probePaths[r.URL.Path] {
+				logger.DebugContext(ctx, "http request")
+				return
+			}
+			logger.InfoContext(ctx, "http request")
+		})
+	}
+}
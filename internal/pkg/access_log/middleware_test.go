@@ -0,0 +1,68 @@
+package access_log
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"service-pr-reviewer-assignment/pkg/log"
+)
+
+type recordingLogger struct {
+	levels []string
+}
+
+func (l *recordingLogger) LogCtx(ctx context.Context, _ ...any) context.Context { return ctx }
+
+func (l *recordingLogger) WithoutSampling(ctx context.Context) context.Context { return ctx }
+
+func (l *recordingLogger) DebugContext(context.Context, string) { l.levels = append(l.levels, "debug") }
+
+func (l *recordingLogger) InfoContext(context.Context, string) { l.levels = append(l.levels, "info") }
+
+func TestMiddlewareLogsProbesAtDebug(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{path: "/healthcheck", want: "debug"},
+		{path: "/livez", want: "debug"},
+		{path: "/readyz", want: "debug"},
+		{path: "/metrics", want: "debug"},
+		{path: "/api/v1/teams", want: "info"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			logger := &recordingLogger{}
+			handler := Middleware(logger)(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+
+			handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			if len(logger.levels) != 1 || logger.levels[0] != tt.want {
+				t.Errorf("levels = %v, want [%s]", logger.levels, tt.want)
+			}
+		})
+	}
+}
+
+func TestMiddlewareIsNotSampled(t *testing.T) {
+	var output bytes.Buffer
+	logger := log.Must(log.Options{
+		Output:   &output,
+		Sampling: log.Sampling{Initial: 1, Thereafter: 100, Tick: time.Hour},
+	})
+	handler := Middleware(logger)(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+
+	for range 5 {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/v1/teams", nil))
+	}
+
+	if got := strings.Count(output.String(), "http request"); got != 5 {
+		t.Errorf("got %d access log lines, want 5:\n%s", got, output.String())
+	}
+}
@@ -0,0 +1,62 @@
+package authentication
+
+import (
+	"context"
+
+	"service-pr-reviewer-assignment/internal/generated/api/dto"
+	"service-pr-reviewer-assignment/internal/pkg/grpc_metadata"
+	"service-pr-reviewer-assignment/internal/pkg/grpc_status"
+	"service-pr-reviewer-assignment/internal/pkg/identity"
+	"service-pr-reviewer-assignment/internal/service/entities"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+// UnaryServerInterceptor аутентифицирует вызов gRPC так же, как Middleware, по метаданным
+// authorization и x-api-key.
+func UnaryServerInterceptor(logger Logger, service Service, verifier TokenVerifier, enabled bool) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if !enabled {
+			return handler(identity.WithIdentity(ctx, identity.Anonymous), req)
+		}
+
+		authorization := grpc_metadata.Get(ctx, HeaderAuthorization)
+		apiKey := grpc_metadata.Get(ctx, HeaderAPIKey)
+
+		caller, err := authenticate(ctx, authorization, apiKey, service, verifier)
+		if err != nil {
+			logger.ErrorfContext(ctx, "authenticate request failed: %v", err)
+			return nil, grpc_status.FromError(err)
+		}
+
+		ctx = logger.LogCtx(ctx, "actor", caller.Subject)
+		ctx = identity.WithIdentity(ctx, caller)
+
+		return handler(ctx, req)
+	}
+}
+
+// RequireScopeInterceptor пропускает только вызывающих со scope метода из scopes.
+// Метод без записи в scopes требует admin, чтобы новый RPC не оказался открытым.
+// Должен стоять после UnaryServerInterceptor.
+func RequireScopeInterceptor(logger Logger, scopes map[string]entities.Scope) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		caller, ok := identity.FromContext(ctx)
+		if !ok {
+			logger.ErrorfContext(ctx, "identity is missing in context")
+			return nil, grpc_status.Error(codes.Unauthenticated, dto.UNAUTHORIZED, "api key is required")
+		}
+
+		scope, ok := scopes[info.FullMethod]
+		if !ok {
+			scope = entities.ScopeAdmin
+		}
+		if !caller.HasScope(scope) {
+			logger.ErrorfContext(ctx, "scope %s is required", scope)
+			return nil, grpc_status.Error(codes.PermissionDenied, dto.INSUFFICIENTSCOPE, "scope "+string(scope)+" is required")
+		}
+
+		return handler(ctx, req)
+	}
+}
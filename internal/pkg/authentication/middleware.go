@@ -0,0 +1,117 @@
+package authentication
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"service-pr-reviewer-assignment/internal/generated/api/dto"
+	"service-pr-reviewer-assignment/internal/pkg/identity"
+	"service-pr-reviewer-assignment/internal/pkg/response"
+	"service-pr-reviewer-assignment/internal/service/entities"
+)
+
+const (
+	HeaderAPIKey        = "X-API-Key"
+	HeaderAuthorization = "Authorization"
+
+	bearerPrefix = "Bearer "
+)
+
+type Logger interface {
+	ErrorfContext(ctx context.Context, format string, args ...interface{})
+	LogCtx(ctx context.Context, fields ...any) context.Context
+}
+
+type Service interface {
+	AuthenticateAPIKey(ctx context.Context, secret string) (*entities.Identity, error)
+}
+
+type TokenVerifier interface {
+	Verify(ctx context.Context, token string) (*entities.Identity, error)
+}
+
+// Middleware аутентифицирует запрос по bearer-токену из заголовка Authorization
+// или по заголовку X-API-Key и сохраняет вызывающего в контексте. Bearer-токеном
+// можно передать и API-ключ: так его отправляют SCIM-клиенты. Если verifier
+// равен nil, JWT не принимаются. С выключенной аутентификацией все
+// запросы выполняются от имени identity.Anonymous.
+func Middleware(logger Logger, service Service, verifier TokenVerifier, enabled bool) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+
+			if !enabled {
+				ctx = identity.WithIdentity(ctx, identity.Anonymous)
+				next.ServeHTTP(w, r.WithContext(ctx))
+				return
+			}
+
+			caller, err := authenticate(ctx, r.Header.Get(HeaderAuthorization), r.Header.Get(HeaderAPIKey), service, verifier)
+			if err != nil {
+				logger.ErrorfContext(ctx, "authenticate request failed: %v", err)
+				response.FromError(w, err)
+				return
+			}
+
+			ctx = logger.LogCtx(ctx, "actor", caller.Subject)
+			ctx = identity.WithIdentity(ctx, caller)
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// authenticate проверяет значения заголовков Authorization и X-API-Key, общие для HTTP и gRPC.
+func authenticate(
+	ctx context.Context,
+	authorization string,
+	apiKey string,
+	service Service,
+	verifier TokenVerifier,
+) (*entities.Identity, error) {
+	if authorization != "" {
+		token, ok := strings.CutPrefix(authorization, bearerPrefix)
+		if !ok || token == "" {
+			return nil, fmt.Errorf("%w: unsupported authorization scheme", entities.ErrInvalidCredentials)
+		}
+		if strings.HasPrefix(token, entities.APIKeyPrefix) {
+			return service.AuthenticateAPIKey(ctx, token)
+		}
+		if verifier == nil {
+			return nil, fmt.Errorf("%w: bearer tokens are not accepted", entities.ErrInvalidCredentials)
+		}
+		return verifier.Verify(ctx, token)
+	}
+
+	if apiKey != "" {
+		return service.AuthenticateAPIKey(ctx, apiKey)
+	}
+
+	return nil, fmt.Errorf("%w: credentials are missing", entities.ErrInvalidCredentials)
+}
+
+// RequireScope пропускает только вызывающих с нужным scope. Должен стоять после Middleware.
+func RequireScope(logger Logger, scope entities.Scope) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+
+			caller, ok := identity.FromContext(ctx)
+			if !ok {
+				logger.ErrorfContext(ctx, "identity is missing in context")
+				response.Error(w, http.StatusUnauthorized, dto.UNAUTHORIZED, "api key is required")
+				return
+			}
+
+			if !caller.HasScope(scope) {
+				logger.ErrorfContext(ctx, "scope %s is required", scope)
+				response.Error(w, http.StatusForbidden, dto.INSUFFICIENTSCOPE, "scope "+string(scope)+" is required")
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,170 @@
+package authentication
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"service-pr-reviewer-assignment/internal/generated/api/dto"
+	"service-pr-reviewer-assignment/internal/pkg/identity"
+	"service-pr-reviewer-assignment/internal/service/entities"
+)
+
+type noopLogger struct{}
+
+func (noopLogger) ErrorfContext(context.Context, string, ...interface{}) {}
+
+func (noopLogger) LogCtx(ctx context.Context, _ ...any) context.Context { return ctx }
+
+// fakeService принимает только ключи из keys.
+type fakeService struct {
+	keys map[string]*entities.Identity
+}
+
+func (s fakeService) AuthenticateAPIKey(_ context.Context, secret string) (*entities.Identity, error) {
+	if caller, ok := s.keys[secret]; ok {
+		return caller, nil
+	}
+	return nil, fmt.Errorf("authenticate api key: %w", entities.ErrInvalidCredentials)
+}
+
+// fakeVerifier принимает единственный токен.
+type fakeVerifier struct{}
+
+func (fakeVerifier) Verify(_ context.Context, token string) (*entities.Identity, error) {
+	if token != "valid.jwt.token" {
+		return nil, fmt.Errorf("%w: signature is invalid", entities.ErrInvalidCredentials)
+	}
+	return &entities.Identity{Subject: "user:alice", Scopes: []entities.Scope{entities.ScopeRead}}, nil
+}
+
+var (
+	readerKey = entities.APIKeyPrefix + "reader"
+	reader    = &entities.Identity{Subject: "api_key:reader", Scopes: []entities.Scope{entities.ScopeRead}}
+	service   = fakeService{keys: map[string]*entities.Identity{readerKey: reader}}
+)
+
+// subjectHandler возвращает Subject вызывающего из контекста.
+var subjectHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	caller, ok := identity.FromContext(r.Context())
+	if !ok {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	_, _ = w.Write([]byte(caller.Subject))
+})
+
+func TestMiddleware(t *testing.T) {
+	tests := []struct {
+		name          string
+		verifier      TokenVerifier
+		disabled      bool
+		authorization string
+		apiKey        string
+		wantStatus    int
+		wantSubject   string
+	}{
+		{name: "disabled", disabled: true, wantStatus: http.StatusOK, wantSubject: identity.Anonymous.Subject},
+		{name: "api key header", apiKey: readerKey, wantStatus: http.StatusOK, wantSubject: reader.Subject},
+		{name: "api key as bearer", authorization: "Bearer " + readerKey, wantStatus: http.StatusOK, wantSubject: reader.Subject},
+		{name: "jwt", verifier: fakeVerifier{}, authorization: "Bearer valid.jwt.token", wantStatus: http.StatusOK, wantSubject: "user:alice"},
+		{name: "authorization wins over api key", verifier: fakeVerifier{}, authorization: "Bearer valid.jwt.token", apiKey: readerKey, wantStatus: http.StatusOK, wantSubject: "user:alice"},
+		{name: "missing credentials", wantStatus: http.StatusUnauthorized},
+		{name: "unknown api key", apiKey: entities.APIKeyPrefix + "unknown", wantStatus: http.StatusUnauthorized},
+		{name: "unsupported scheme", authorization: "Basic dXNlcjpwYXNz", wantStatus: http.StatusUnauthorized},
+		{name: "empty bearer", authorization: "Bearer ", wantStatus: http.StatusUnauthorized},
+		{name: "jwt without verifier", authorization: "Bearer valid.jwt.token", wantStatus: http.StatusUnauthorized},
+		{name: "invalid jwt", verifier: fakeVerifier{}, authorization: "Bearer forged.jwt.token", wantStatus: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/team/get", nil)
+			if tt.authorization != "" {
+				req.Header.Set(HeaderAuthorization, tt.authorization)
+			}
+			if tt.apiKey != "" {
+				req.Header.Set(HeaderAPIKey, tt.apiKey)
+			}
+
+			rec := httptest.NewRecorder()
+			Middleware(noopLogger{}, service, tt.verifier, !tt.disabled)(subjectHandler).ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if tt.wantStatus != http.StatusOK {
+				assertErrorCode(t, rec, dto.UNAUTHORIZED)
+				return
+			}
+			if got := rec.Body.String(); got != tt.wantSubject {
+				t.Errorf("subject = %q, want %q", got, tt.wantSubject)
+			}
+		})
+	}
+}
+
+func TestRequireScope(t *testing.T) {
+	tests := []struct {
+		name       string
+		caller     *entities.Identity
+		wantStatus int
+		wantCode   dto.ErrorResponseErrorCode
+	}{
+		{name: "no identity", wantStatus: http.StatusUnauthorized, wantCode: dto.UNAUTHORIZED},
+		{name: "missing scope", caller: reader, wantStatus: http.StatusForbidden, wantCode: dto.INSUFFICIENTSCOPE},
+		{
+			name:       "matching scope",
+			caller:     &entities.Identity{Scopes: []entities.Scope{entities.ScopeWriteTeams}},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "admin implies every scope",
+			caller:     &entities.Identity{Scopes: []entities.Scope{entities.ScopeAdmin}},
+			wantStatus: http.StatusOK,
+		},
+		{name: "anonymous", caller: identity.Anonymous, wantStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/team/add", nil)
+			if tt.caller != nil {
+				req = req.WithContext(identity.WithIdentity(req.Context(), tt.caller))
+			}
+
+			rec := httptest.NewRecorder()
+			RequireScope(noopLogger{}, entities.ScopeWriteTeams)(subjectHandler).ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if tt.wantCode != "" {
+				assertErrorCode(t, rec, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestAuthenticatePassesServiceErrors(t *testing.T) {
+	_, err := authenticate(context.Background(), "", entities.APIKeyPrefix+"unknown", service, nil)
+	if !errors.Is(err, entities.ErrInvalidCredentials) {
+		t.Errorf("err = %v, want ErrInvalidCredentials", err)
+	}
+}
+
+func assertErrorCode(t *testing.T, rec *httptest.ResponseRecorder, want dto.ErrorResponseErrorCode) {
+	t.Helper()
+
+	var resp dto.ErrorResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp.Error.Code != want {
+		t.Errorf("code = %s, want %s", resp.Error.Code, want)
+	}
+}
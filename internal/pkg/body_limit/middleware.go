@@ -0,0 +1,37 @@
+package body_limit
+
+import (
+	"context"
+	"net/http"
+
+	"service-pr-reviewer-assignment/internal/pkg/response"
+	"service-pr-reviewer-assignment/internal/pkg/route"
+)
+
+type Logger interface {
+	ErrorfContext(ctx context.Context, format string, args ...interface{})
+}
+
+// Middleware ограничивает размер тела запроса лимитом маршрута или лимитом по умолчанию.
+// Запрос с заведомо большим Content-Length отклоняется сразу, тело без длины читается
+// не дальше лимита, после чего декодирование в обработчике завершается ошибкой,
+// которую response.DecodeBodyError превращает в тот же ответ 413.
+func Middleware(logger Logger, defaultLimit int64, routes map[string]int64) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			limit := defaultLimit
+			if routeLimit, ok := routes[route.Template(r)]; ok {
+				limit = routeLimit
+			}
+
+			if r.ContentLength > limit {
+				logger.ErrorfContext(r.Context(), "request body of %d bytes exceeds limit of %d bytes", r.ContentLength, limit)
+				response.FromError(w, response.PayloadTooLarge(limit))
+				return
+			}
+
+			r.Body = http.MaxBytesReader(w, r.Body, limit)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package body_limit
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"service-pr-reviewer-assignment/internal/generated/api/dto"
+	"service-pr-reviewer-assignment/internal/pkg/response"
+
+	"github.com/gorilla/mux"
+)
+
+type noopLogger struct{}
+
+func (noopLogger) ErrorfContext(context.Context, string, ...interface{}) {}
+
+// decodeHandler декодирует тело так же, как обработчики API.
+var decodeHandler = response.HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+	var body map[string]any
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		return response.DecodeBodyError(err, "decode body failed: "+err.Error())
+	}
+	w.WriteHeader(http.StatusOK)
+	return nil
+})
+
+func newRouter() *mux.Router {
+	router := mux.NewRouter()
+	router.Use(Middleware(noopLogger{}, 32, map[string]int64{"/team/import": 128}))
+	router.Handle("/team/add", decodeHandler).Methods(http.MethodPost)
+	router.Handle("/team/import", decodeHandler).Methods(http.MethodPost)
+	return router
+}
+
+func TestMiddleware(t *testing.T) {
+	small := `{"team_name":"payments"}`
+	large := `{"team_name":"` + strings.Repeat("a", 64) + `"}`
+
+	tests := []struct {
+		name          string
+		path          string
+		body          string
+		unknownLength bool
+		wantStatus    int
+	}{
+		{name: "within default limit", path: "/team/add", body: small, wantStatus: http.StatusOK},
+		{name: "content length over limit", path: "/team/add", body: large, wantStatus: http.StatusRequestEntityTooLarge},
+		{name: "streamed body over limit", path: "/team/add", body: large, unknownLength: true, wantStatus: http.StatusRequestEntityTooLarge},
+		{name: "route limit", path: "/team/import", body: large, wantStatus: http.StatusOK},
+		{name: "invalid json", path: "/team/add", body: `{"team_name":`, wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(tt.body))
+			if tt.unknownLength {
+				req.ContentLength = -1
+			}
+
+			rec := httptest.NewRecorder()
+			newRouter().ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.wantStatus, rec.Body.String())
+			}
+			if tt.wantStatus != http.StatusRequestEntityTooLarge {
+				return
+			}
+
+			var resp dto.ErrorResponse
+			if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+				t.Fatal(err)
+			}
+			if resp.Error.Code != dto.PAYLOADTOOLARGE || resp.Error.Message != "request body exceeds 32 bytes" {
+				t.Errorf("error = %+v", resp.Error)
+			}
+		})
+	}
+}
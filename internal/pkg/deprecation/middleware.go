@@ -0,0 +1,31 @@
+package deprecation
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Header заголовок RFC 9745 с датой, с которой маршрут считается устаревшим.
+const Header = "Deprecation"
+
+// Middleware помечает ответы устаревших маршрутов paths заголовком Deprecation
+// в формате structured field date (@<unix-время>). Middleware ставится до аутентификации,
+// чтобы заголовок получали и ответы 401/403.
+func Middleware(deprecatedAt time.Time, paths []string) func(http.Handler) http.Handler {
+	value := "@" + strconv.FormatInt(deprecatedAt.Unix(), 10)
+
+	deprecated := make(map[string]struct{}, len(paths))
+	for _, path := range paths {
+		deprecated[path] = struct{}{}
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if _, ok := deprecated[r.URL.Path]; ok {
+				w.Header().Set(Header, value)
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
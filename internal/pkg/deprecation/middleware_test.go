@@ -0,0 +1,35 @@
+package deprecation
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestMiddleware(t *testing.T) {
+	deprecatedAt := time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC)
+	handler := Middleware(deprecatedAt, []string{"/team/get"})(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+
+	tests := []struct {
+		target string
+		want   string
+	}{
+		{target: "/team/get?team_name=payments", want: "@1792368000"},
+		{target: "/team/import", want: ""},
+		{target: "/api/v1/teams/payments", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.target, nil))
+
+			if got := rec.Header().Get(Header); got != tt.want {
+				t.Errorf("%s = %q, want %q", Header, got, tt.want)
+			}
+		})
+	}
+}
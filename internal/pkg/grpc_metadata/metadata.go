@@ -0,0 +1,22 @@
+package grpc_metadata
+
+import (
+	"context"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// Get возвращает первое значение ключа из входящих метаданных вызова gRPC. Ключи
+// сравниваются без учёта регистра, поэтому подходят имена HTTP-заголовков (X-API-Key).
+func Get(ctx context.Context, key string) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+
+	values := md.Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
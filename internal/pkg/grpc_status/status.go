@@ -0,0 +1,132 @@
+package grpc_status
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"service-pr-reviewer-assignment/internal/generated/api/dto"
+	"service-pr-reviewer-assignment/internal/pkg/response"
+
+	"github.com/google/uuid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Domain домен google.rpc.ErrorInfo, по которому клиент отличает ошибки сервиса.
+const Domain = "service-pr-reviewer-assignment"
+
+// FromError превращает ошибку в статус gRPC по тому же реестру, что и ответы HTTP API:
+// код ошибки API попадает в ErrorInfo.Reason, поля доменной ошибки — в ErrorInfo.Metadata.
+// Ошибки сервера отдаются как Internal без текста исходной ошибки.
+func FromError(err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+		return status.FromContextError(err).Err()
+	}
+
+	classified, ok := response.Classify(err)
+	if !ok {
+		return Internal()
+	}
+
+	return withInfo(codeFor(classified), classified.Message, classified.Code, classified.Fields)
+}
+
+// Error возвращает статус с кодом ошибки API без доменной ошибки, например для невалидного запроса.
+func Error(code codes.Code, apiCode dto.ErrorResponseErrorCode, message string) error {
+	return withInfo(code, message, apiCode, nil)
+}
+
+// Internal возвращает статус для ошибок сервера.
+func Internal() error {
+	return withInfo(codes.Internal, "internal server error", dto.INTERNALERROR, nil)
+}
+
+func withInfo(code codes.Code, message string, apiCode dto.ErrorResponseErrorCode, fields map[string]any) error {
+	st := status.New(code, message)
+
+	info := &errdetails.ErrorInfo{
+		Reason:   string(apiCode),
+		Domain:   Domain,
+		Metadata: metadata(fields),
+	}
+	withDetails, err := st.WithDetails(info)
+	if err != nil {
+		return st.Err()
+	}
+
+	return withDetails.Err()
+}
+
+// codeFor сопоставляет HTTP-статус ошибки с кодом gRPC. Конфликты уникальности отделены
+// от конфликтов состояния PR, как AlreadyExists и FailedPrecondition. Запрос, который нельзя
+// выполнить по содержанию (422), — InvalidArgument, как и невалидный запрос.
+func codeFor(classified response.Classified) codes.Code {
+	switch classified.Status {
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return codes.InvalidArgument
+	case http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case http.StatusForbidden:
+		return codes.PermissionDenied
+	case http.StatusNotFound:
+		return codes.NotFound
+	case http.StatusConflict:
+		switch classified.Code {
+		case dto.TEAMEXISTS, dto.PREXISTS, dto.ORGEXISTS, dto.USEREXISTS:
+			return codes.AlreadyExists
+		default:
+			return codes.FailedPrecondition
+		}
+	case http.StatusRequestEntityTooLarge, http.StatusTooManyRequests:
+		return codes.ResourceExhausted
+	case http.StatusServiceUnavailable:
+		return codes.Unavailable
+	case http.StatusGatewayTimeout:
+		return codes.DeadlineExceeded
+	default:
+		return codes.Unknown
+	}
+}
+
+// metadata переводит поля доменной ошибки в строки: ErrorInfo.Metadata допускает только их.
+func metadata(fields map[string]any) map[string]string {
+	if len(fields) == 0 {
+		return nil
+	}
+
+	result := make(map[string]string, len(fields))
+	for key, value := range fields {
+		switch v := value.(type) {
+		case []uuid.UUID:
+			ids := make([]string, 0, len(v))
+			for _, id := range v {
+				ids = append(ids, id.String())
+			}
+			result[key] = strings.Join(ids, ",")
+		default:
+			result[key] = fmt.Sprint(v)
+		}
+	}
+
+	return result
+}
+
+// UnaryServerInterceptor превращает ошибки методов в статусы через FromError, поэтому методы
+// возвращают доменные ошибки как есть, как обработчики HTTP через response.HandlerFunc.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		resp, err := handler(ctx, req)
+		if err != nil {
+			return nil, FromError(err)
+		}
+		return resp, nil
+	}
+}
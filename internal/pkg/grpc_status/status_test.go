@@ -0,0 +1,103 @@
+package grpc_status
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"service-pr-reviewer-assignment/internal/generated/api/dto"
+	"service-pr-reviewer-assignment/internal/pkg/response"
+	"service-pr-reviewer-assignment/internal/service/entities"
+
+	"github.com/google/uuid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// errorInfo возвращает ErrorInfo из деталей статуса.
+func errorInfo(t *testing.T, st *status.Status) *errdetails.ErrorInfo {
+	t.Helper()
+
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok {
+			return info
+		}
+	}
+	t.Fatalf("status %v has no ErrorInfo", st)
+	return nil
+}
+
+func TestFromError(t *testing.T) {
+	prID := uuid.MustParse("550e8400-e29b-41d4-a716-446655440001")
+
+	tests := []struct {
+		name       string
+		err        error
+		wantCode   codes.Code
+		wantReason dto.ErrorResponseErrorCode
+	}{
+		{name: "bad request", err: response.BadRequest("team is required"), wantCode: codes.InvalidArgument, wantReason: dto.BADREQUEST},
+		{name: "unprocessable", err: &response.HTTPError{Status: http.StatusUnprocessableEntity, Code: dto.IDEMPOTENCYKEYREUSED, Message: "key reused"}, wantCode: codes.InvalidArgument, wantReason: dto.IDEMPOTENCYKEYREUSED},
+		{name: "unauthenticated", err: entities.ErrInvalidCredentials, wantCode: codes.Unauthenticated, wantReason: dto.UNAUTHORIZED},
+		{name: "not found", err: &entities.ErrTeamNotFound{Name: "payments"}, wantCode: codes.NotFound, wantReason: dto.NOTFOUND},
+		{name: "already exists", err: &entities.ErrTeamAlreadyExists{Name: "payments"}, wantCode: codes.AlreadyExists, wantReason: dto.TEAMEXISTS},
+		{name: "state conflict", err: fmt.Errorf("merge: %w", &entities.ErrPullRequestAlreadyMerged{ID: prID}), wantCode: codes.FailedPrecondition, wantReason: dto.PRMERGED},
+		{name: "payload too large", err: response.PayloadTooLarge(1024), wantCode: codes.ResourceExhausted, wantReason: dto.PAYLOADTOOLARGE},
+		{name: "unavailable", err: &response.HTTPError{Status: http.StatusServiceUnavailable, Code: dto.INTERNALERROR, Message: "shutting down"}, wantCode: codes.Unavailable, wantReason: dto.INTERNALERROR},
+		{name: "timeout", err: &response.HTTPError{Status: http.StatusGatewayTimeout, Code: dto.TIMEOUT, Message: "request timed out"}, wantCode: codes.DeadlineExceeded, wantReason: dto.TIMEOUT},
+		{name: "unclassified", err: errors.New("connection reset"), wantCode: codes.Internal, wantReason: dto.INTERNALERROR},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := status.Convert(FromError(tt.err))
+
+			if st.Code() != tt.wantCode {
+				t.Errorf("code = %s, want %s", st.Code(), tt.wantCode)
+			}
+			info := errorInfo(t, st)
+			if info.GetReason() != string(tt.wantReason) || info.GetDomain() != Domain {
+				t.Errorf("error info = %v, want reason %s", info, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestFromErrorHidesInternalMessage(t *testing.T) {
+	st := status.Convert(FromError(errors.New("dial tcp 10.0.0.1:5432: connection refused")))
+
+	if st.Message() != "internal server error" {
+		t.Errorf("message = %q", st.Message())
+	}
+}
+
+func TestFromErrorKeepsFields(t *testing.T) {
+	ids := []uuid.UUID{
+		uuid.MustParse("550e8400-e29b-41d4-a716-446655440001"),
+		uuid.MustParse("550e8400-e29b-41d4-a716-446655440002"),
+	}
+
+	info := errorInfo(t, status.Convert(FromError(&entities.ErrDuplicateUserIDs{IDs: ids})))
+
+	want := ids[0].String() + "," + ids[1].String()
+	if got := info.GetMetadata()["user_ids"]; got != want {
+		t.Errorf("user_ids = %q, want %q", got, want)
+	}
+}
+
+func TestFromErrorContext(t *testing.T) {
+	if got := status.Code(FromError(context.DeadlineExceeded)); got != codes.DeadlineExceeded {
+		t.Errorf("deadline code = %s", got)
+	}
+	if got := status.Code(FromError(fmt.Errorf("query: %w", context.Canceled))); got != codes.Canceled {
+		t.Errorf("canceled code = %s", got)
+	}
+
+	existing := status.Error(codes.Aborted, "aborted")
+	if got := FromError(existing); got != existing {
+		t.Errorf("status error was rewritten: %v", got)
+	}
+}
@@ -0,0 +1,26 @@
+package http_metrics
+
+import (
+	"net/http"
+	"time"
+
+	"service-pr-reviewer-assignment/internal/pkg/response_writer"
+	"service-pr-reviewer-assignment/internal/pkg/route"
+)
+
+type Metrics interface {
+	ObserveHTTPRequest(route, method string, status int, duration time.Duration)
+}
+
+func Middleware(metrics Metrics) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			rw := response_writer.Wrap(w)
+
+			next.ServeHTTP(rw, r)
+
+			metrics.ObserveHTTPRequest(route.Template(r), r.Method, rw.Status(), time.Since(start))
+		})
+	}
+}
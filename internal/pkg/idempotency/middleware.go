@@ -0,0 +1,166 @@
+package idempotency
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"time"
+
+	"service-pr-reviewer-assignment/internal/generated/api/dto"
+	"service-pr-reviewer-assignment/internal/pkg/identity"
+	"service-pr-reviewer-assignment/internal/pkg/response"
+	"service-pr-reviewer-assignment/internal/pkg/response_writer"
+	"service-pr-reviewer-assignment/internal/pkg/tenant"
+	"service-pr-reviewer-assignment/internal/service/entities"
+)
+
+const (
+	HeaderKey      = "Idempotency-Key"
+	HeaderReplayed = "Idempotent-Replayed"
+
+	keyTTL       = 24 * time.Hour
+	maxKeyLength = 255
+)
+
+type Logger interface {
+	InfoContext(ctx context.Context, msg string)
+	ErrorfContext(ctx context.Context, format string, args ...interface{})
+	LogCtx(ctx context.Context, fields ...any) context.Context
+}
+
+type Storage interface {
+	ReserveIdempotencyKey(ctx context.Context, key entities.IdempotencyKey) (*entities.IdempotencyKey, bool, error)
+	SaveIdempotencyKeyResponse(ctx context.Context, key entities.IdempotencyKey) error
+	DeleteIdempotencyKey(ctx context.Context, key string) error
+}
+
+// Middleware сохраняет ответы на POST-запросы с заголовком Idempotency-Key
+// и воспроизводит их при повторе запроса с тем же ключом и телом.
+func Middleware(logger Logger, storage Storage) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(HeaderKey)
+			if r.Method != http.MethodPost || key == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			ctx := logger.LogCtx(r.Context(), "idempotency_key", key)
+
+			if len(key) > maxKeyLength {
+				response.Error(w, http.StatusBadRequest, dto.BADREQUEST, "idempotency key is too long")
+				return
+			}
+
+			body, err := io.ReadAll(r.Body)
+			if err != nil {
+				logger.ErrorfContext(ctx, "read body failed: %v", err)
+				response.FromError(w, response.DecodeBodyError(err, "read body failed"))
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			// Ключи разных вызывающих и организаций не пересекаются.
+			if caller, ok := identity.FromContext(ctx); ok {
+				key = caller.Subject + ":" + key
+			}
+			if orgID, ok := tenant.FromContext(ctx); ok {
+				key = orgID.String() + ":" + key
+			}
+
+			reserved, ok, err := storage.ReserveIdempotencyKey(ctx, entities.IdempotencyKey{
+				Key:         key,
+				RequestHash: requestHash(r, body),
+				ExpiresAt:   time.Now().Add(keyTTL),
+			})
+			if err != nil {
+				logger.ErrorfContext(ctx, "reserve idempotency key failed: %v", err)
+				response.InternalError(w, err)
+				return
+			}
+
+			if !ok {
+				replay(ctx, logger, w, r, body, reserved)
+				return
+			}
+
+			completed := false
+			defer func() {
+				if completed {
+					return
+				}
+				// Ответ не сохранён (5xx или паника), ключ освобождается для повтора.
+				if err := storage.DeleteIdempotencyKey(context.WithoutCancel(ctx), key); err != nil {
+					logger.ErrorfContext(ctx, "release idempotency key failed: %v", err)
+				}
+			}()
+
+			rw := response_writer.WrapCapturing(w)
+			next.ServeHTTP(rw, r.WithContext(ctx))
+
+			if rw.Status() >= http.StatusInternalServerError {
+				return
+			}
+
+			reserved.StatusCode = rw.Status()
+			reserved.ContentType = rw.Header().Get("Content-Type")
+			reserved.ResponseBody = rw.Body()
+			if err := storage.SaveIdempotencyKeyResponse(context.WithoutCancel(ctx), *reserved); err != nil {
+				logger.ErrorfContext(ctx, "save idempotency key response failed: %v", err)
+				return
+			}
+			completed = true
+		})
+	}
+}
+
+func replay(
+	ctx context.Context,
+	logger Logger,
+	w http.ResponseWriter,
+	r *http.Request,
+	body []byte,
+	stored *entities.IdempotencyKey,
+) {
+	if stored.RequestHash != requestHash(r, body) {
+		logger.ErrorfContext(ctx, "idempotency key reused with a different request")
+		response.Error(w, http.StatusUnprocessableEntity, dto.IDEMPOTENCYKEYREUSED,
+			"idempotency key was used with a different request")
+		return
+	}
+
+	if !stored.IsCompleted() {
+		logger.ErrorfContext(ctx, "request with idempotency key is in progress")
+		response.Error(w, http.StatusConflict, dto.IDEMPOTENCYKEYINPROGRESS,
+			"request with this idempotency key is in progress")
+		return
+	}
+
+	logger.InfoContext(ctx, "replaying stored response")
+	w.Header().Set(HeaderReplayed, "true")
+
+	// Ошибка записывается заново: формат ошибки зависит от Accept текущего запроса.
+	if stored.StatusCode >= http.StatusBadRequest &&
+		response.ReplayError(w, stored.StatusCode, stored.ContentType, stored.ResponseBody) {
+		return
+	}
+
+	if stored.ContentType != "" {
+		w.Header().Set("Content-Type", stored.ContentType)
+	}
+	w.WriteHeader(stored.StatusCode)
+	_, _ = w.Write(stored.ResponseBody)
+}
+
+func requestHash(r *http.Request, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(r.Method))
+	h.Write([]byte{'\n'})
+	h.Write([]byte(r.URL.Path))
+	h.Write([]byte{'\n'})
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,280 @@
+package idempotency
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"service-pr-reviewer-assignment/internal/generated/api/dto"
+	"service-pr-reviewer-assignment/internal/pkg/response"
+	"service-pr-reviewer-assignment/internal/service/entities"
+)
+
+type noopLogger struct{}
+
+func (noopLogger) InfoContext(context.Context, string) {}
+
+func (noopLogger) ErrorfContext(context.Context, string, ...interface{}) {}
+
+func (noopLogger) LogCtx(ctx context.Context, _ ...any) context.Context { return ctx }
+
+// memoryStorage повторяет семантику storage: занятый ключ перехватывается только после ExpiresAt.
+type memoryStorage struct {
+	mu   sync.Mutex
+	keys map[string]entities.IdempotencyKey
+}
+
+func newMemoryStorage() *memoryStorage {
+	return &memoryStorage{keys: make(map[string]entities.IdempotencyKey)}
+}
+
+func (s *memoryStorage) ReserveIdempotencyKey(
+	_ context.Context,
+	key entities.IdempotencyKey,
+) (*entities.IdempotencyKey, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if existing, ok := s.keys[key.Key]; ok && existing.ExpiresAt.After(time.Now()) {
+		return &existing, false, nil
+	}
+	s.keys[key.Key] = key
+	return &key, true, nil
+}
+
+func (s *memoryStorage) SaveIdempotencyKeyResponse(_ context.Context, key entities.IdempotencyKey) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.keys[key.Key] = key
+	return nil
+}
+
+func (s *memoryStorage) DeleteIdempotencyKey(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.keys, key)
+	return nil
+}
+
+func (s *memoryStorage) get(key string) (entities.IdempotencyKey, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	k, ok := s.keys[key]
+	return k, ok
+}
+
+// countingHandler отвечает 201 с номером вызова, чтобы отличать повтор от нового выполнения.
+type countingHandler struct {
+	mu     sync.Mutex
+	calls  int
+	status int
+}
+
+func (h *countingHandler) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	h.mu.Lock()
+	h.calls++
+	calls := h.calls
+	h.mu.Unlock()
+
+	status := h.status
+	if status == 0 {
+		status = http.StatusCreated
+	}
+	if status >= http.StatusBadRequest {
+		response.Error(w, status, dto.INTERNALERROR, "internal server error")
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]int{"call": calls})
+}
+
+func post(handler http.Handler, key, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/teams", strings.NewReader(body))
+	req.Header.Set(HeaderKey, key)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestMiddlewareReplaysStoredResponse(t *testing.T) {
+	next := &countingHandler{}
+	handler := Middleware(noopLogger{}, newMemoryStorage())(next)
+
+	first := post(handler, "key-1", `{"team_name":"backend"}`)
+	second := post(handler, "key-1", `{"team_name":"backend"}`)
+
+	if next.calls != 1 {
+		t.Errorf("handler calls = %d, want 1", next.calls)
+	}
+	if second.Code != first.Code || second.Code != http.StatusCreated {
+		t.Errorf("replayed status = %d, want %d", second.Code, first.Code)
+	}
+	if second.Body.String() != first.Body.String() {
+		t.Errorf("replayed body = %q, want %q", second.Body.String(), first.Body.String())
+	}
+	if got := second.Header().Get(HeaderReplayed); got != "true" {
+		t.Errorf("%s = %q, want true", HeaderReplayed, got)
+	}
+	if got := first.Header().Get(HeaderReplayed); got != "" {
+		t.Errorf("first response %s = %q, want empty", HeaderReplayed, got)
+	}
+}
+
+func TestMiddlewareRejectsReusedKey(t *testing.T) {
+	next := &countingHandler{}
+	handler := Middleware(noopLogger{}, newMemoryStorage())(next)
+
+	post(handler, "key-1", `{"team_name":"backend"}`)
+	rec := post(handler, "key-1", `{"team_name":"frontend"}`)
+
+	if rec.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("status = %d, want 422", rec.Code)
+	}
+	assertErrorCode(t, rec, dto.IDEMPOTENCYKEYREUSED)
+	if next.calls != 1 {
+		t.Errorf("handler calls = %d, want 1", next.calls)
+	}
+}
+
+func TestMiddlewareRejectsConcurrentRequest(t *testing.T) {
+	entered := make(chan struct{})
+	release := make(chan struct{})
+	slow := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		close(entered)
+		<-release
+		w.WriteHeader(http.StatusCreated)
+	})
+	handler := Middleware(noopLogger{}, newMemoryStorage())(slow)
+
+	done := make(chan *httptest.ResponseRecorder)
+	go func() { done <- post(handler, "key-1", `{}`) }()
+	<-entered
+
+	rec := post(handler, "key-1", `{}`)
+	close(release)
+
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("concurrent status = %d, want 409", rec.Code)
+	}
+	assertErrorCode(t, rec, dto.IDEMPOTENCYKEYINPROGRESS)
+	if first := <-done; first.Code != http.StatusCreated {
+		t.Errorf("first status = %d, want 201", first.Code)
+	}
+}
+
+func TestMiddlewareReleasesKeyAfterServerError(t *testing.T) {
+	storage := newMemoryStorage()
+	next := &countingHandler{status: http.StatusInternalServerError}
+	handler := Middleware(noopLogger{}, storage)(next)
+
+	if rec := post(handler, "key-1", `{}`); rec.Code != http.StatusInternalServerError {
+		t.Fatalf("first status = %d, want 500", rec.Code)
+	}
+	if _, ok := storage.get("key-1"); ok {
+		t.Fatal("key is kept after a 5xx response")
+	}
+
+	next.status = 0
+	if rec := post(handler, "key-1", `{}`); rec.Code != http.StatusCreated {
+		t.Errorf("retry status = %d, want 201", rec.Code)
+	}
+	if next.calls != 2 {
+		t.Errorf("handler calls = %d, want 2", next.calls)
+	}
+}
+
+func TestMiddlewareTakesOverExpiredKey(t *testing.T) {
+	storage := newMemoryStorage()
+	storage.keys["key-1"] = entities.IdempotencyKey{
+		Key:          "key-1",
+		RequestHash:  "stale",
+		StatusCode:   http.StatusCreated,
+		ContentType:  "application/json",
+		ResponseBody: []byte(`{"call":0}`),
+		ExpiresAt:    time.Now().Add(-time.Minute),
+	}
+	next := &countingHandler{}
+	handler := Middleware(noopLogger{}, storage)(next)
+
+	rec := post(handler, "key-1", `{}`)
+
+	if rec.Code != http.StatusCreated || next.calls != 1 {
+		t.Fatalf("status = %d, handler calls = %d, want a fresh 201", rec.Code, next.calls)
+	}
+	if rec.Header().Get(HeaderReplayed) != "" {
+		t.Error("expired key was replayed")
+	}
+	stored, _ := storage.get("key-1")
+	if !stored.ExpiresAt.After(time.Now()) || stored.RequestHash == "stale" {
+		t.Errorf("stored key = %+v, want a new reservation", stored)
+	}
+}
+
+func TestMiddlewareReplaysErrorInRequestedFormat(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		response.Error(w, http.StatusConflict, dto.TEAMEXISTS, "team already exists")
+	})
+	handler := Middleware(noopLogger{}, newMemoryStorage())(next)
+
+	first := post(handler, "key-1", `{}`)
+	if got := first.Header().Get("Content-Type"); got != "application/json" {
+		t.Fatalf("first Content-Type = %q", got)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/teams", strings.NewReader(`{}`))
+	req.Header.Set(HeaderKey, "key-1")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(response.WithProblemDetails(rec), req)
+
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("status = %d, want 409", rec.Code)
+	}
+	if got := rec.Header().Get("Content-Type"); got != response.ProblemContentType {
+		t.Errorf("replayed Content-Type = %q, want %q", got, response.ProblemContentType)
+	}
+	var problem dto.Problem
+	if err := json.NewDecoder(rec.Body).Decode(&problem); err != nil {
+		t.Fatal(err)
+	}
+	if problem.Code != string(dto.TEAMEXISTS) || problem.Detail == nil || *problem.Detail != "team already exists" {
+		t.Errorf("problem = %+v", problem)
+	}
+}
+
+func TestMiddlewareIgnoresRequestsWithoutKey(t *testing.T) {
+	next := &countingHandler{}
+	handler := Middleware(noopLogger{}, newMemoryStorage())(next)
+
+	for range 2 {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/teams", strings.NewReader(`{}`))
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	if next.calls != 2 {
+		t.Errorf("handler calls = %d, want 2", next.calls)
+	}
+}
+
+func assertErrorCode(t *testing.T, rec *httptest.ResponseRecorder, want dto.ErrorResponseErrorCode) {
+	t.Helper()
+
+	body, _ := io.ReadAll(rec.Body)
+	var resp dto.ErrorResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		t.Fatalf("decode %q: %v", body, err)
+	}
+	if resp.Error.Code != want {
+		t.Errorf("code = %s, want %s", resp.Error.Code, want)
+	}
+}
@@ -0,0 +1,26 @@
+package identity
+
+import (
+	"context"
+
+	"service-pr-reviewer-assignment/internal/service/entities"
+)
+
+type ctxKey struct{}
+
+// Anonymous идентичность для режима с выключенной аутентификацией.
+var Anonymous = &entities.Identity{
+	Subject: "anonymous",
+	Scopes:  []entities.Scope{entities.ScopeAdmin},
+}
+
+// WithIdentity сохраняет аутентифицированного вызывающего в контексте.
+func WithIdentity(ctx context.Context, identity *entities.Identity) context.Context {
+	return context.WithValue(ctx, ctxKey{}, identity)
+}
+
+// FromContext возвращает вызывающего, сохранённого в контексте.
+func FromContext(ctx context.Context) (*entities.Identity, bool) {
+	identity, ok := ctx.Value(ctxKey{}).(*entities.Identity)
+	return identity, ok
+}
@@ -0,0 +1,52 @@
+package openapi_validation
+
+import (
+	"strings"
+
+	"service-pr-reviewer-assignment/internal/generated/api/dto"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+)
+
+// details раскладывает ошибку kin-openapi на отдельные нарушения с указанием места.
+func details(err error) []dto.ErrorDetail {
+	var out []dto.ErrorDetail
+	collectDetails(&out, "", err)
+	return out
+}
+
+// collectDetails обходит дерево ошибок по конкретным типам, а не через errors.As:
+// MultiError.As находит только первую подходящую ошибку, а RequestError
+// разворачивается во вложенный MultiError и теряет место нарушения.
+func collectDetails(out *[]dto.ErrorDetail, location string, err error) {
+	switch e := err.(type) {
+	case openapi3.MultiError:
+		for _, inner := range e {
+			collectDetails(out, location, inner)
+		}
+	case *openapi3filter.RequestError:
+		location := "body"
+		if e.Parameter != nil {
+			location = e.Parameter.In + "." + e.Parameter.Name
+		}
+		if e.Err == nil {
+			*out = append(*out, dto.ErrorDetail{Location: location, Message: e.Reason})
+			return
+		}
+		collectDetails(out, location, e.Err)
+	case *openapi3filter.ResponseError:
+		if e.Err == nil {
+			*out = append(*out, dto.ErrorDetail{Location: "response", Message: e.Reason})
+			return
+		}
+		collectDetails(out, "response", e.Err)
+	case *openapi3.SchemaError:
+		if pointer := e.JSONPointer(); len(pointer) > 0 {
+			location = location + "." + strings.Join(pointer, ".")
+		}
+		*out = append(*out, dto.ErrorDetail{Location: location, Message: e.Reason})
+	default:
+		*out = append(*out, dto.ErrorDetail{Location: location, Message: err.Error()})
+	}
+}
@@ -0,0 +1,146 @@
+package openapi_validation
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+
+	"service-pr-reviewer-assignment/internal/generated/api/dto"
+	"service-pr-reviewer-assignment/internal/pkg/response"
+
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+)
+
+const (
+	headerContentType = "Content-Type"
+
+	eventStreamContentType = "text/event-stream"
+)
+
+type Logger interface {
+	WarnfContext(ctx context.Context, format string, args ...interface{})
+	ErrorfContext(ctx context.Context, format string, args ...interface{})
+}
+
+// Middleware проверяет запросы по спецификации и отвечает BAD_REQUEST с перечнем
+// нарушений. При validateResponses ответ буферизуется и тоже проверяется: ответ,
+// не соответствующий спецификации, заменяется на INTERNAL_ERROR. Проверка ответов
+// нужна в тестах, в рабочем режиме её стоит выключать.
+func Middleware(logger Logger, validator *Validator, validateResponses bool) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+
+			route, pathParams, err := validator.router.FindRoute(r)
+			if err != nil {
+				// Маршрут есть в роутере, но не описан в спецификации.
+				logger.WarnfContext(ctx, "route is not described in openapi spec: %v", err)
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			// API принимает только JSON, поэтому тело без Content-Type считаем JSON.
+			if r.ContentLength != 0 && r.Header.Get(headerContentType) == "" {
+				r.Header.Set(headerContentType, "application/json")
+			}
+
+			input := &openapi3filter.RequestValidationInput{
+				Request:    r,
+				PathParams: pathParams,
+				Route:      route,
+				Options:    validator.options,
+			}
+			if err := openapi3filter.ValidateRequest(ctx, input); err != nil {
+				logger.ErrorfContext(ctx, "request validation failed: %v", err)
+
+				var tooLarge *http.MaxBytesError
+				if errors.As(err, &tooLarge) {
+					response.FromError(w, response.PayloadTooLarge(tooLarge.Limit))
+					return
+				}
+
+				response.ErrorWithDetails(w, http.StatusBadRequest, dto.BADREQUEST,
+					"request does not match the api specification", details(err))
+				return
+			}
+
+			// Поток событий не буферизуется: он не заканчивается, пока клиент подключён.
+			if !validateResponses || streams(route) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			buffered := &bufferedWriter{ResponseWriter: w}
+			next.ServeHTTP(buffered, r)
+
+			// Ошибки application/problem+json формирует пакет response по единой схеме Problem,
+			// в спецификации операций они перечислены только для общих ответов.
+			if w.Header().Get(headerContentType) == response.ProblemContentType {
+				buffered.flush()
+				return
+			}
+
+			err = openapi3filter.ValidateResponse(ctx, &openapi3filter.ResponseValidationInput{
+				RequestValidationInput: input,
+				Status:                 buffered.Status(),
+				Header:                 w.Header(),
+				Body:                   io.NopCloser(bytes.NewReader(buffered.body.Bytes())),
+				Options:                validator.options,
+			})
+			if err != nil {
+				logger.ErrorfContext(ctx, "response validation failed: %v", err)
+				response.ErrorWithDetails(w, http.StatusInternalServerError, dto.INTERNALERROR,
+					"response does not match the api specification", details(err))
+				return
+			}
+
+			buffered.flush()
+		})
+	}
+}
+
+// streams сообщает, отвечает ли операция потоком text/event-stream.
+func streams(route *routers.Route) bool {
+	ok := route.Operation.Responses.Status(http.StatusOK)
+	return ok != nil && ok.Value != nil && ok.Value.Content.Get(eventStreamContentType) != nil
+}
+
+// bufferedWriter откладывает отправку ответа до его проверки. Заголовки пишутся
+// сразу в исходный writer, статус и тело — только в flush.
+type bufferedWriter struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (w *bufferedWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+}
+
+func (w *bufferedWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	return w.body.Write(b)
+}
+
+func (w *bufferedWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+func (w *bufferedWriter) Status() int {
+	if w.status == 0 {
+		return http.StatusOK
+	}
+	return w.status
+}
+
+func (w *bufferedWriter) flush() {
+	w.ResponseWriter.WriteHeader(w.Status())
+	_, _ = w.ResponseWriter.Write(w.body.Bytes())
+}
@@ -0,0 +1,252 @@
+package openapi_validation
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"service-pr-reviewer-assignment/internal/generated/api/dto"
+	"service-pr-reviewer-assignment/internal/pkg/response"
+)
+
+const testSpec = `
+openapi: 3.0.3
+info:
+  title: test
+  version: 1.0.0
+paths:
+  /team/add:
+    post:
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [team_name]
+              properties:
+                team_name:
+                  type: string
+                  minLength: 1
+      responses:
+        "201":
+          description: created
+          content:
+            application/json:
+              schema:
+                type: object
+                required: [team_name]
+                properties:
+                  team_name:
+                    type: string
+  /users/getReview:
+    get:
+      parameters:
+        - name: user_id
+          in: query
+          required: true
+          schema:
+            type: string
+            format: uuid
+      responses:
+        "200":
+          description: ok
+  /events:
+    get:
+      responses:
+        "200":
+          description: stream
+          content:
+            text/event-stream:
+              schema:
+                type: string
+`
+
+type noopLogger struct{}
+
+func (noopLogger) WarnfContext(context.Context, string, ...interface{}) {}
+
+func (noopLogger) ErrorfContext(context.Context, string, ...interface{}) {}
+
+// jsonHandler отвечает 201 с заданным телом.
+func jsonHandler(body string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(body))
+	})
+}
+
+func serve(t *testing.T, handler http.Handler, validateResponses bool, req *http.Request) *httptest.ResponseRecorder {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	Middleware(noopLogger{}, Must([]byte(testSpec)), validateResponses)(handler).ServeHTTP(rec, req)
+	return rec
+}
+
+func decodeError(t *testing.T, rec *httptest.ResponseRecorder) dto.ErrorResponse {
+	t.Helper()
+
+	var resp dto.ErrorResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatal(err)
+	}
+	return resp
+}
+
+func hasDetail(resp dto.ErrorResponse, location string) bool {
+	if resp.Error.Details == nil {
+		return false
+	}
+	for _, detail := range *resp.Error.Details {
+		if detail.Location == location {
+			return true
+		}
+	}
+	return false
+}
+
+func TestMiddlewareAcceptsValidRequest(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/team/add", strings.NewReader(`{"team_name":"payments"}`))
+	rec := serve(t, jsonHandler(`{"team_name":"payments"}`), true, req)
+
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("status = %d, want 201: %s", rec.Code, rec.Body.String())
+	}
+	if rec.Body.String() != `{"team_name":"payments"}` {
+		t.Errorf("body = %q", rec.Body.String())
+	}
+}
+
+func TestMiddlewareRejectsInvalidRequest(t *testing.T) {
+	tests := []struct {
+		name         string
+		req          *http.Request
+		wantLocation string
+	}{
+		{
+			name:         "missing body field",
+			req:          httptest.NewRequest(http.MethodPost, "/team/add", strings.NewReader(`{}`)),
+			wantLocation: "body.team_name",
+		},
+		{
+			name:         "empty body field",
+			req:          httptest.NewRequest(http.MethodPost, "/team/add", strings.NewReader(`{"team_name":""}`)),
+			wantLocation: "body.team_name",
+		},
+		{
+			name:         "invalid uuid",
+			req:          httptest.NewRequest(http.MethodGet, "/users/getReview?user_id=u1", nil),
+			wantLocation: "query.user_id",
+		},
+		{
+			name:         "missing parameter",
+			req:          httptest.NewRequest(http.MethodGet, "/users/getReview", nil),
+			wantLocation: "query.user_id",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called := false
+			next := http.HandlerFunc(func(http.ResponseWriter, *http.Request) { called = true })
+
+			rec := serve(t, next, false, tt.req)
+
+			if rec.Code != http.StatusBadRequest {
+				t.Fatalf("status = %d, want 400", rec.Code)
+			}
+			if called {
+				t.Error("handler was called for an invalid request")
+			}
+			resp := decodeError(t, rec)
+			if resp.Error.Code != dto.BADREQUEST {
+				t.Errorf("code = %s, want BAD_REQUEST", resp.Error.Code)
+			}
+			if !hasDetail(resp, tt.wantLocation) {
+				t.Errorf("details = %+v, want location %q", resp.Error.Details, tt.wantLocation)
+			}
+		})
+	}
+}
+
+func TestMiddlewareRejectsOversizedBody(t *testing.T) {
+	next := http.HandlerFunc(func(http.ResponseWriter, *http.Request) { t.Error("handler was called") })
+	req := httptest.NewRequest(http.MethodPost, "/team/add", strings.NewReader(`{"team_name":"payments"}`))
+	req.Body = http.MaxBytesReader(httptest.NewRecorder(), req.Body, 8)
+
+	rec := serve(t, next, false, req)
+
+	if rec.Code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("status = %d, want 413", rec.Code)
+	}
+	if resp := decodeError(t, rec); resp.Error.Code != dto.PAYLOADTOOLARGE {
+		t.Errorf("code = %s, want PAYLOAD_TOO_LARGE", resp.Error.Code)
+	}
+}
+
+func TestMiddlewareValidatesResponses(t *testing.T) {
+	req := func() *http.Request {
+		return httptest.NewRequest(http.MethodPost, "/team/add", strings.NewReader(`{"team_name":"payments"}`))
+	}
+	handler := jsonHandler(`{"name":"payments"}`)
+
+	rec := serve(t, handler, true, req())
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("status = %d, want 500", rec.Code)
+	}
+	if resp := decodeError(t, rec); resp.Error.Code != dto.INTERNALERROR || !hasDetail(resp, "response.team_name") {
+		t.Errorf("error = %+v", resp.Error)
+	}
+
+	if rec := serve(t, handler, false, req()); rec.Code != http.StatusCreated {
+		t.Errorf("status without response validation = %d, want 201", rec.Code)
+	}
+}
+
+func TestMiddlewareSkipsProblemResponses(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		response.Error(w, http.StatusConflict, dto.TEAMEXISTS, "team already exists")
+	})
+	req := httptest.NewRequest(http.MethodPost, "/team/add", strings.NewReader(`{"team_name":"payments"}`))
+
+	rec := httptest.NewRecorder()
+	Middleware(noopLogger{}, Must([]byte(testSpec)), true)(next).ServeHTTP(response.WithProblemDetails(rec), req)
+
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("status = %d, want 409: %s", rec.Code, rec.Body.String())
+	}
+	if got := rec.Header().Get("Content-Type"); got != response.ProblemContentType {
+		t.Errorf("Content-Type = %q, want %q", got, response.ProblemContentType)
+	}
+}
+
+func TestMiddlewareStreamsEventsWithoutBuffering(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if _, ok := w.(*bufferedWriter); ok {
+			t.Error("event stream is buffered")
+		}
+		w.Header().Set("Content-Type", eventStreamContentType)
+		_, _ = w.Write([]byte("data: {}\n\n"))
+	})
+
+	rec := serve(t, next, true, httptest.NewRequest(http.MethodGet, "/events", nil))
+
+	if rec.Code != http.StatusOK || rec.Body.String() != "data: {}\n\n" {
+		t.Errorf("status = %d, body = %q", rec.Code, rec.Body.String())
+	}
+}
+
+func TestMiddlewarePassesUndescribedRoutes(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusTeapot) })
+
+	rec := serve(t, next, true, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	if rec.Code != http.StatusTeapot {
+		t.Errorf("status = %d, want the handler's 418", rec.Code)
+	}
+}
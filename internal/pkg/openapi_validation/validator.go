@@ -0,0 +1,54 @@
+package openapi_validation
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+	"github.com/google/uuid"
+)
+
+// Validator сопоставляет запросы с операциями спецификации.
+type Validator struct {
+	router  routers.Router
+	options *openapi3filter.Options
+}
+
+// Must загружает спецификацию. Спецификация встроена в бинарник, поэтому ошибка
+// загрузки — ошибка сборки, и Must паникует.
+func Must(spec []byte) *Validator {
+	// По умолчанию kin-openapi не проверяет формат uuid.
+	openapi3.DefineStringFormatCallback("uuid", func(value string) error {
+		_, err := uuid.Parse(value)
+		return err
+	})
+
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromData(spec)
+	if err != nil {
+		panic(fmt.Sprintf("load openapi spec: %v", err))
+	}
+	if err := doc.Validate(context.Background()); err != nil {
+		panic(fmt.Sprintf("validate openapi spec: %v", err))
+	}
+
+	// Без servers маршруты сопоставляются только по пути, независимо от хоста запроса.
+	doc.Servers = nil
+
+	router, err := gorillamux.NewRouter(doc)
+	if err != nil {
+		panic(fmt.Sprintf("create openapi router: %v", err))
+	}
+
+	return &Validator{
+		router: router,
+		options: &openapi3filter.Options{
+			MultiError: true,
+			// Аутентификацию уже выполнил middleware authentication.
+			AuthenticationFunc: openapi3filter.NoopAuthenticationFunc,
+		},
+	}
+}
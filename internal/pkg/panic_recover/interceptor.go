@@ -0,0 +1,29 @@
+package panic_recover
+
+import (
+	"context"
+	"runtime/debug"
+
+	"service-pr-reviewer-assignment/internal/pkg/grpc_status"
+
+	"google.golang.org/grpc"
+)
+
+func UnaryServerInterceptor(logger Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				logger.ErrorfContext(
+					ctx,
+					"panic recovered: %v\nstack trace: %s",
+					recovered,
+					string(debug.Stack()),
+				)
+
+				resp, err = nil, grpc_status.Internal()
+			}
+		}()
+
+		return handler(ctx, req)
+	}
+}
@@ -0,0 +1,23 @@
+package problem_details
+
+import (
+	"net/http"
+
+	"service-pr-reviewer-assignment/internal/pkg/response"
+)
+
+// Middleware выбирает формат ошибок по заголовку Accept: клиенту, явно запросившему
+// application/problem+json, ошибки отдаются в формате RFC 7807, остальным — ErrorResponse.
+func Middleware() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Add("Vary", "Accept")
+
+			if response.AcceptsProblem(r.Header.Get("Accept")) {
+				w = response.WithProblemDetails(w)
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package rate_limit
+
+import (
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+const (
+	// idleTTL время, после которого неактивный клиент забывается.
+	idleTTL = 10 * time.Minute
+
+	sweepInterval = time.Minute
+)
+
+// Limit параметры token bucket: RPS токенов в секунду, ёмкость Burst.
+type Limit struct {
+	RPS   float64
+	Burst int
+}
+
+type bucket struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// Limiter хранит token bucket на каждую пару маршрут-клиент и на каждый IP.
+type Limiter struct {
+	defaultLimit Limit
+	routes       map[string]Limit
+	ipLimit      Limit
+
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+// Must создаёт лимитер с лимитом по умолчанию, переопределениями для шаблонов маршрутов
+// и общим для всех маршрутов лимитом IP.
+func Must(defaultLimit Limit, routes map[string]Limit, ipLimit Limit) *Limiter {
+	return &Limiter{
+		defaultLimit: defaultLimit,
+		routes:       routes,
+		ipLimit:      ipLimit,
+		buckets:      make(map[string]*bucket),
+		lastSweep:    time.Now(),
+	}
+}
+
+// Allow забирает токен клиента на маршруте. Если токенов нет, возвращает false
+// и время, через которое появится следующий.
+func (l *Limiter) Allow(route, client string) (bool, time.Duration) {
+	return l.take(route+" "+client, l.limitFor(route))
+}
+
+// AllowIP забирает токен IP из лимита, общего для всех маршрутов.
+func (l *Limiter) AllowIP(ip string) (bool, time.Duration) {
+	return l.take("ip "+ip, l.ipLimit)
+}
+
+// Clients возвращает число отслеживаемых пар маршрут-клиент и IP.
+func (l *Limiter) Clients() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return len(l.buckets)
+}
+
+// take забирает токен из bucket-а key, создавая его с лимитом limit.
+func (l *Limiter) take(key string, limit Limit) (bool, time.Duration) {
+	now := time.Now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.sweep(now)
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{limiter: rate.NewLimiter(rate.Limit(limit.RPS), limit.Burst)}
+		l.buckets[key] = b
+	}
+	b.lastSeen = now
+
+	reservation := b.limiter.ReserveN(now, 1)
+	delay := reservation.DelayFrom(now)
+	if delay == 0 {
+		return true, 0
+	}
+
+	reservation.CancelAt(now)
+	return false, delay
+}
+
+func (l *Limiter) limitFor(route string) Limit {
+	if limit, ok := l.routes[route]; ok {
+		return limit
+	}
+	return l.defaultLimit
+}
+
+// sweep удаляет давно неактивных клиентов, чтобы память не росла с числом IP.
+func (l *Limiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < sweepInterval {
+		return
+	}
+	l.lastSweep = now
+
+	for key, b := range l.buckets {
+		if now.Sub(b.lastSeen) > idleTTL {
+			delete(l.buckets, key)
+		}
+	}
+}
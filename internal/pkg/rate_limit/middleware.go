@@ -0,0 +1,107 @@
+package rate_limit
+
+import (
+	"context"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"service-pr-reviewer-assignment/internal/generated/api/dto"
+	"service-pr-reviewer-assignment/internal/pkg/identity"
+	"service-pr-reviewer-assignment/internal/pkg/response"
+	"service-pr-reviewer-assignment/internal/pkg/route"
+)
+
+const (
+	headerForwardedFor = "X-Forwarded-For"
+	headerRetryAfter   = "Retry-After"
+)
+
+type Logger interface {
+	ErrorfContext(ctx context.Context, format string, args ...interface{})
+	LogCtx(ctx context.Context, fields ...any) context.Context
+}
+
+type Metrics interface {
+	RateLimited(route string)
+}
+
+// IPMiddleware ограничивает частоту запросов с одного IP ко всем маршрутам. Стоит до
+// аутентификации, чтобы поток запросов с неверными учётными данными тоже ограничивался
+// и не нагружал базу. Непроверенные заголовки с учётными данными не учитываются:
+// иначе клиент обходил бы лимит, меняя их в каждом запросе.
+func IPMiddleware(logger Logger, limiter *Limiter, metrics Metrics, trustProxy bool) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			allowed, retryAfter := limiter.AllowIP(clientIP(r, trustProxy))
+			if allowed {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			reject(w, r, logger, metrics, retryAfter)
+		})
+	}
+}
+
+// Middleware ограничивает частоту запросов клиента к маршруту. Клиент определяется по
+// вызывающему, которого сохранила аутентификация, поэтому стоит после неё. Без
+// аутентификации клиентом считается IP.
+func Middleware(logger Logger, limiter *Limiter, metrics Metrics, trustProxy bool) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			allowed, retryAfter := limiter.Allow(route.Template(r), clientKey(r, trustProxy))
+			if allowed {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			reject(w, r, logger, metrics, retryAfter)
+		})
+	}
+}
+
+func reject(w http.ResponseWriter, r *http.Request, logger Logger, metrics Metrics, retryAfter time.Duration) {
+	metrics.RateLimited(route.Template(r))
+
+	ctx := logger.LogCtx(r.Context(), "retry_after", retryAfter.String())
+	logger.ErrorfContext(ctx, "rate limit exceeded")
+
+	seconds := int(math.Ceil(retryAfter.Seconds()))
+	w.Header().Set(headerRetryAfter, strconv.Itoa(max(seconds, 1)))
+	response.Error(w, http.StatusTooManyRequests, dto.RATELIMITED, "rate limit exceeded")
+}
+
+// clientKey возвращает ключ аутентифицированного вызывающего, а для анонимных запросов — IP.
+func clientKey(r *http.Request, trustProxy bool) string {
+	caller, ok := identity.FromContext(r.Context())
+	if !ok || caller == identity.Anonymous {
+		return "ip:" + clientIP(r, trustProxy)
+	}
+	return "caller:" + caller.Subject
+}
+
+// clientIP возвращает IP клиента. За доверенным прокси берётся последний адрес
+// X-Forwarded-For: его добавил сам прокси, а более ранние клиент может подставить.
+func clientIP(r *http.Request, trustProxy bool) string {
+	if trustProxy {
+		if forwarded := r.Header.Values(headerForwardedFor); len(forwarded) > 0 {
+			last := forwarded[len(forwarded)-1]
+			if i := strings.LastIndex(last, ","); i >= 0 {
+				last = last[i+1:]
+			}
+			if ip := strings.TrimSpace(last); ip != "" {
+				return ip
+			}
+		}
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
@@ -0,0 +1,102 @@
+package rate_limit
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"service-pr-reviewer-assignment/internal/pkg/identity"
+	"service-pr-reviewer-assignment/internal/service/entities"
+)
+
+type noopLogger struct{}
+
+func (noopLogger) ErrorfContext(context.Context, string, ...interface{}) {}
+
+func (noopLogger) LogCtx(ctx context.Context, _ ...any) context.Context { return ctx }
+
+type noopMetrics struct{}
+
+func (noopMetrics) RateLimited(string) {}
+
+var ok = http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusOK) })
+
+func TestIPMiddlewareIgnoresUnverifiedCredentials(t *testing.T) {
+	limiter := Must(Limit{RPS: 100, Burst: 100}, nil, Limit{RPS: 1, Burst: 2})
+	handler := IPMiddleware(noopLogger{}, limiter, noopMetrics{}, false)(ok)
+
+	var codes []int
+	for i := range 3 {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/teams", nil)
+		req.RemoteAddr = "203.0.113.7:5000"
+		req.Header.Set("X-API-Key", "prk_guess_"+strconv.Itoa(i))
+		req.Header.Set("Authorization", "Bearer token-"+strconv.Itoa(i))
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		codes = append(codes, rec.Code)
+	}
+
+	if codes[2] != http.StatusTooManyRequests {
+		t.Errorf("codes = %v, want the third request limited despite new credentials", codes)
+	}
+	if limiter.Clients() != 1 {
+		t.Errorf("tracked clients = %d, want 1", limiter.Clients())
+	}
+}
+
+func TestMiddlewareKeysOnVerifiedCaller(t *testing.T) {
+	limiter := Must(Limit{RPS: 1, Burst: 1}, nil, Limit{RPS: 100, Burst: 100})
+	handler := Middleware(noopLogger{}, limiter, noopMetrics{}, false)(ok)
+
+	serve := func(subject string) int {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/teams", nil)
+		req.RemoteAddr = "203.0.113.7:5000"
+		req = req.WithContext(identity.WithIdentity(req.Context(), &entities.Identity{Subject: subject}))
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	if code := serve("api_key:a"); code != http.StatusOK {
+		t.Fatalf("first request of a: %d", code)
+	}
+	if code := serve("api_key:b"); code != http.StatusOK {
+		t.Errorf("caller b behind the same IP: %d, want 200", code)
+	}
+	if code := serve("api_key:a"); code != http.StatusTooManyRequests {
+		t.Errorf("second request of a: %d, want 429", code)
+	}
+}
+
+func TestClientIP(t *testing.T) {
+	tests := []struct {
+		name       string
+		forwarded  []string
+		trustProxy bool
+		want       string
+	}{
+		{name: "remote address", want: "10.0.0.1"},
+		{name: "forwarded ignored without trusted proxy", forwarded: []string{"198.51.100.1"}, want: "10.0.0.1"},
+		{name: "rightmost entry", forwarded: []string{"1.2.3.4, 198.51.100.1"}, trustProxy: true, want: "198.51.100.1"},
+		{name: "last header", forwarded: []string{"1.2.3.4", "198.51.100.1"}, trustProxy: true, want: "198.51.100.1"},
+		{name: "empty entry", forwarded: []string{"1.2.3.4, "}, trustProxy: true, want: "10.0.0.1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.RemoteAddr = "10.0.0.1:5000"
+			for _, value := range tt.forwarded {
+				req.Header.Add(headerForwardedFor, value)
+			}
+
+			if got := clientIP(req, tt.trustProxy); got != tt.want {
+				t.Errorf("clientIP = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package request_id
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+// Header заголовок, в котором клиент передаёт идентификатор запроса и в котором он возвращается в ответе.
+const Header = "X-Request-ID"
+
+// maxLength ограничивает длину идентификатора клиента, чтобы он не раздувал логи.
+const maxLength = 128
+
+type ctxKey struct{}
+
+// WithRequestID сохраняет идентификатор запроса в контексте.
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, ctxKey{}, requestID)
+}
+
+// FromContext возвращает идентификатор запроса, сохранённый в контексте.
+func FromContext(ctx context.Context) (string, bool) {
+	requestID, ok := ctx.Value(ctxKey{}).(string)
+	return requestID, ok
+}
+
+// Resolve берёт идентификатор из заголовка запроса, а если его нет или он некорректен, генерирует новый.
+func Resolve(r *http.Request) string {
+	return FromValue(r.Header.Get(Header))
+}
+
+// FromValue возвращает идентификатор клиента, если он корректен, иначе новый.
+// Используется там, где идентификатор приходит не в заголовке HTTP, например в метаданных gRPC.
+func FromValue(requestID string) string {
+	if isValid(requestID) {
+		return requestID
+	}
+	return uuid.NewString()
+}
+
+// isValid допускает только печатные ASCII-символы без пробелов.
+func isValid(requestID string) bool {
+	if requestID == "" || len(requestID) > maxLength {
+		return false
+	}
+	for i := 0; i < len(requestID); i++ {
+		if requestID[i] <= ' ' || requestID[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
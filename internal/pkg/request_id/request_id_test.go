@@ -0,0 +1,62 @@
+package request_id
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestFromValue(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		wantKept bool
+	}{
+		{name: "client id", value: "req-42.retry_1", wantKept: true},
+		{name: "max length", value: strings.Repeat("a", maxLength), wantKept: true},
+		{name: "empty", value: ""},
+		{name: "too long", value: strings.Repeat("a", maxLength+1)},
+		{name: "space", value: "req 42"},
+		{name: "newline", value: "req-42\nlevel=error"},
+		{name: "non-ascii", value: "запрос"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FromValue(tt.value)
+
+			if tt.wantKept {
+				if got != tt.value {
+					t.Errorf("FromValue(%q) = %q, want it kept", tt.value, got)
+				}
+				return
+			}
+			if _, err := uuid.Parse(got); err != nil {
+				t.Errorf("FromValue(%q) = %q, want a generated uuid", tt.value, got)
+			}
+		})
+	}
+}
+
+func TestResolveGeneratesUniqueIDs(t *testing.T) {
+	first := Resolve(httptest.NewRequest("GET", "/team/get", nil))
+	second := Resolve(httptest.NewRequest("GET", "/team/get", nil))
+
+	if first == second {
+		t.Errorf("generated ids are equal: %q", first)
+	}
+}
+
+func TestContext(t *testing.T) {
+	if _, ok := FromContext(context.Background()); ok {
+		t.Error("empty context has a request id")
+	}
+
+	ctx := WithRequestID(context.Background(), "req-42")
+	if got, ok := FromContext(ctx); !ok || got != "req-42" {
+		t.Errorf("FromContext = %q, %v", got, ok)
+	}
+}
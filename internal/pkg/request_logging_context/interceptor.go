@@ -0,0 +1,28 @@
+package request_logging_context
+
+import (
+	"context"
+
+	"service-pr-reviewer-assignment/internal/pkg/grpc_metadata"
+	"service-pr-reviewer-assignment/internal/pkg/request_id"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// UnaryServerInterceptor назначает вызову gRPC идентификатор (из метаданных x-request-id или новый),
+// возвращает его в заголовках ответа и добавляет request_id и grpc_method в поля всех логов вызова.
+func UnaryServerInterceptor(logger Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		requestID := request_id.FromValue(grpc_metadata.Get(ctx, request_id.Header))
+		_ = grpc.SetHeader(ctx, metadata.Pairs(request_id.Header, requestID))
+
+		ctx = request_id.WithRequestID(ctx, requestID)
+		ctx = logger.LogCtx(ctx,
+			"request_id", requestID,
+			"grpc_method", info.FullMethod,
+		)
+
+		return handler(ctx, req)
+	}
+}
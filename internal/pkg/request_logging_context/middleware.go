@@ -3,18 +3,25 @@ package request_logging_context
 import (
 	"context"
 	"net/http"
+
+	"service-pr-reviewer-assignment/internal/pkg/request_id"
 )
 
 type Logger interface {
 	LogCtx(ctx context.Context, fields ...any) context.Context
 }
 
+// Middleware назначает запросу идентификатор (из X-Request-ID или новый), возвращает его
+// в ответе и добавляет request_id, path и method в поля всех логов запроса.
 func Middleware(logger Logger) func(next http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			ctx := r.Context()
+			requestID := request_id.Resolve(r)
+			w.Header().Set(request_id.Header, requestID)
 
+			ctx := request_id.WithRequestID(r.Context(), requestID)
 			ctx = logger.LogCtx(ctx,
+				"request_id", requestID,
 				"path", r.URL.Path,
 				"method", r.Method,
 			)
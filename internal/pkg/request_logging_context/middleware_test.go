@@ -0,0 +1,137 @@
+package request_logging_context
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"service-pr-reviewer-assignment/internal/generated/api/dto"
+	"service-pr-reviewer-assignment/internal/pkg/request_id"
+	"service-pr-reviewer-assignment/internal/pkg/response"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// recordingLogger запоминает поля, добавленные в контекст логов.
+type recordingLogger struct {
+	fields map[string]any
+}
+
+func (l *recordingLogger) LogCtx(ctx context.Context, fields ...any) context.Context {
+	l.fields = make(map[string]any, len(fields)/2)
+	for i := 0; i+1 < len(fields); i += 2 {
+		l.fields[fields[i].(string)] = fields[i+1]
+	}
+	return ctx
+}
+
+func TestMiddlewarePropagatesRequestID(t *testing.T) {
+	tests := []struct {
+		name     string
+		header   string
+		wantKept bool
+	}{
+		{name: "client id", header: "req-42", wantKept: true},
+		{name: "missing id"},
+		{name: "invalid id", header: "req 42"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger := &recordingLogger{}
+			var inContext string
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				inContext, _ = request_id.FromContext(r.Context())
+				w.WriteHeader(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/team/get", nil)
+			if tt.header != "" {
+				req.Header.Set(request_id.Header, tt.header)
+			}
+			rec := httptest.NewRecorder()
+			Middleware(logger)(next).ServeHTTP(rec, req)
+
+			echoed := rec.Header().Get(request_id.Header)
+			if echoed == "" {
+				t.Fatal("response has no request id")
+			}
+			if tt.wantKept && echoed != tt.header {
+				t.Errorf("echoed id = %q, want %q", echoed, tt.header)
+			}
+			if !tt.wantKept && echoed == tt.header {
+				t.Errorf("invalid id %q was echoed", tt.header)
+			}
+			if inContext != echoed {
+				t.Errorf("id in context = %q, want %q", inContext, echoed)
+			}
+			if logger.fields["request_id"] != echoed || logger.fields["path"] != "/team/get" || logger.fields["method"] != http.MethodGet {
+				t.Errorf("log fields = %v", logger.fields)
+			}
+		})
+	}
+}
+
+func TestMiddlewareAddsRequestIDToServerErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		write  func(w http.ResponseWriter)
+		wantID bool
+	}{
+		{
+			name:   "internal error",
+			write:  func(w http.ResponseWriter) { response.InternalError(w, errors.New("boom")) },
+			wantID: true,
+		},
+		{
+			name:  "client error",
+			write: func(w http.ResponseWriter) { response.Error(w, http.StatusNotFound, dto.NOTFOUND, "team not found") },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) { tt.write(w) })
+
+			req := httptest.NewRequest(http.MethodGet, "/team/get", nil)
+			req.Header.Set(request_id.Header, "req-42")
+			rec := httptest.NewRecorder()
+			Middleware(&recordingLogger{})(next).ServeHTTP(rec, req)
+
+			var resp dto.ErrorResponse
+			if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+				t.Fatal(err)
+			}
+			gotID := resp.Error.RequestId != nil && *resp.Error.RequestId == "req-42"
+			if gotID != tt.wantID {
+				t.Errorf("request_id = %v, want it present: %v", resp.Error.RequestId, tt.wantID)
+			}
+		})
+	}
+}
+
+func TestUnaryServerInterceptorPropagatesRequestID(t *testing.T) {
+	logger := &recordingLogger{}
+	info := &grpc.UnaryServerInfo{FullMethod: "/reviewer.v1.ReviewerService/GetTeam"}
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-request-id", "req-42"))
+	var inContext string
+	_, err := UnaryServerInterceptor(logger)(ctx, nil, info, func(ctx context.Context, _ any) (any, error) {
+		inContext, _ = request_id.FromContext(ctx)
+		return nil, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if inContext != "req-42" {
+		t.Errorf("id in context = %q, want req-42", inContext)
+	}
+	if logger.fields["request_id"] != "req-42" || logger.fields["grpc_method"] != info.FullMethod {
+		t.Errorf("log fields = %v", logger.fields)
+	}
+}
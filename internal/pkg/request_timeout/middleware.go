@@ -0,0 +1,20 @@
+package request_timeout
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// Middleware ограничивает время обработки запроса дедлайном контекста. Запросы к базе
+// отменяются по дедлайну, а обработчики отвечают на такие ошибки кодом TIMEOUT.
+func Middleware(timeout time.Duration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx, cancel := context.WithTimeout(r.Context(), timeout)
+			defer cancel()
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
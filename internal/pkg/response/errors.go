@@ -0,0 +1,256 @@
+package response
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"service-pr-reviewer-assignment/internal/generated/api/dto"
+	"service-pr-reviewer-assignment/internal/service/entities"
+)
+
+// HTTPError ошибка уровня HTTP, которую обработчик возвращает сам, например при
+// невалидном теле запроса.
+type HTTPError struct {
+	Status  int
+	Code    dto.ErrorResponseErrorCode
+	Message string
+}
+
+func (e *HTTPError) Error() string {
+	return e.Message
+}
+
+// BadRequest возвращает ошибку 400 BAD_REQUEST с сообщением для клиента.
+func BadRequest(message string) error {
+	return &HTTPError{Status: http.StatusBadRequest, Code: dto.BADREQUEST, Message: message}
+}
+
+// PayloadTooLarge возвращает ошибку 413 PAYLOAD_TOO_LARGE для тела запроса больше limit байт.
+func PayloadTooLarge(limit int64) error {
+	return &HTTPError{
+		Status:  http.StatusRequestEntityTooLarge,
+		Code:    dto.PAYLOADTOOLARGE,
+		Message: fmt.Sprintf("request body exceeds %d bytes", limit),
+	}
+}
+
+// DecodeBodyError возвращает ошибку чтения тела запроса: тело больше лимита body_limit —
+// 413 PAYLOAD_TOO_LARGE, остальные ошибки — 400 BAD_REQUEST с message.
+func DecodeBodyError(err error, message string) error {
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		return PayloadTooLarge(tooLarge.Limit)
+	}
+	return BadRequest(message)
+}
+
+// HandlerFunc обработчик, который возвращает ошибку вместо записи ответа.
+// Ошибка превращается в ответ через FromError.
+type HandlerFunc func(w http.ResponseWriter, r *http.Request) error
+
+func (f HandlerFunc) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := f(w, r); err != nil {
+		FromError(w, err)
+	}
+}
+
+// FromError отвечает ошибкой по реестру: HTTPError пишется как есть, доменная ошибка —
+// со статусом и кодом из registry, остальные ошибки — через InternalError.
+func FromError(w http.ResponseWriter, err error) {
+	classified, ok := Classify(err)
+	if !ok {
+		InternalError(w, err)
+		return
+	}
+
+	writeError(w, classified.Status, classified.Code, classified.Message, classified.Details, classified.Fields)
+}
+
+// Classified ответ на ошибку клиента: HTTP-статус, код ошибки API, сообщение, перечень
+// нарушений и поля доменной ошибки.
+type Classified struct {
+	Status  int
+	Code    dto.ErrorResponseErrorCode
+	Message string
+	Details []dto.ErrorDetail
+	Fields  map[string]any
+}
+
+// Classify находит ответ на ошибку по HTTPError и registry. ok равен false для ошибок сервера,
+// их текст не должен уходить клиенту. Используется и HTTP, и gRPC API.
+func Classify(err error) (_ Classified, ok bool) {
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		return Classified{Status: httpErr.Status, Code: httpErr.Code, Message: httpErr.Message}, true
+	}
+
+	for _, m := range registry {
+		target, fields, ok := m.match(err)
+		if !ok {
+			continue
+		}
+		if m.status >= http.StatusInternalServerError {
+			break
+		}
+
+		message := m.message
+		if message == "" {
+			message = target.Error()
+		}
+		var details []dto.ErrorDetail
+		if m.details != nil {
+			details = m.details(target)
+		}
+		return Classified{Status: m.status, Code: m.code, Message: message, Details: details, Fields: fields}, true
+	}
+
+	return Classified{}, false
+}
+
+// mapping описывает ответ на доменную ошибку. Пустой message означает текст самой ошибки.
+type mapping struct {
+	status  int
+	code    dto.ErrorResponseErrorCode
+	message string
+	match   func(err error) (error, map[string]any, bool)
+	details func(err error) []dto.ErrorDetail
+}
+
+// typed сопоставляет ошибку типа T, fields достаёт из неё поля для application/problem+json.
+func typed[T error](status int, code dto.ErrorResponseErrorCode, fields func(T) map[string]any) mapping {
+	return mapping{
+		status: status,
+		code:   code,
+		match: func(err error) (error, map[string]any, bool) {
+			var target T
+			if !errors.As(err, &target) {
+				return nil, nil, false
+			}
+			if fields == nil {
+				return target, nil, true
+			}
+			return target, fields(target), true
+		},
+	}
+}
+
+// withDetails добавляет к ответу на ошибку типа T перечень нарушений, как у ошибки валидации запроса.
+func withDetails[T error](m mapping, details func(T) []dto.ErrorDetail) mapping {
+	m.details = func(err error) []dto.ErrorDetail {
+		return details(err.(T))
+	}
+	return m
+}
+
+func sentinel(target error, status int, code dto.ErrorResponseErrorCode, message string) mapping {
+	return mapping{
+		status:  status,
+		code:    code,
+		message: message,
+		match: func(err error) (error, map[string]any, bool) {
+			if !errors.Is(err, target) {
+				return nil, nil, false
+			}
+			return target, nil, true
+		},
+	}
+}
+
+func reason(r string) map[string]any {
+	return map[string]any{"reason": r}
+}
+
+// registry сопоставляет каждую ошибку из entities со статусом и кодом ответа.
+// Новая ошибка в entities должна появиться здесь, иначе клиент получит INTERNAL_ERROR.
+var registry = []mapping{
+	typed(http.StatusBadRequest, dto.DUPLICATEUSERID, func(e *entities.ErrDuplicateUserIDs) map[string]any {
+		return map[string]any{"user_ids": e.IDs}
+	}),
+	typed(http.StatusBadRequest, dto.BADREQUEST, func(e *entities.ErrTeamNameValidation) map[string]any {
+		return reason(e.Reason)
+	}),
+	typed(http.StatusBadRequest, dto.BADREQUEST, func(e *entities.ErrUserNameValidation) map[string]any {
+		return reason(e.Reason)
+	}),
+	typed(http.StatusBadRequest, dto.BADREQUEST, func(e *entities.ErrPullRequestNameValidation) map[string]any {
+		return reason(e.Reason)
+	}),
+	typed(http.StatusBadRequest, dto.BADREQUEST, func(e *entities.ErrAPIKeyValidation) map[string]any {
+		return reason(e.Reason)
+	}),
+	typed(http.StatusBadRequest, dto.BADREQUEST, func(e *entities.ErrOrganizationNameValidation) map[string]any {
+		return reason(e.Reason)
+	}),
+	typed(http.StatusBadRequest, dto.BADREQUEST, func(e *entities.ErrUserRoleValidation) map[string]any {
+		return map[string]any{"role": e.Role}
+	}),
+	withDetails(
+		typed[*entities.ErrTeamImportValidation](http.StatusBadRequest, dto.BADREQUEST, nil),
+		func(e *entities.ErrTeamImportValidation) []dto.ErrorDetail {
+			details := make([]dto.ErrorDetail, 0, len(e.Errors))
+			for _, rowErr := range e.Errors {
+				location := "body"
+				if rowErr.Row > 0 {
+					location = fmt.Sprintf("row.%d.%s", rowErr.Row, rowErr.Field)
+				}
+				details = append(details, dto.ErrorDetail{Location: location, Message: rowErr.Reason})
+			}
+			return details
+		},
+	),
+	sentinel(entities.ErrInvalidCredentials, http.StatusUnauthorized, dto.UNAUTHORIZED, "invalid or missing credentials"),
+	typed(http.StatusForbidden, dto.FORBIDDEN, func(e *entities.ErrForbidden) map[string]any {
+		return reason(e.Reason)
+	}),
+	typed(http.StatusNotFound, dto.NOTFOUND, func(e *entities.ErrTeamNotFound) map[string]any {
+		return map[string]any{"team_name": e.Name}
+	}),
+	typed(http.StatusNotFound, dto.NOTFOUND, func(e *entities.ErrUserNotFound) map[string]any {
+		fields := make(map[string]any)
+		if e.UserID != nil {
+			fields["user_id"] = *e.UserID
+		}
+		if e.Name != nil {
+			fields["username"] = *e.Name
+		}
+		return fields
+	}),
+	typed(http.StatusNotFound, dto.NOTFOUND, func(e *entities.ErrPullRequestNotFound) map[string]any {
+		return map[string]any{"pull_request_id": e.ID}
+	}),
+	typed(http.StatusNotFound, dto.NOTFOUND, func(e *entities.ErrAPIKeyNotFound) map[string]any {
+		if e.ID == nil {
+			return nil
+		}
+		return map[string]any{"api_key_id": *e.ID}
+	}),
+	typed(http.StatusNotFound, dto.NOTFOUND, func(e *entities.ErrOrganizationNotFound) map[string]any {
+		return map[string]any{"org_id": e.ID}
+	}),
+	typed(http.StatusConflict, dto.TEAMEXISTS, func(e *entities.ErrTeamAlreadyExists) map[string]any {
+		return map[string]any{"team_name": e.Name}
+	}),
+	typed(http.StatusConflict, dto.PREXISTS, func(e *entities.ErrPullRequestAlreadyExists) map[string]any {
+		return map[string]any{"pull_request_id": e.ID}
+	}),
+	typed(http.StatusConflict, dto.PRMERGED, func(e *entities.ErrPullRequestAlreadyMerged) map[string]any {
+		return map[string]any{"pull_request_id": e.ID}
+	}),
+	typed(http.StatusConflict, dto.NOTASSIGNED, func(e *entities.ErrReviewerNotAssigned) map[string]any {
+		return map[string]any{"user_id": e.ID}
+	}),
+	sentinel(entities.ErrNoReplacementCandidate, http.StatusConflict, dto.NOCANDIDATE, ""),
+	typed(http.StatusConflict, dto.NOCANDIDATE, func(e *entities.ErrReassignTargetInvalid) map[string]any {
+		return map[string]any{"user_id": e.ID, "reason": e.Reason}
+	}),
+	typed(http.StatusConflict, dto.ORGEXISTS, func(e *entities.ErrOrganizationAlreadyExists) map[string]any {
+		return map[string]any{"org_name": e.Name}
+	}),
+	typed(http.StatusConflict, dto.USEREXISTS, func(e *entities.ErrUserAlreadyExists) map[string]any {
+		return map[string]any{"username": e.Name}
+	}),
+	// Организация должна быть определена middleware tenant до вызова сервиса,
+	// поэтому её отсутствие — ошибка сервера, а не клиента.
+	sentinel(entities.ErrTenantNotResolved, http.StatusInternalServerError, dto.INTERNALERROR, ""),
+}
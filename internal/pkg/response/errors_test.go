@@ -0,0 +1,138 @@
+package response
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"net/http"
+	"strings"
+	"testing"
+
+	"service-pr-reviewer-assignment/internal/generated/api/dto"
+	"service-pr-reviewer-assignment/internal/service/entities"
+
+	"github.com/google/uuid"
+)
+
+// entityErrors содержит по экземпляру каждой ошибки из entities/errors.go.
+var entityErrors = map[string]error{
+	"ErrDuplicateUserIDs":   &entities.ErrDuplicateUserIDs{IDs: []uuid.UUID{uuid.New()}},
+	"ErrTeamAlreadyExists":  &entities.ErrTeamAlreadyExists{Name: "payments"},
+	"ErrUserAlreadyExists":  &entities.ErrUserAlreadyExists{Name: "Alice"},
+	"ErrTeamNotFound":       &entities.ErrTeamNotFound{Name: "payments"},
+	"ErrUserNameValidation": &entities.ErrUserNameValidation{Reason: "empty"},
+	"ErrUserNotFound":       &entities.ErrUserNotFound{UserID: new(uuid.UUID)},
+	"ErrTeamNameValidation": &entities.ErrTeamNameValidation{Reason: "empty"},
+	"ErrTeamImportValidation": &entities.ErrTeamImportValidation{
+		Errors: []entities.TeamImportRowError{{Row: 2, Field: "user_id", Reason: "invalid"}},
+	},
+	"ErrPullRequestAlreadyExists":   &entities.ErrPullRequestAlreadyExists{ID: uuid.New()},
+	"ErrPullRequestNotFound":        &entities.ErrPullRequestNotFound{ID: uuid.New()},
+	"ErrPullRequestAlreadyMerged":   &entities.ErrPullRequestAlreadyMerged{ID: uuid.New()},
+	"ErrReviewerNotAssigned":        &entities.ErrReviewerNotAssigned{ID: uuid.New()},
+	"ErrNoReplacementCandidate":     entities.ErrNoReplacementCandidate,
+	"ErrPullRequestNameValidation":  &entities.ErrPullRequestNameValidation{Reason: "empty"},
+	"ErrInvalidCredentials":         entities.ErrInvalidCredentials,
+	"ErrAPIKeyNotFound":             &entities.ErrAPIKeyNotFound{},
+	"ErrAPIKeyValidation":           &entities.ErrAPIKeyValidation{Reason: "empty"},
+	"ErrForbidden":                  &entities.ErrForbidden{Reason: "not an admin"},
+	"ErrUserRoleValidation":         &entities.ErrUserRoleValidation{Role: "owner"},
+	"ErrReassignTargetInvalid":      &entities.ErrReassignTargetInvalid{ID: uuid.New(), Reason: "inactive"},
+	"ErrTenantNotResolved":          entities.ErrTenantNotResolved,
+	"ErrOrganizationNotFound":       &entities.ErrOrganizationNotFound{ID: uuid.New()},
+	"ErrOrganizationAlreadyExists":  &entities.ErrOrganizationAlreadyExists{Name: "beta"},
+	"ErrOrganizationNameValidation": &entities.ErrOrganizationNameValidation{Reason: "empty"},
+}
+
+// internalErrors ошибки entities, которые намеренно отдаются как 500.
+var internalErrors = map[string]bool{
+	"ErrTenantNotResolved": true,
+}
+
+func TestClassifyEntityErrors(t *testing.T) {
+	for name, err := range entityErrors {
+		t.Run(name, func(t *testing.T) {
+			classified, ok := Classify(fmt.Errorf("service call: %w", err))
+
+			if internalErrors[name] {
+				if ok {
+					t.Errorf("Classify = %d %s, want internal error", classified.Status, classified.Code)
+				}
+				return
+			}
+			if !ok {
+				t.Fatalf("Classify reports an internal error, add %s to registry", name)
+			}
+			if classified.Status >= http.StatusInternalServerError || classified.Code == dto.INTERNALERROR {
+				t.Errorf("Classify = %d %s, want a client error", classified.Status, classified.Code)
+			}
+		})
+	}
+}
+
+// TestEntityErrorsListed проверяет, что entityErrors содержит все ошибки из entities/errors.go.
+func TestEntityErrorsListed(t *testing.T) {
+	file, err := parser.ParseFile(token.NewFileSet(), "../../service/entities/errors.go", nil, 0)
+	if err != nil {
+		t.Fatalf("parse errors.go: %v", err)
+	}
+
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			var names []*ast.Ident
+			switch spec := spec.(type) {
+			case *ast.TypeSpec:
+				names = []*ast.Ident{spec.Name}
+			case *ast.ValueSpec:
+				names = spec.Names
+			}
+			for _, name := range names {
+				if !name.IsExported() || !strings.HasPrefix(name.Name, "Err") {
+					continue
+				}
+				if _, ok := entityErrors[name.Name]; !ok {
+					t.Errorf("%s is missing from entityErrors", name.Name)
+				}
+			}
+		}
+	}
+}
+
+func TestDecodeBodyError(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantCode   dto.ErrorResponseErrorCode
+	}{
+		{
+			name:       "body too large",
+			err:        fmt.Errorf("read row: %w", &http.MaxBytesError{Limit: 1024}),
+			wantStatus: http.StatusRequestEntityTooLarge,
+			wantCode:   dto.PAYLOADTOOLARGE,
+		},
+		{
+			name:       "malformed body",
+			err:        fmt.Errorf("unexpected EOF"),
+			wantStatus: http.StatusBadRequest,
+			wantCode:   dto.BADREQUEST,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			classified, ok := Classify(DecodeBodyError(tt.err, "decode body failed"))
+			if !ok {
+				t.Fatal("error is not classified")
+			}
+			if classified.Status != tt.wantStatus || classified.Code != tt.wantCode {
+				t.Errorf("classified = %d %s, want %d %s", classified.Status, classified.Code, tt.wantStatus, tt.wantCode)
+			}
+		})
+	}
+}
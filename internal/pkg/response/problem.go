@@ -0,0 +1,174 @@
+package response
+
+import (
+	"encoding/json"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"service-pr-reviewer-assignment/internal/generated/api/dto"
+	"service-pr-reviewer-assignment/internal/pkg/request_id"
+)
+
+// ProblemContentType тип ответа с ошибкой в формате RFC 7807.
+const ProblemContentType = "application/problem+json"
+
+// problemTypeBase пространство имён URN типов ошибок. URN не зависит от того, публикуется ли
+// документация (features.docs): описания типов на /docs/problems.html доступны по якорю
+// с тем же кодом.
+const problemTypeBase = "urn:pr-reviewer:problem:"
+
+var problemTitles = map[dto.ErrorResponseErrorCode]string{
+	dto.BADREQUEST:               "Bad request",
+	dto.DUPLICATEUSERID:          "Duplicate user ID",
+	dto.FORBIDDEN:                "Forbidden",
+	dto.IDEMPOTENCYKEYINPROGRESS: "Idempotency key in progress",
+	dto.IDEMPOTENCYKEYREUSED:     "Idempotency key reused",
+	dto.INSUFFICIENTSCOPE:        "Insufficient scope",
+	dto.INTERNALERROR:            "Internal server error",
+	dto.NOCANDIDATE:              "No replacement candidate",
+	dto.NOTASSIGNED:              "Reviewer not assigned",
+	dto.NOTFOUND:                 "Resource not found",
+	dto.ORGEXISTS:                "Organization already exists",
+	dto.PAYLOADTOOLARGE:          "Payload too large",
+	dto.PREXISTS:                 "Pull request already exists",
+	dto.PRMERGED:                 "Pull request already merged",
+	dto.RATELIMITED:              "Rate limit exceeded",
+	dto.TEAMEXISTS:               "Team already exists",
+	dto.TIMEOUT:                  "Request timed out",
+	dto.UNAUTHORIZED:             "Unauthorized",
+	dto.USEREXISTS:               "User already exists",
+}
+
+// problemWriter помечает ответ, клиент которого предпочитает application/problem+json.
+type problemWriter struct {
+	http.ResponseWriter
+}
+
+func (w *problemWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// WithProblemDetails переключает ошибки, записанные в w, на формат RFC 7807.
+func WithProblemDetails(w http.ResponseWriter) http.ResponseWriter {
+	return &problemWriter{ResponseWriter: w}
+}
+
+// AcceptsProblem сообщает, что клиент явно перечислил application/problem+json в Accept
+// и оценил его не ниже application/json. Шаблоны вида */* не учитываются: без явного
+// запроса ошибки остаются в формате ErrorResponse.
+func AcceptsProblem(accept string) bool {
+	problemQ, jsonQ := -1.0, 0.0
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+
+		q := 1.0
+		if value, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(value, 64); err != nil {
+				continue
+			}
+		}
+
+		switch mediaType {
+		case ProblemContentType:
+			problemQ = max(problemQ, q)
+		case "application/json", "application/*", "*/*":
+			jsonQ = max(jsonQ, q)
+		}
+	}
+
+	return problemQ > 0 && problemQ >= jsonQ
+}
+
+// wantsProblem ищет пометку problemWriter среди обёрток writer-а.
+func wantsProblem(w http.ResponseWriter) bool {
+	for {
+		switch rw := w.(type) {
+		case *problemWriter:
+			return true
+		case interface{ Unwrap() http.ResponseWriter }:
+			w = rw.Unwrap()
+		default:
+			return false
+		}
+	}
+}
+
+// ProblemType возвращает URI типа ошибки для кода.
+func ProblemType(code dto.ErrorResponseErrorCode) string {
+	return problemTypeBase + strings.ReplaceAll(strings.ToLower(string(code)), "_", "-")
+}
+
+func writeProblem(
+	w http.ResponseWriter,
+	status int,
+	errorCode dto.ErrorResponseErrorCode,
+	message string,
+	details []dto.ErrorDetail,
+	fields map[string]any,
+) {
+	title, ok := problemTitles[errorCode]
+	if !ok {
+		title = http.StatusText(status)
+	}
+
+	body := dto.Problem{
+		Type:                 ProblemType(errorCode),
+		Title:                title,
+		Status:               status,
+		Code:                 string(errorCode),
+		AdditionalProperties: fields,
+	}
+	if message != "" {
+		body.Detail = &message
+	}
+	if requestID := w.Header().Get(request_id.Header); requestID != "" {
+		body.Instance = &requestID
+	}
+	if len(details) > 0 {
+		body.Errors = &details
+	}
+
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// ReplayError повторно записывает сохранённый ответ с ошибкой в формате, который выбрал
+// текущий клиент: сохранённый ответ мог быть записан для клиента с другим Accept.
+// Возвращает false, если body не разбирается ни как ErrorResponse, ни как Problem.
+func ReplayError(w http.ResponseWriter, status int, contentType string, body []byte) bool {
+	if mediaType, _, _ := mime.ParseMediaType(contentType); mediaType == ProblemContentType {
+		var problem dto.Problem
+		if err := json.Unmarshal(body, &problem); err != nil || problem.Code == "" {
+			return false
+		}
+
+		var message string
+		if problem.Detail != nil {
+			message = *problem.Detail
+		}
+		var details []dto.ErrorDetail
+		if problem.Errors != nil {
+			details = *problem.Errors
+		}
+		writeError(w, status, dto.ErrorResponseErrorCode(problem.Code), message, details, problem.AdditionalProperties)
+		return true
+	}
+
+	var resp dto.ErrorResponse
+	if err := json.Unmarshal(body, &resp); err != nil || resp.Error.Code == "" {
+		return false
+	}
+
+	var details []dto.ErrorDetail
+	if resp.Error.Details != nil {
+		details = *resp.Error.Details
+	}
+	writeError(w, status, resp.Error.Code, resp.Error.Message, details, nil)
+	return true
+}
@@ -0,0 +1,46 @@
+package response
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"service-pr-reviewer-assignment/internal/generated/api/dto"
+	"service-pr-reviewer-assignment/internal/pkg/request_id"
+)
+
+func TestProblemType(t *testing.T) {
+	tests := map[dto.ErrorResponseErrorCode]string{
+		dto.NOTFOUND:        "urn:pr-reviewer:problem:not-found",
+		dto.DUPLICATEUSERID: "urn:pr-reviewer:problem:duplicate-user-id",
+		dto.PAYLOADTOOLARGE: "urn:pr-reviewer:problem:payload-too-large",
+	}
+
+	for code, want := range tests {
+		if got := ProblemType(code); got != want {
+			t.Errorf("ProblemType(%s) = %q, want %q", code, got, want)
+		}
+	}
+}
+
+func TestErrorWritesProblem(t *testing.T) {
+	rec := httptest.NewRecorder()
+	rec.Header().Set(request_id.Header, "request-1")
+
+	Error(WithProblemDetails(rec), http.StatusNotFound, dto.NOTFOUND, "team not found")
+
+	if got := rec.Header().Get("Content-Type"); got != ProblemContentType {
+		t.Errorf("Content-Type = %q, want %q", got, ProblemContentType)
+	}
+	var problem dto.Problem
+	if err := json.NewDecoder(rec.Body).Decode(&problem); err != nil {
+		t.Fatal(err)
+	}
+	if problem.Type != "urn:pr-reviewer:problem:not-found" || problem.Title != "Resource not found" || problem.Status != http.StatusNotFound {
+		t.Errorf("problem = %+v", problem)
+	}
+	if problem.Instance == nil || *problem.Instance != "request-1" {
+		t.Errorf("instance = %v, want request-1", problem.Instance)
+	}
+}
@@ -1,10 +1,13 @@
 package response
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 
 	"service-pr-reviewer-assignment/internal/generated/api/dto"
+	"service-pr-reviewer-assignment/internal/pkg/request_id"
 )
 
 func OK(w http.ResponseWriter, resp interface{}) {
@@ -14,17 +17,68 @@ func OK(w http.ResponseWriter, resp interface{}) {
 }
 
 func Error(w http.ResponseWriter, status int, errorCode dto.ErrorResponseErrorCode, message string) {
+	writeError(w, status, errorCode, message, nil, nil)
+}
+
+// ErrorWithDetails отвечает ошибкой с перечнем нарушений, например при валидации запроса.
+func ErrorWithDetails(
+	w http.ResponseWriter,
+	status int,
+	errorCode dto.ErrorResponseErrorCode,
+	message string,
+	details []dto.ErrorDetail,
+) {
+	writeError(w, status, errorCode, message, details, nil)
+}
+
+// ErrorWithFields отвечает ошибкой со структурированными полями доменной ошибки.
+// Поля попадают только в ответ application/problem+json, формат ErrorResponse не меняется.
+func ErrorWithFields(
+	w http.ResponseWriter,
+	status int,
+	errorCode dto.ErrorResponseErrorCode,
+	message string,
+	fields map[string]any,
+) {
+	writeError(w, status, errorCode, message, nil, fields)
+}
+
+func writeError(
+	w http.ResponseWriter,
+	status int,
+	errorCode dto.ErrorResponseErrorCode,
+	message string,
+	details []dto.ErrorDetail,
+	fields map[string]any,
+) {
+	if wantsProblem(w) {
+		writeProblem(w, status, errorCode, message, details, fields)
+		return
+	}
+
+	var body dto.ErrorResponse
+	body.Error.Code = errorCode
+	body.Error.Message = message
+	if len(details) > 0 {
+		body.Error.Details = &details
+	}
+
+	// Идентификатор запроса уже выставлен в заголовке ответа middleware request_logging_context.
+	if requestID := w.Header().Get(request_id.Header); requestID != "" && status >= http.StatusInternalServerError {
+		body.Error.RequestId = &requestID
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(status)
-	_ = json.NewEncoder(w).Encode(
-		dto.ErrorResponse{
-			Error: struct {
-				Code    dto.ErrorResponseErrorCode `json:"code"`
-				Message string                     `json:"message"`
-			}{
-				Code:    errorCode,
-				Message: message,
-			},
-		},
-	)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// InternalError отвечает на непредвиденную ошибку: 504 TIMEOUT, если запрос не уложился
+// в дедлайн контекста, иначе 500 INTERNAL_ERROR.
+func InternalError(w http.ResponseWriter, err error) {
+	if errors.Is(err, context.DeadlineExceeded) {
+		Error(w, http.StatusGatewayTimeout, dto.TIMEOUT, "request timed out")
+		return
+	}
+	Error(w, http.StatusInternalServerError, dto.INTERNALERROR, "internal server error")
 }
@@ -0,0 +1,72 @@
+package response_writer
+
+import (
+	"bytes"
+	"net/http"
+)
+
+// Writer запоминает статус и размер ответа, при необходимости копирует тело.
+type Writer struct {
+	http.ResponseWriter
+	status int
+	size   int
+	body   *bytes.Buffer
+}
+
+func Wrap(w http.ResponseWriter) *Writer {
+	return &Writer{ResponseWriter: w}
+}
+
+func WrapCapturing(w http.ResponseWriter) *Writer {
+	return &Writer{
+		ResponseWriter: w,
+		body:           &bytes.Buffer{},
+	}
+}
+
+func (w *Writer) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *Writer) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+
+	n, err := w.ResponseWriter.Write(b)
+	w.size += n
+	if w.body != nil {
+		w.body.Write(b[:n])
+	}
+	return n, err
+}
+
+func (w *Writer) Flush() {
+	_ = http.NewResponseController(w.ResponseWriter).Flush()
+}
+
+// Unwrap нужен http.ResponseController для доступа к исходному writer.
+func (w *Writer) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+func (w *Writer) Status() int {
+	if w.status == 0 {
+		return http.StatusOK
+	}
+	return w.status
+}
+
+func (w *Writer) Size() int {
+	return w.size
+}
+
+func (w *Writer) Body() []byte {
+	if w.body == nil {
+		return nil
+	}
+	return w.body.Bytes()
+}
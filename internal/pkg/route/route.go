@@ -0,0 +1,26 @@
+package route
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// Unmatched шаблон запроса, для которого роутер не нашёл маршрут.
+const Unmatched = "unmatched"
+
+// Template возвращает шаблон маршрута запроса (например, /api/v1/teams/{name}), по
+// которому middleware выбирают лимиты и подписывают метрики и span-ы, не раздувая
+// кардинальность. Без маршрута возвращает Unmatched.
+func Template(r *http.Request) string {
+	current := mux.CurrentRoute(r)
+	if current == nil {
+		return Unmatched
+	}
+
+	template, err := current.GetPathTemplate()
+	if err != nil {
+		return Unmatched
+	}
+	return template
+}
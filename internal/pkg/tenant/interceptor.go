@@ -0,0 +1,26 @@
+package tenant
+
+import (
+	"context"
+
+	"service-pr-reviewer-assignment/internal/pkg/grpc_metadata"
+	"service-pr-reviewer-assignment/internal/pkg/grpc_status"
+
+	"google.golang.org/grpc"
+)
+
+// UnaryServerInterceptor определяет организацию вызова gRPC по тем же правилам, что и Middleware,
+// с метаданными x-org-id вместо заголовка. Должен стоять после authentication.UnaryServerInterceptor.
+func UnaryServerInterceptor(logger Logger, service Service) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		orgID, err := resolve(ctx, logger, service, grpc_metadata.Get(ctx, HeaderOrgID))
+		if err != nil {
+			return nil, grpc_status.FromError(err)
+		}
+
+		ctx = logger.LogCtx(ctx, "org_id", orgID)
+		ctx = WithOrgID(ctx, orgID)
+
+		return handler(ctx, req)
+	}
+}
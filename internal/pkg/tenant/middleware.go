@@ -0,0 +1,83 @@
+package tenant
+
+import (
+	"context"
+	"net/http"
+
+	"service-pr-reviewer-assignment/internal/generated/api/dto"
+	"service-pr-reviewer-assignment/internal/pkg/identity"
+	"service-pr-reviewer-assignment/internal/pkg/response"
+	"service-pr-reviewer-assignment/internal/service/entities"
+
+	"github.com/google/uuid"
+)
+
+const HeaderOrgID = "X-Org-ID"
+
+type Logger interface {
+	ErrorfContext(ctx context.Context, format string, args ...interface{})
+	LogCtx(ctx context.Context, fields ...any) context.Context
+}
+
+type Service interface {
+	GetOrganization(ctx context.Context, id uuid.UUID) (*entities.Organization, error)
+}
+
+// Middleware определяет организацию запроса. Организация из учётных данных
+// (API-ключ, claim JWT) имеет приоритет, заголовок X-Org-ID учитывается только
+// для вызывающих без привязки к организации, без него используется организация
+// по умолчанию. Должен стоять после authentication.Middleware.
+func Middleware(logger Logger, service Service) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+
+			orgID, err := resolve(ctx, logger, service, r.Header.Get(HeaderOrgID))
+			if err != nil {
+				response.FromError(w, err)
+				return
+			}
+
+			ctx = logger.LogCtx(ctx, "org_id", orgID)
+			ctx = WithOrgID(ctx, orgID)
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// resolve выбирает организацию по вызывающему из контекста и значению заголовка X-Org-ID.
+// Ошибки возвращаются в виде, понятном и response.FromError, и grpc_status.FromError.
+func resolve(ctx context.Context, logger Logger, service Service, header string) (uuid.UUID, error) {
+	var requested *uuid.UUID
+	if header != "" {
+		parsed, err := uuid.Parse(header)
+		if err != nil {
+			logger.ErrorfContext(ctx, "parse %s header failed: %v", HeaderOrgID, err)
+			return uuid.Nil, response.BadRequest("invalid " + HeaderOrgID + " header")
+		}
+		requested = &parsed
+	}
+
+	caller, ok := identity.FromContext(ctx)
+	switch {
+	case ok && caller.OrgID != nil:
+		if requested != nil && *requested != *caller.OrgID {
+			logger.ErrorfContext(ctx, "caller of organization %s requested organization %s", *caller.OrgID, *requested)
+			return uuid.Nil, &response.HTTPError{
+				Status:  http.StatusForbidden,
+				Code:    dto.FORBIDDEN,
+				Message: "credentials belong to another organization",
+			}
+		}
+		return *caller.OrgID, nil
+	case requested != nil:
+		if _, err := service.GetOrganization(ctx, *requested); err != nil {
+			logger.ErrorfContext(ctx, "get organization failed: %v", err)
+			return uuid.Nil, err
+		}
+		return *requested, nil
+	default:
+		return entities.DefaultOrganizationID, nil
+	}
+}
@@ -0,0 +1,20 @@
+package tenant
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type ctxKey struct{}
+
+// WithOrgID сохраняет организацию запроса в контексте.
+func WithOrgID(ctx context.Context, orgID uuid.UUID) context.Context {
+	return context.WithValue(ctx, ctxKey{}, orgID)
+}
+
+// FromContext возвращает организацию, сохранённую в контексте.
+func FromContext(ctx context.Context) (uuid.UUID, bool) {
+	orgID, ok := ctx.Value(ctxKey{}).(uuid.UUID)
+	return orgID, ok
+}
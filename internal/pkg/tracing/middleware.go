@@ -0,0 +1,56 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+
+	"service-pr-reviewer-assignment/internal/pkg/response_writer"
+	"service-pr-reviewer-assignment/internal/pkg/route"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "service-pr-reviewer-assignment/internal/pkg/tracing"
+
+type Logger interface {
+	LogCtx(ctx context.Context, fields ...any) context.Context
+}
+
+// Middleware открывает серверный span на запрос, продолжая трассу из заголовка traceparent,
+// и добавляет trace_id в поля логов.
+func Middleware(logger Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+
+			template := route.Template(r)
+			ctx, span := otel.Tracer(instrumentationName).Start(ctx, r.Method+" "+template,
+				trace.WithSpanKind(trace.SpanKindServer),
+				trace.WithAttributes(
+					attribute.String("http.request.method", r.Method),
+					attribute.String("http.route", template),
+					attribute.String("url.path", r.URL.Path),
+					attribute.String("user_agent.original", r.UserAgent()),
+				),
+			)
+			defer span.End()
+
+			if spanCtx := span.SpanContext(); spanCtx.IsValid() {
+				ctx = logger.LogCtx(ctx, "trace_id", spanCtx.TraceID().String())
+			}
+
+			rw := response_writer.Wrap(w)
+			next.ServeHTTP(rw, r.WithContext(ctx))
+
+			status := rw.Status()
+			span.SetAttributes(attribute.Int("http.response.status_code", status))
+			if status >= http.StatusInternalServerError {
+				span.SetStatus(codes.Error, http.StatusText(status))
+			}
+		})
+	}
+}
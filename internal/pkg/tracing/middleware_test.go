@@ -0,0 +1,83 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	incomingTraceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	incomingSpanID  = "00f067aa0ba902b7"
+)
+
+type logger struct {
+	fields []any
+}
+
+func (l *logger) LogCtx(ctx context.Context, fields ...any) context.Context {
+	l.fields = append(l.fields, fields...)
+	return ctx
+}
+
+func TestMiddlewareContinuesIncomingTrace(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	l := &logger{}
+	router := mux.NewRouter()
+	router.Use(Middleware(l))
+	router.Handle("/api/v1/teams/{name}", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, span := otel.Tracer("test").Start(r.Context(), "Service.GetTeam")
+		span.End()
+		w.WriteHeader(http.StatusNotFound)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/teams/payments", nil)
+	req.Header.Set("traceparent", "00-"+incomingTraceID+"-"+incomingSpanID+"-01")
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("got %d spans, want 2", len(spans))
+	}
+	child, server := spans[0], spans[1]
+
+	if server.Name() != "GET /api/v1/teams/{name}" {
+		t.Errorf("server span name = %q", server.Name())
+	}
+	if server.SpanKind() != trace.SpanKindServer {
+		t.Errorf("server span kind = %v", server.SpanKind())
+	}
+	if got := server.SpanContext().TraceID().String(); got != incomingTraceID {
+		t.Errorf("trace id = %s, want %s from traceparent", got, incomingTraceID)
+	}
+	if got := server.Parent().SpanID().String(); got != incomingSpanID || !server.Parent().IsRemote() {
+		t.Errorf("server span parent = %s (remote %v), want remote %s", got, server.Parent().IsRemote(), incomingSpanID)
+	}
+	if child.Parent().SpanID() != server.SpanContext().SpanID() {
+		t.Errorf("handler span is not a child of the server span")
+	}
+
+	attributes := attribute.NewSet(server.Attributes()...)
+	if route, _ := attributes.Value("http.route"); route.AsString() != "/api/v1/teams/{name}" {
+		t.Errorf("http.route = %q", route.AsString())
+	}
+	if status, _ := attributes.Value("http.response.status_code"); status.AsInt64() != http.StatusNotFound {
+		t.Errorf("http.response.status_code = %d", status.AsInt64())
+	}
+
+	if len(l.fields) != 2 || l.fields[0] != "trace_id" || l.fields[1] != incomingTraceID {
+		t.Errorf("log fields = %v, want trace_id %s", l.fields, incomingTraceID)
+	}
+}
@@ -0,0 +1,183 @@
+package prctl
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// usageError ошибка в аргументах командной строки, завершается с кодом ExitUsage.
+type usageError struct {
+	msg string
+}
+
+func (e *usageError) Error() string {
+	return e.msg
+}
+
+func usagef(format string, args ...any) error {
+	return &usageError{msg: fmt.Sprintf(format, args...)}
+}
+
+// env окружение выполнения команды.
+type env struct {
+	client  *Client
+	printer *printer
+	stderr  io.Writer
+}
+
+// command подкоманда prctl. setup объявляет флаги команды и возвращает функцию,
+// которая выполняет команду с позиционными аргументами после разбора флагов.
+type command struct {
+	name    string
+	args    string
+	summary string
+	setup   func(fs *flag.FlagSet) func(ctx context.Context, e *env, args []string) error
+}
+
+// Run выполняет prctl с аргументами args (без имени программы) и возвращает код завершения.
+func Run(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	var configPath, output string
+
+	global := flag.NewFlagSet("prctl", flag.ContinueOnError)
+	global.SetOutput(stderr)
+	global.StringVar(&configPath, "config", "", "path to config file (default $PRCTL_CONFIG or "+defaultConfigPath()+")")
+	global.StringVar(&output, "o", "", "output format: table or json")
+	global.Usage = func() { printUsage(stderr, global) }
+
+	if err := global.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return ExitOK
+		}
+		return ExitUsage
+	}
+
+	args = global.Args()
+	if len(args) == 0 || args[0] == "help" {
+		printUsage(stderr, global)
+		if len(args) == 0 {
+			return ExitUsage
+		}
+		return ExitOK
+	}
+
+	cmd, args := findCommand(args)
+	if cmd == nil {
+		fmt.Fprintf(stderr, "prctl: unknown command %q, see prctl help\n", strings.Join(args, " "))
+		return ExitUsage
+	}
+
+	fs := flag.NewFlagSet("prctl "+cmd.name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.StringVar(&output, "o", output, "output format: table or json")
+	run := cmd.setup(fs)
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "usage: prctl %s %s\n\n%s\n\n", cmd.name, cmd.args, cmd.summary)
+		fs.PrintDefaults()
+	}
+
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return ExitOK
+		}
+		return ExitUsage
+	}
+
+	cfg, err := loadConfig(configPath)
+	if err != nil {
+		fmt.Fprintf(stderr, "prctl: %v\n", err)
+		return ExitUsage
+	}
+	if output != "" {
+		if err := validateOutput(output); err != nil {
+			fmt.Fprintf(stderr, "prctl: %v\n", err)
+			return ExitUsage
+		}
+		cfg.Output = output
+	}
+
+	client, err := NewClient(cfg)
+	if err != nil {
+		fmt.Fprintf(stderr, "prctl: %v\n", err)
+		return ExitUsage
+	}
+
+	e := &env{
+		client:  client,
+		printer: &printer{w: stdout, format: cfg.Output},
+		stderr:  stderr,
+	}
+	if err := run(ctx, e, positional); err != nil {
+		fmt.Fprintf(stderr, "prctl %s: %v\n", cmd.name, err)
+		var usageErr *usageError
+		if errors.As(err, &usageErr) {
+			fs.Usage()
+		}
+		return exitCode(err)
+	}
+
+	return ExitOK
+}
+
+// findCommand ищет команду по первым двум словам (группа и действие) и возвращает
+// оставшиеся аргументы.
+func findCommand(args []string) (*command, []string) {
+	if len(args) < 2 {
+		return nil, args
+	}
+	name := args[0] + " " + args[1]
+	for i := range commands {
+		if commands[i].name == name {
+			return &commands[i], args[2:]
+		}
+	}
+	return nil, args
+}
+
+// parseArgs разбирает флаги, которые могут стоять как до, так и после позиционных
+// аргументов, и возвращает позиционные аргументы. Всё после "--" считается позиционным.
+func parseArgs(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+
+		rest := fs.Args()
+		if len(rest) == 0 {
+			return positional, nil
+		}
+		if n := len(args) - len(rest); n > 0 && args[n-1] == "--" {
+			return append(positional, rest...), nil
+		}
+
+		positional = append(positional, rest[0])
+		args = rest[1:]
+	}
+}
+
+func printUsage(w io.Writer, global *flag.FlagSet) {
+	fmt.Fprintf(w, "usage: prctl [-config file] [-o table|json] <command> [flags] [args]\n\ncommands:\n")
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "  %-16s %s\n", cmd.name, cmd.summary)
+	}
+	fmt.Fprintf(w, "\nflags:\n")
+	global.PrintDefaults()
+	fmt.Fprintf(w, `
+exit codes:
+  %d  success
+  %d  network error, unexpected response or INTERNAL_ERROR
+  %d  invalid command line or config
+  %d  BAD_REQUEST, DUPLICATE_USER_ID, PAYLOAD_TOO_LARGE, IDEMPOTENCY_KEY_REUSED
+  %d  UNAUTHORIZED
+  %d  FORBIDDEN, INSUFFICIENT_SCOPE
+  %d  NOT_FOUND
+  %d  TEAM_EXISTS, PR_EXISTS, USER_EXISTS, ORG_EXISTS
+  %d  PR_MERGED, NOT_ASSIGNED, NO_CANDIDATE
+  %d  RATE_LIMITED, TIMEOUT, IDEMPOTENCY_KEY_IN_PROGRESS
+`, ExitOK, ExitError, ExitUsage, ExitInvalid, ExitUnauthorized, ExitForbidden, ExitNotFound, ExitExists, ExitConflict, ExitRetry)
+}
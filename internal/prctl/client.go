@@ -0,0 +1,257 @@
+package prctl
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"service-pr-reviewer-assignment/internal/generated/api/dto"
+
+	"github.com/google/uuid"
+)
+
+const (
+	contentTypeJSON    = "application/json"
+	contentTypeProblem = "application/problem+json"
+	contentTypeCSV     = "text/csv"
+	contentTypeYAML    = "application/yaml"
+
+	// maxErrorBodyBytes сколько байт тела ответа с ошибкой читается для разбора.
+	maxErrorBodyBytes = 1 << 20
+)
+
+// APIError ответ API с ошибкой: код из ErrorResponse (или Problem) и подробности валидации.
+type APIError struct {
+	Status    int
+	Code      string
+	Message   string
+	Details   []dto.ErrorDetail
+	RequestID string
+}
+
+func (e *APIError) Error() string {
+	var b strings.Builder
+	if e.Code != "" {
+		b.WriteString(e.Code)
+	} else {
+		b.WriteString(strconv.Itoa(e.Status))
+	}
+	if e.Message != "" {
+		b.WriteString(": ")
+		b.WriteString(e.Message)
+	}
+	for _, detail := range e.Details {
+		fmt.Fprintf(&b, "\n  %s: %s", detail.Location, detail.Message)
+	}
+	if e.RequestID != "" {
+		fmt.Fprintf(&b, "\n  request id: %s", e.RequestID)
+	}
+	return b.String()
+}
+
+// Client клиент HTTP API сервиса. Использует маршруты /api/v1.
+type Client struct {
+	baseURL *url.URL
+	http    *http.Client
+	header  http.Header
+}
+
+func NewClient(cfg *Config) (*Client, error) {
+	baseURL, err := url.Parse(strings.TrimSuffix(cfg.BaseURL, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("parse base url: %w", err)
+	}
+
+	header := make(http.Header)
+	header.Set("Accept", contentTypeJSON)
+	header.Set("User-Agent", "prctl")
+	if cfg.APIKey != "" {
+		header.Set("X-API-Key", cfg.APIKey)
+	}
+	if cfg.Token != "" {
+		header.Set("Authorization", "Bearer "+cfg.Token)
+	}
+	if cfg.OrgID != "" {
+		header.Set("X-Org-ID", cfg.OrgID)
+	}
+
+	return &Client{
+		baseURL: baseURL,
+		http:    &http.Client{Timeout: cfg.Timeout},
+		header:  header,
+	}, nil
+}
+
+func (c *Client) CreateTeam(ctx context.Context, team dto.Team) (*dto.Team, error) {
+	var resp struct {
+		Team dto.Team `json:"team"`
+	}
+	if err := c.doJSON(ctx, http.MethodPost, "/api/v1/teams", nil, team, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Team, nil
+}
+
+func (c *Client) ImportTeams(ctx context.Context, body io.Reader, contentType string, dryRun, upsert bool) (*dto.TeamImportResult, error) {
+	query := url.Values{}
+	query.Set("dry_run", strconv.FormatBool(dryRun))
+	query.Set("upsert", strconv.FormatBool(upsert))
+
+	var resp dto.TeamImportResult
+	if err := c.do(ctx, http.MethodPost, "/api/v1/teams/import", query, contentType, body, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *Client) GetTeam(ctx context.Context, teamName string) (*dto.Team, error) {
+	var resp dto.Team
+	if err := c.doJSON(ctx, http.MethodGet, "/api/v1/teams/"+url.PathEscape(teamName), nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *Client) SetUserActive(ctx context.Context, userID uuid.UUID, isActive bool) (*dto.User, error) {
+	var resp dto.User
+	body := dto.SetUserActiveBody{IsActive: isActive}
+	if err := c.doJSON(ctx, http.MethodPut, "/api/v1/users/"+userID.String()+"/active", nil, body, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *Client) GetUserReviews(ctx context.Context, userID uuid.UUID) (*dto.UserReviewResponse, error) {
+	var resp dto.UserReviewResponse
+	if err := c.doJSON(ctx, http.MethodGet, "/api/v1/users/"+userID.String()+"/reviews", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *Client) CreatePullRequest(ctx context.Context, req dto.CreatePullRequestRequest) (*dto.PullRequest, error) {
+	var resp struct {
+		PR dto.PullRequest `json:"pr"`
+	}
+	if err := c.doJSON(ctx, http.MethodPost, "/api/v1/pull-requests", nil, req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.PR, nil
+}
+
+func (c *Client) MergePullRequest(ctx context.Context, pullRequestID uuid.UUID) (*dto.PullRequest, error) {
+	var resp struct {
+		PR dto.PullRequest `json:"pr"`
+	}
+	if err := c.doJSON(ctx, http.MethodPost, "/api/v1/pull-requests/"+pullRequestID.String()+"/merge", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.PR, nil
+}
+
+func (c *Client) ReassignPullRequest(ctx context.Context, pullRequestID uuid.UUID, body dto.ReassignReviewerBody) (*dto.ReassignPullRequestResponse, error) {
+	var resp dto.ReassignPullRequestResponse
+	if err := c.doJSON(ctx, http.MethodPost, "/api/v1/pull-requests/"+pullRequestID.String()+"/reassign", nil, body, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// doJSON отправляет in как JSON (если in не nil) и декодирует ответ в out.
+func (c *Client) doJSON(ctx context.Context, method, path string, query url.Values, in, out any) error {
+	if in == nil {
+		return c.do(ctx, method, path, query, "", nil, out)
+	}
+
+	body, err := json.Marshal(in)
+	if err != nil {
+		return fmt.Errorf("encode request: %w", err)
+	}
+	return c.do(ctx, method, path, query, contentTypeJSON, bytes.NewReader(body), out)
+}
+
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, contentType string, body io.Reader, out any) error {
+	u := c.baseURL.JoinPath(path)
+	u.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return fmt.Errorf("build request: %w", err)
+	}
+	req.Header = c.header.Clone()
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s %s: %w", method, path, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return decodeError(resp)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("decode response: %w", err)
+	}
+	return nil
+}
+
+// decodeError разбирает ответ с ошибкой в формате ErrorResponse или Problem. Ответы
+// в другом формате (например, от прокси) превращаются в APIError только со статусом.
+func decodeError(resp *http.Response) error {
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodyBytes))
+	if err != nil {
+		return fmt.Errorf("read error response: %w", err)
+	}
+
+	apiErr := &APIError{Status: resp.StatusCode}
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+
+	switch mediaType {
+	case contentTypeProblem:
+		var problem dto.Problem
+		if err := json.Unmarshal(data, &problem); err == nil && problem.Code != "" {
+			apiErr.Code = problem.Code
+			apiErr.Message = problem.Title
+			if problem.Detail != nil {
+				apiErr.Message = *problem.Detail
+			}
+			if problem.Errors != nil {
+				apiErr.Details = *problem.Errors
+			}
+			if problem.Instance != nil {
+				apiErr.RequestID = *problem.Instance
+			}
+			return apiErr
+		}
+	case contentTypeJSON:
+		var errResp dto.ErrorResponse
+		if err := json.Unmarshal(data, &errResp); err == nil && errResp.Error.Code != "" {
+			apiErr.Code = string(errResp.Error.Code)
+			apiErr.Message = errResp.Error.Message
+			if errResp.Error.Details != nil {
+				apiErr.Details = *errResp.Error.Details
+			}
+			if errResp.Error.RequestId != nil {
+				apiErr.RequestID = *errResp.Error.RequestId
+			}
+			return apiErr
+		}
+	}
+
+	apiErr.Message = http.StatusText(resp.StatusCode)
+	if text := strings.TrimSpace(string(data)); text != "" {
+		apiErr.Message += ": " + text
+	}
+	return apiErr
+}
@@ -0,0 +1,319 @@
+package prctl
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"service-pr-reviewer-assignment/internal/generated/api/dto"
+
+	"github.com/google/uuid"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc, modify ...func(*Config)) *Client {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	cfg := defaultConfig()
+	cfg.BaseURL = server.URL
+	cfg.Timeout = 5 * time.Second
+	for _, m := range modify {
+		m(cfg)
+	}
+
+	client, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	return client
+}
+
+func writeJSON(w http.ResponseWriter, contentType string, status int, v any) {
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func TestClientSendsRequest(t *testing.T) {
+	member := dto.TeamMember{UserId: uuid.New(), Username: "alice", IsActive: true}
+	team := dto.Team{TeamName: "backend", Members: []dto.TeamMember{member}}
+
+	var got dto.Team
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/v1/teams" {
+			t.Errorf("request = %s %s, want POST /api/v1/teams", r.Method, r.URL.Path)
+		}
+		for header, want := range map[string]string{
+			"X-API-Key":     "secret",
+			"X-Org-ID":      "acme",
+			"Authorization": "",
+			"Accept":        contentTypeJSON,
+			"Content-Type":  contentTypeJSON,
+		} {
+			if value := r.Header.Get(header); value != want {
+				t.Errorf("%s = %q, want %q", header, value, want)
+			}
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("decode body: %v", err)
+		}
+
+		writeJSON(w, contentTypeJSON, http.StatusCreated, map[string]dto.Team{"team": got})
+	}, func(cfg *Config) {
+		cfg.APIKey = "secret"
+		cfg.OrgID = "acme"
+	})
+
+	created, err := client.CreateTeam(context.Background(), team)
+	if err != nil {
+		t.Fatalf("CreateTeam: %v", err)
+	}
+	if !reflect.DeepEqual(got, team) {
+		t.Errorf("request body = %+v, want %+v", got, team)
+	}
+	if !reflect.DeepEqual(*created, team) {
+		t.Errorf("CreateTeam = %+v, want %+v", *created, team)
+	}
+}
+
+func TestClientBearerTokenAndQuery(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if auth := r.Header.Get("Authorization"); auth != "Bearer jwt" {
+			t.Errorf("Authorization = %q, want Bearer jwt", auth)
+		}
+		if key := r.Header.Get("X-API-Key"); key != "" {
+			t.Errorf("X-API-Key = %q, want none", key)
+		}
+		if r.URL.Path != "/api/v1/teams/import" || r.URL.RawQuery != "dry_run=true&upsert=false" {
+			t.Errorf("request = %s?%s", r.URL.Path, r.URL.RawQuery)
+		}
+		if ct := r.Header.Get("Content-Type"); ct != contentTypeCSV {
+			t.Errorf("Content-Type = %q, want %q", ct, contentTypeCSV)
+		}
+
+		writeJSON(w, contentTypeJSON, http.StatusOK, dto.TeamImportResult{DryRun: true})
+	}, func(cfg *Config) {
+		cfg.Token = "jwt"
+	})
+
+	result, err := client.ImportTeams(context.Background(), strings.NewReader("team_name,user_id,username\n"), contentTypeCSV, true, false)
+	if err != nil {
+		t.Fatalf("ImportTeams: %v", err)
+	}
+	if !result.DryRun {
+		t.Error("dry_run = false, want true")
+	}
+}
+
+func TestClientEscapesPath(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.EscapedPath() != "/api/v1/teams/back%2Fend" {
+			t.Errorf("path = %q, want the team name escaped", r.URL.EscapedPath())
+		}
+		writeJSON(w, contentTypeJSON, http.StatusOK, dto.Team{TeamName: "back/end"})
+	})
+
+	if _, err := client.GetTeam(context.Background(), "back/end"); err != nil {
+		t.Fatalf("GetTeam: %v", err)
+	}
+}
+
+func TestClientDecodesErrors(t *testing.T) {
+	requestID := "req-1"
+	detail := "team backend not found"
+	details := []dto.ErrorDetail{{Location: "body.team_name", Message: "is required"}}
+
+	tests := []struct {
+		name     string
+		respond  http.HandlerFunc
+		want     APIError
+		wantExit int
+	}{
+		{
+			name: "error response",
+			respond: func(w http.ResponseWriter, _ *http.Request) {
+				var body dto.ErrorResponse
+				body.Error.Code = dto.NOTFOUND
+				body.Error.Message = "team not found"
+				writeJSON(w, contentTypeJSON, http.StatusNotFound, body)
+			},
+			want:     APIError{Status: http.StatusNotFound, Code: string(dto.NOTFOUND), Message: "team not found"},
+			wantExit: ExitNotFound,
+		},
+		{
+			name: "error response with details and request id",
+			respond: func(w http.ResponseWriter, _ *http.Request) {
+				var body dto.ErrorResponse
+				body.Error.Code = dto.BADREQUEST
+				body.Error.Message = "invalid request"
+				body.Error.Details = &details
+				body.Error.RequestId = &requestID
+				writeJSON(w, contentTypeJSON, http.StatusBadRequest, body)
+			},
+			want:     APIError{Status: http.StatusBadRequest, Code: string(dto.BADREQUEST), Message: "invalid request", Details: details, RequestID: requestID},
+			wantExit: ExitInvalid,
+		},
+		{
+			name: "problem",
+			respond: func(w http.ResponseWriter, _ *http.Request) {
+				writeJSON(w, contentTypeProblem+"; charset=utf-8", http.StatusConflict, dto.Problem{
+					Code:     string(dto.TEAMEXISTS),
+					Title:    "Team already exists",
+					Detail:   &detail,
+					Instance: &requestID,
+					Status:   http.StatusConflict,
+				})
+			},
+			want:     APIError{Status: http.StatusConflict, Code: string(dto.TEAMEXISTS), Message: detail, RequestID: requestID},
+			wantExit: ExitExists,
+		},
+		{
+			name: "foreign body",
+			respond: func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Content-Type", "text/plain")
+				w.WriteHeader(http.StatusServiceUnavailable)
+				_, _ = w.Write([]byte("upstream unavailable\n"))
+			},
+			want:     APIError{Status: http.StatusServiceUnavailable, Message: "Service Unavailable: upstream unavailable"},
+			wantExit: ExitRetry,
+		},
+		{
+			name: "json without error code",
+			respond: func(w http.ResponseWriter, _ *http.Request) {
+				writeJSON(w, contentTypeJSON, http.StatusForbidden, map[string]string{"message": "denied"})
+			},
+			want:     APIError{Status: http.StatusForbidden, Message: `Forbidden: {"message":"denied"}`},
+			wantExit: ExitForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t, tt.respond)
+
+			_, err := client.GetTeam(context.Background(), "backend")
+
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("GetTeam error = %v, want *APIError", err)
+			}
+			if !reflect.DeepEqual(*apiErr, tt.want) {
+				t.Errorf("APIError = %+v, want %+v", *apiErr, tt.want)
+			}
+			if code := exitCode(err); code != tt.wantExit {
+				t.Errorf("exitCode = %d, want %d", code, tt.wantExit)
+			}
+		})
+	}
+}
+
+func TestClientTransportError(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	cfg := defaultConfig()
+	cfg.BaseURL = server.URL
+	client, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	_, err = client.GetTeam(context.Background(), "backend")
+	if err == nil {
+		t.Fatal("GetTeam error = nil, want a transport error")
+	}
+	if code := exitCode(err); code != ExitError {
+		t.Errorf("exitCode = %d, want %d", code, ExitError)
+	}
+}
+
+func TestRunExitCodes(t *testing.T) {
+	member := dto.TeamMember{UserId: uuid.New(), Username: "alice", IsActive: true}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/teams/backend":
+			writeJSON(w, contentTypeJSON, http.StatusOK, dto.Team{TeamName: "backend", Members: []dto.TeamMember{member}})
+		default:
+			var body dto.ErrorResponse
+			body.Error.Code = dto.NOTFOUND
+			body.Error.Message = "team not found"
+			writeJSON(w, contentTypeJSON, http.StatusNotFound, body)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	tests := []struct {
+		name       string
+		args       []string
+		wantCode   int
+		wantStdout string
+		wantStderr string
+	}{
+		{
+			name:       "success",
+			args:       []string{"team", "members", "backend"},
+			wantCode:   ExitOK,
+			wantStdout: member.UserId.String() + "  alice     true",
+		},
+		{
+			name:       "json output",
+			args:       []string{"-o", "json", "team", "members", "backend"},
+			wantCode:   ExitOK,
+			wantStdout: `"team_name": "backend"`,
+		},
+		{
+			name:       "api error",
+			args:       []string{"team", "members", "frontend"},
+			wantCode:   ExitNotFound,
+			wantStderr: "prctl team members: NOT_FOUND: team not found",
+		},
+		{
+			name:       "usage error",
+			args:       []string{"team", "members"},
+			wantCode:   ExitUsage,
+			wantStderr: "want exactly one team name",
+		},
+		{
+			name:       "unknown command",
+			args:       []string{"team", "delete"},
+			wantCode:   ExitUsage,
+			wantStderr: `unknown command "team delete"`,
+		},
+		{
+			name:       "invalid output flag",
+			args:       []string{"-o", "xml", "team", "members", "backend"},
+			wantCode:   ExitUsage,
+			wantStderr: "output must be table or json",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			isolateConfig(t)
+			t.Setenv("PRCTL_BASE_URL", server.URL)
+
+			var stdout, stderr bytes.Buffer
+			code := Run(context.Background(), tt.args, &stdout, &stderr)
+
+			if code != tt.wantCode {
+				t.Errorf("Run = %d, want %d; stderr: %s", code, tt.wantCode, stderr.String())
+			}
+			if !strings.Contains(stdout.String(), tt.wantStdout) {
+				t.Errorf("stdout = %q, want it to contain %q", stdout.String(), tt.wantStdout)
+			}
+			if !strings.Contains(stderr.String(), tt.wantStderr) {
+				t.Errorf("stderr = %q, want it to contain %q", stderr.String(), tt.wantStderr)
+			}
+		})
+	}
+}
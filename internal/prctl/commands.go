@@ -0,0 +1,326 @@
+package prctl
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"service-pr-reviewer-assignment/internal/generated/api/dto"
+
+	"github.com/google/uuid"
+)
+
+var commands = []command{
+	{
+		name:    "team create",
+		args:    "<team> -member <user_id>:<username>[:inactive] ...",
+		summary: "create a team with members (creates or updates the users)",
+		setup:   teamCreate,
+	},
+	{
+		name:    "team import",
+		args:    "[-dry-run] [-upsert] [-format csv|yaml] <file|->",
+		summary: "import teams from a CSV or YAML file",
+		setup:   teamImport,
+	},
+	{
+		name:    "team members",
+		args:    "<team>",
+		summary: "list team members",
+		setup:   teamMembers,
+	},
+	{
+		name:    "user activate",
+		args:    "<user_id>",
+		summary: "mark a user active",
+		setup:   userSetActive(true),
+	},
+	{
+		name:    "user deactivate",
+		args:    "<user_id>",
+		summary: "mark a user inactive and remove them from open reviews",
+		setup:   userSetActive(false),
+	},
+	{
+		name:    "user reviews",
+		args:    "<user_id>",
+		summary: "show pull requests the user is assigned to review",
+		setup:   userReviews,
+	},
+	{
+		name:    "pr create",
+		args:    "-author <user_id> -name <name> [-return-existing] [<pull_request_id>]",
+		summary: "create a pull request and assign reviewers",
+		setup:   prCreate,
+	},
+	{
+		name:    "pr merge",
+		args:    "<pull_request_id>",
+		summary: "mark a pull request merged",
+		setup:   prMerge,
+	},
+	{
+		name:    "pr reassign",
+		args:    "-old <user_id> [-new <user_id>] <pull_request_id>",
+		summary: "replace a reviewer of a pull request",
+		setup:   prReassign,
+	},
+}
+
+// membersFlag повторяемый флаг -member в формате user_id:username[:inactive].
+type membersFlag []dto.TeamMember
+
+func (f *membersFlag) String() string {
+	return ""
+}
+
+func (f *membersFlag) Set(value string) error {
+	parts := strings.Split(value, ":")
+	if len(parts) < 2 || len(parts) > 3 || (len(parts) == 3 && parts[2] != "inactive") {
+		return fmt.Errorf("want <user_id>:<username>[:inactive], got %q", value)
+	}
+
+	id, err := uuid.Parse(parts[0])
+	if err != nil {
+		return fmt.Errorf("invalid user_id %q", parts[0])
+	}
+
+	*f = append(*f, dto.TeamMember{
+		UserId:   id,
+		Username: parts[1],
+		IsActive: len(parts) == 2,
+	})
+	return nil
+}
+
+func teamCreate(fs *flag.FlagSet) func(ctx context.Context, e *env, args []string) error {
+	var members membersFlag
+	fs.Var(&members, "member", "team member as <user_id>:<username>[:inactive], repeatable")
+
+	return func(ctx context.Context, e *env, args []string) error {
+		if len(args) != 1 {
+			return usagef("want exactly one team name")
+		}
+		if len(members) == 0 {
+			return usagef("at least one -member is required")
+		}
+
+		team, err := e.client.CreateTeam(ctx, dto.Team{TeamName: args[0], Members: members})
+		if err != nil {
+			return err
+		}
+		return e.printer.print(team, membersTable(team.Members))
+	}
+}
+
+func teamImport(fs *flag.FlagSet) func(ctx context.Context, e *env, args []string) error {
+	dryRun := fs.Bool("dry-run", false, "only validate the file, change nothing")
+	upsert := fs.Bool("upsert", false, "add members to existing teams instead of failing")
+	format := fs.String("format", "", "file format: csv or yaml (default from the file extension)")
+
+	return func(ctx context.Context, e *env, args []string) error {
+		if len(args) != 1 {
+			return usagef("want exactly one file, - for stdin")
+		}
+
+		contentType, err := importContentType(args[0], *format)
+		if err != nil {
+			return err
+		}
+
+		var body io.Reader = os.Stdin
+		if args[0] != "-" {
+			file, err := os.Open(args[0])
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+			body = file
+		}
+
+		result, err := e.client.ImportTeams(ctx, body, contentType, *dryRun, *upsert)
+		if err != nil {
+			return err
+		}
+		if result.DryRun && e.printer.format == outputTable {
+			fmt.Fprintln(e.stderr, "dry run: the file is valid, nothing was changed")
+		}
+		return e.printer.print(result, teamImportTable(result))
+	}
+}
+
+// importContentType выбирает Content-Type импорта по флагу -format или расширению файла.
+func importContentType(path, format string) (string, error) {
+	if format == "" && path != "-" {
+		format = strings.TrimPrefix(strings.ToLower(filepath.Ext(path)), ".")
+	}
+
+	switch format {
+	case "csv":
+		return contentTypeCSV, nil
+	case "yaml", "yml":
+		return contentTypeYAML, nil
+	case "":
+		return "", usagef("-format is required when reading from stdin")
+	default:
+		return "", usagef("unsupported format %q, want csv or yaml", format)
+	}
+}
+
+func teamMembers(_ *flag.FlagSet) func(ctx context.Context, e *env, args []string) error {
+	return func(ctx context.Context, e *env, args []string) error {
+		if len(args) != 1 {
+			return usagef("want exactly one team name")
+		}
+
+		team, err := e.client.GetTeam(ctx, args[0])
+		if err != nil {
+			return err
+		}
+		return e.printer.print(team, membersTable(team.Members))
+	}
+}
+
+func userSetActive(isActive bool) func(fs *flag.FlagSet) func(ctx context.Context, e *env, args []string) error {
+	return func(_ *flag.FlagSet) func(ctx context.Context, e *env, args []string) error {
+		return func(ctx context.Context, e *env, args []string) error {
+			userID, err := singleID(args, "user_id")
+			if err != nil {
+				return err
+			}
+
+			user, err := e.client.SetUserActive(ctx, userID, isActive)
+			if err != nil {
+				return err
+			}
+			return e.printer.print(user, userTable(user))
+		}
+	}
+}
+
+func userReviews(_ *flag.FlagSet) func(ctx context.Context, e *env, args []string) error {
+	return func(ctx context.Context, e *env, args []string) error {
+		userID, err := singleID(args, "user_id")
+		if err != nil {
+			return err
+		}
+
+		reviews, err := e.client.GetUserReviews(ctx, userID)
+		if err != nil {
+			return err
+		}
+		return e.printer.print(reviews, reviewsTable(reviews))
+	}
+}
+
+func prCreate(fs *flag.FlagSet) func(ctx context.Context, e *env, args []string) error {
+	author := fs.String("author", "", "author user_id")
+	name := fs.String("name", "", "pull request name")
+	returnExisting := fs.Bool("return-existing", false, "return the existing pull request with the same id, name and author instead of PR_EXISTS")
+
+	return func(ctx context.Context, e *env, args []string) error {
+		if len(args) > 1 {
+			return usagef("want at most one pull_request_id")
+		}
+		if *name == "" {
+			return usagef("-name is required")
+		}
+
+		authorID, err := parseID(*author, "-author")
+		if err != nil {
+			return err
+		}
+
+		// Без явного id генерируется новый: повторный запуск создаст другой PR.
+		pullRequestID := uuid.New()
+		if len(args) == 1 {
+			if pullRequestID, err = parseID(args[0], "pull_request_id"); err != nil {
+				return err
+			}
+		}
+
+		req := dto.CreatePullRequestRequest{
+			PullRequestId:   pullRequestID,
+			PullRequestName: *name,
+			AuthorId:        authorID,
+		}
+		if *returnExisting {
+			req.ReturnExisting = returnExisting
+		}
+
+		pr, err := e.client.CreatePullRequest(ctx, req)
+		if err != nil {
+			return err
+		}
+		return e.printer.print(pr, pullRequestTable(pr))
+	}
+}
+
+func prMerge(_ *flag.FlagSet) func(ctx context.Context, e *env, args []string) error {
+	return func(ctx context.Context, e *env, args []string) error {
+		pullRequestID, err := singleID(args, "pull_request_id")
+		if err != nil {
+			return err
+		}
+
+		pr, err := e.client.MergePullRequest(ctx, pullRequestID)
+		if err != nil {
+			return err
+		}
+		return e.printer.print(pr, pullRequestTable(pr))
+	}
+}
+
+func prReassign(fs *flag.FlagSet) func(ctx context.Context, e *env, args []string) error {
+	oldUser := fs.String("old", "", "user_id of the reviewer to replace")
+	newUser := fs.String("new", "", "user_id of the new reviewer (team leads and admins only; default: picked automatically)")
+
+	return func(ctx context.Context, e *env, args []string) error {
+		pullRequestID, err := singleID(args, "pull_request_id")
+		if err != nil {
+			return err
+		}
+
+		oldUserID, err := parseID(*oldUser, "-old")
+		if err != nil {
+			return err
+		}
+		body := dto.ReassignReviewerBody{OldUserId: oldUserID}
+
+		if *newUser != "" {
+			newUserID, err := parseID(*newUser, "-new")
+			if err != nil {
+				return err
+			}
+			body.NewUserId = &newUserID
+		}
+
+		resp, err := e.client.ReassignPullRequest(ctx, pullRequestID, body)
+		if err != nil {
+			return err
+		}
+		return e.printer.print(resp, reassignTable(resp))
+	}
+}
+
+func singleID(args []string, name string) (uuid.UUID, error) {
+	if len(args) != 1 {
+		return uuid.Nil, usagef("want exactly one %s", name)
+	}
+	return parseID(args[0], name)
+}
+
+func parseID(value, name string) (uuid.UUID, error) {
+	if value == "" {
+		return uuid.Nil, usagef("%s is required", name)
+	}
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return uuid.Nil, usagef("invalid %s %q", name, value)
+	}
+	return id, nil
+}
@@ -0,0 +1,148 @@
+package prctl
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// configFileEnv переменная окружения с путём к файлу конфигурации prctl.
+const configFileEnv = "PRCTL_CONFIG"
+
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+)
+
+// Config адрес API и учётные данные. Задаётся файлом конфигурации, значения
+// переопределяются переменными окружения PRCTL_*.
+type Config struct {
+	BaseURL string `yaml:"base_url"`
+	// APIKey передаётся в заголовке X-API-Key, Token — в Authorization: Bearer.
+	// Задаётся что-то одно.
+	APIKey string `yaml:"api_key"`
+	Token  string `yaml:"token"`
+	// OrgID передаётся в заголовке X-Org-ID, учитывается сервисом только при
+	// выключенной аутентификации.
+	OrgID   string        `yaml:"org_id"`
+	Timeout time.Duration `yaml:"timeout"`
+	// Output формат вывода по умолчанию: table или json.
+	Output string `yaml:"output"`
+}
+
+func defaultConfig() *Config {
+	return &Config{
+		BaseURL: "http://localhost:8080",
+		Timeout: 30 * time.Second,
+		Output:  outputTable,
+	}
+}
+
+// defaultConfigPath путь к файлу конфигурации, если он не задан флагом -config
+// или PRCTL_CONFIG: $XDG_CONFIG_HOME/prctl/config.yaml (~/.config/prctl/config.yaml).
+func defaultConfigPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "prctl", "config.yaml")
+}
+
+// loadConfig читает файл path (или файл по умолчанию), затем применяет переменные
+// окружения. Отсутствие файла по умолчанию не ошибка, явно указанного — ошибка.
+func loadConfig(path string) (*Config, error) {
+	cfg := defaultConfig()
+
+	explicit := true
+	if path == "" {
+		path = os.Getenv(configFileEnv)
+	}
+	if path == "" {
+		path = defaultConfigPath()
+		explicit = false
+	}
+
+	if path != "" {
+		err := cfg.loadFile(path)
+		if errors.Is(err, fs.ErrNotExist) && !explicit {
+			err = nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("load config file %s: %w", path, err)
+		}
+	}
+
+	cfg.applyEnv()
+
+	if err := cfg.validate(); err != nil {
+		return nil, fmt.Errorf("validate config: %w", err)
+	}
+
+	return cfg, nil
+}
+
+// loadFile накладывает значения из файла поверх текущих. Неизвестные ключи считаются ошибкой.
+func (c *Config) loadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(c); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("decode yaml: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Config) applyEnv() {
+	c.BaseURL = getEnv("PRCTL_BASE_URL", c.BaseURL)
+	c.APIKey = getEnv("PRCTL_API_KEY", c.APIKey)
+	c.Token = getEnv("PRCTL_TOKEN", c.Token)
+	c.OrgID = getEnv("PRCTL_ORG_ID", c.OrgID)
+	c.Output = getEnv("PRCTL_OUTPUT", c.Output)
+}
+
+func (c *Config) validate() error {
+	var errs []error
+
+	u, err := url.Parse(c.BaseURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		errs = append(errs, fmt.Errorf("base_url must be an absolute http(s) URL, got %q", c.BaseURL))
+	}
+	if c.APIKey != "" && c.Token != "" {
+		errs = append(errs, errors.New("only one of api_key and token may be set"))
+	}
+	if c.Timeout <= 0 {
+		errs = append(errs, errors.New("timeout must be positive"))
+	}
+	if err := validateOutput(c.Output); err != nil {
+		errs = append(errs, err)
+	}
+
+	return errors.Join(errs...)
+}
+
+func validateOutput(output string) error {
+	if output != outputTable && output != outputJSON {
+		return fmt.Errorf("output must be %s or %s, got %q", outputTable, outputJSON, output)
+	}
+	return nil
+}
+
+func getEnv(key, def string) string {
+	if value, ok := os.LookupEnv(key); ok {
+		return value
+	}
+	return def
+}
@@ -0,0 +1,172 @@
+package prctl
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// isolateConfig убирает переменные PRCTL_* и направляет файл конфигурации по
+// умолчанию во временный каталог, чтобы тест не зависел от окружения.
+func isolateConfig(t *testing.T) string {
+	t.Helper()
+
+	for _, key := range []string{configFileEnv, "PRCTL_BASE_URL", "PRCTL_API_KEY", "PRCTL_TOKEN", "PRCTL_ORG_ID", "PRCTL_OUTPUT"} {
+		t.Setenv(key, "")
+		os.Unsetenv(key)
+	}
+
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	t.Setenv("HOME", dir)
+	return dir
+}
+
+func writeConfig(t *testing.T, path, data string) {
+	t.Helper()
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadConfigDefaults(t *testing.T) {
+	isolateConfig(t)
+
+	cfg, err := loadConfig("")
+	if err != nil {
+		t.Fatalf("loadConfig: %v", err)
+	}
+	if *cfg != *defaultConfig() {
+		t.Errorf("config = %+v, want defaults %+v", cfg, defaultConfig())
+	}
+}
+
+func TestLoadConfigFile(t *testing.T) {
+	dir := isolateConfig(t)
+	path := filepath.Join(dir, "prctl.yaml")
+	writeConfig(t, path, `
+base_url: https://reviewer.example.com
+api_key: secret
+org_id: acme
+timeout: 5s
+output: json
+`)
+
+	cfg, err := loadConfig(path)
+	if err != nil {
+		t.Fatalf("loadConfig: %v", err)
+	}
+
+	want := Config{
+		BaseURL: "https://reviewer.example.com",
+		APIKey:  "secret",
+		OrgID:   "acme",
+		Timeout: 5 * time.Second,
+		Output:  outputJSON,
+	}
+	if *cfg != want {
+		t.Errorf("config = %+v, want %+v", *cfg, want)
+	}
+}
+
+func TestLoadConfigPathSources(t *testing.T) {
+	t.Run("env path", func(t *testing.T) {
+		dir := isolateConfig(t)
+		path := filepath.Join(dir, "from-env.yaml")
+		writeConfig(t, path, "base_url: https://env.example.com\n")
+		t.Setenv(configFileEnv, path)
+
+		cfg, err := loadConfig("")
+		if err != nil {
+			t.Fatalf("loadConfig: %v", err)
+		}
+		if cfg.BaseURL != "https://env.example.com" {
+			t.Errorf("base_url = %q, want the value from %s", cfg.BaseURL, configFileEnv)
+		}
+	})
+
+	t.Run("default path", func(t *testing.T) {
+		dir := isolateConfig(t)
+		writeConfig(t, filepath.Join(dir, "prctl", "config.yaml"), "base_url: https://default.example.com\n")
+
+		cfg, err := loadConfig("")
+		if err != nil {
+			t.Fatalf("loadConfig: %v", err)
+		}
+		if cfg.BaseURL != "https://default.example.com" {
+			t.Errorf("base_url = %q, want the value from the default file", cfg.BaseURL)
+		}
+	})
+}
+
+func TestLoadConfigEnvOverridesFile(t *testing.T) {
+	dir := isolateConfig(t)
+	path := filepath.Join(dir, "prctl.yaml")
+	writeConfig(t, path, `
+base_url: https://file.example.com
+api_key: from-file
+output: json
+`)
+	t.Setenv("PRCTL_BASE_URL", "http://env.example.com:8080")
+	t.Setenv("PRCTL_API_KEY", "")
+	t.Setenv("PRCTL_TOKEN", "jwt")
+	t.Setenv("PRCTL_OUTPUT", outputTable)
+
+	cfg, err := loadConfig(path)
+	if err != nil {
+		t.Fatalf("loadConfig: %v", err)
+	}
+	if cfg.BaseURL != "http://env.example.com:8080" {
+		t.Errorf("base_url = %q, want the env value", cfg.BaseURL)
+	}
+	// Пустая переменная тоже переопределяет файл: так можно сбросить api_key.
+	if cfg.APIKey != "" || cfg.Token != "jwt" {
+		t.Errorf("api_key = %q, token = %q, want empty and jwt", cfg.APIKey, cfg.Token)
+	}
+	if cfg.Output != outputTable {
+		t.Errorf("output = %q, want %q", cfg.Output, outputTable)
+	}
+}
+
+func TestLoadConfigErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		env     map[string]string
+		missing bool
+		wantErr string
+	}{
+		{name: "explicit file missing", missing: true, wantErr: "load config file"},
+		{name: "unknown key", data: "base_url: http://localhost\napi-key: typo\n", wantErr: "field api-key not found"},
+		{name: "invalid yaml", data: "base_url: [\n", wantErr: "decode yaml"},
+		{name: "relative base url", data: "base_url: localhost:8080\n", wantErr: "base_url must be an absolute http(s) URL"},
+		{name: "key and token", data: "api_key: a\ntoken: b\n", wantErr: "only one of api_key and token"},
+		{name: "non-positive timeout", data: "timeout: 0s\n", wantErr: "timeout must be positive"},
+		{name: "invalid output", data: "output: xml\n", wantErr: `output must be table or json, got "xml"`},
+		{name: "invalid output from env", env: map[string]string{"PRCTL_OUTPUT": "yaml"}, wantErr: `got "yaml"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := isolateConfig(t)
+			path := filepath.Join(dir, "prctl.yaml")
+			if !tt.missing {
+				writeConfig(t, path, tt.data)
+			}
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+
+			_, err := loadConfig(path)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("loadConfig error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
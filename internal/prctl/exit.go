@@ -0,0 +1,92 @@
+package prctl
+
+import (
+	"errors"
+	"net/http"
+
+	"service-pr-reviewer-assignment/internal/generated/api/dto"
+)
+
+// Коды завершения prctl. Ошибки API сводятся к группам по коду ошибки, чтобы
+// скрипты могли отличить, например, отсутствующий объект от конфликта.
+const (
+	ExitOK = 0
+	// ExitError сеть, неожиданный ответ, INTERNAL_ERROR и прочие ошибки.
+	ExitError = 1
+	// ExitUsage неверные аргументы командной строки или конфигурация.
+	ExitUsage = 2
+	// ExitInvalid BAD_REQUEST, DUPLICATE_USER_ID, PAYLOAD_TOO_LARGE, IDEMPOTENCY_KEY_REUSED.
+	ExitInvalid = 3
+	// ExitUnauthorized UNAUTHORIZED.
+	ExitUnauthorized = 4
+	// ExitForbidden FORBIDDEN, INSUFFICIENT_SCOPE.
+	ExitForbidden = 5
+	// ExitNotFound NOT_FOUND.
+	ExitNotFound = 6
+	// ExitExists TEAM_EXISTS, PR_EXISTS, USER_EXISTS, ORG_EXISTS.
+	ExitExists = 7
+	// ExitConflict PR_MERGED, NOT_ASSIGNED, NO_CANDIDATE.
+	ExitConflict = 8
+	// ExitRetry RATE_LIMITED, TIMEOUT, IDEMPOTENCY_KEY_IN_PROGRESS: запрос можно повторить позже.
+	ExitRetry = 9
+)
+
+var exitCodes = map[dto.ErrorResponseErrorCode]int{
+	dto.BADREQUEST:               ExitInvalid,
+	dto.DUPLICATEUSERID:          ExitInvalid,
+	dto.PAYLOADTOOLARGE:          ExitInvalid,
+	dto.IDEMPOTENCYKEYREUSED:     ExitInvalid,
+	dto.UNAUTHORIZED:             ExitUnauthorized,
+	dto.FORBIDDEN:                ExitForbidden,
+	dto.INSUFFICIENTSCOPE:        ExitForbidden,
+	dto.NOTFOUND:                 ExitNotFound,
+	dto.TEAMEXISTS:               ExitExists,
+	dto.PREXISTS:                 ExitExists,
+	dto.USEREXISTS:               ExitExists,
+	dto.ORGEXISTS:                ExitExists,
+	dto.PRMERGED:                 ExitConflict,
+	dto.NOTASSIGNED:              ExitConflict,
+	dto.NOCANDIDATE:              ExitConflict,
+	dto.RATELIMITED:              ExitRetry,
+	dto.TIMEOUT:                  ExitRetry,
+	dto.IDEMPOTENCYKEYINPROGRESS: ExitRetry,
+	dto.INTERNALERROR:            ExitError,
+}
+
+// exitCode выбирает код завершения по ошибке. Для ответов без известного кода
+// ошибки API используется HTTP-статус.
+func exitCode(err error) int {
+	if err == nil {
+		return ExitOK
+	}
+
+	var usageErr *usageError
+	if errors.As(err, &usageErr) {
+		return ExitUsage
+	}
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return ExitError
+	}
+	if code, ok := exitCodes[dto.ErrorResponseErrorCode(apiErr.Code)]; ok {
+		return code
+	}
+
+	switch apiErr.Status {
+	case http.StatusBadRequest, http.StatusRequestEntityTooLarge, http.StatusUnprocessableEntity:
+		return ExitInvalid
+	case http.StatusUnauthorized:
+		return ExitUnauthorized
+	case http.StatusForbidden:
+		return ExitForbidden
+	case http.StatusNotFound:
+		return ExitNotFound
+	case http.StatusConflict:
+		return ExitConflict
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return ExitRetry
+	default:
+		return ExitError
+	}
+}
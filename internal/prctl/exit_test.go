@@ -0,0 +1,49 @@
+package prctl
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"service-pr-reviewer-assignment/internal/generated/api/dto"
+)
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{name: "nil", err: nil, want: ExitOK},
+		{name: "usage", err: usagef("want exactly one team name"), want: ExitUsage},
+		{name: "wrapped usage", err: fmt.Errorf("parse: %w", usagef("bad flag")), want: ExitUsage},
+		{name: "plain error", err: errors.New("connection refused"), want: ExitError},
+		{name: "not found code", err: &APIError{Status: http.StatusNotFound, Code: string(dto.NOTFOUND)}, want: ExitNotFound},
+		{name: "code wins over status", err: &APIError{Status: http.StatusConflict, Code: string(dto.TEAMEXISTS)}, want: ExitExists},
+		{name: "conflict code", err: &APIError{Status: http.StatusConflict, Code: string(dto.PRMERGED)}, want: ExitConflict},
+		{name: "insufficient scope", err: &APIError{Status: http.StatusForbidden, Code: string(dto.INSUFFICIENTSCOPE)}, want: ExitForbidden},
+		{name: "rate limited", err: &APIError{Status: http.StatusTooManyRequests, Code: string(dto.RATELIMITED)}, want: ExitRetry},
+		{name: "internal error", err: &APIError{Status: http.StatusInternalServerError, Code: string(dto.INTERNALERROR)}, want: ExitError},
+		{name: "wrapped api error", err: fmt.Errorf("get team: %w", &APIError{Status: http.StatusUnauthorized, Code: string(dto.UNAUTHORIZED)}), want: ExitUnauthorized},
+		{name: "unknown code falls back to status", err: &APIError{Status: http.StatusNotFound, Code: "SOMETHING_NEW"}, want: ExitNotFound},
+		{name: "status 400", err: &APIError{Status: http.StatusBadRequest}, want: ExitInvalid},
+		{name: "status 413", err: &APIError{Status: http.StatusRequestEntityTooLarge}, want: ExitInvalid},
+		{name: "status 422", err: &APIError{Status: http.StatusUnprocessableEntity}, want: ExitInvalid},
+		{name: "status 401", err: &APIError{Status: http.StatusUnauthorized}, want: ExitUnauthorized},
+		{name: "status 403", err: &APIError{Status: http.StatusForbidden}, want: ExitForbidden},
+		{name: "status 409", err: &APIError{Status: http.StatusConflict}, want: ExitConflict},
+		{name: "status 429", err: &APIError{Status: http.StatusTooManyRequests}, want: ExitRetry},
+		{name: "status 503", err: &APIError{Status: http.StatusServiceUnavailable}, want: ExitRetry},
+		{name: "status 504", err: &APIError{Status: http.StatusGatewayTimeout}, want: ExitRetry},
+		{name: "status 502", err: &APIError{Status: http.StatusBadGateway}, want: ExitError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := exitCode(tt.err); got != tt.want {
+				t.Errorf("exitCode(%v) = %d, want %d", tt.err, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package prctl
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"service-pr-reviewer-assignment/internal/generated/api/dto"
+
+	"github.com/google/uuid"
+)
+
+// table строки для табличного вывода, первая строка — заголовок.
+type table [][]string
+
+// printer печатает результат команды таблицей или JSON-ом в том виде, в каком
+// его вернул API.
+type printer struct {
+	w      io.Writer
+	format string
+}
+
+func (p *printer) print(v any, t table) error {
+	if p.format == outputJSON {
+		encoder := json.NewEncoder(p.w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(v)
+	}
+
+	tw := tabwriter.NewWriter(p.w, 0, 0, 2, ' ', 0)
+	for _, row := range t {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
+
+func membersTable(members []dto.TeamMember) table {
+	t := table{{"USER_ID", "USERNAME", "ACTIVE"}}
+	for _, member := range members {
+		t = append(t, []string{member.UserId.String(), member.Username, strconv.FormatBool(member.IsActive)})
+	}
+	return t
+}
+
+func teamImportTable(result *dto.TeamImportResult) table {
+	t := table{{"TEAM", "CREATED", "USER_ID", "USERNAME", "ACTIVE"}}
+	for _, team := range result.Teams {
+		for _, member := range team.Members {
+			t = append(t, []string{
+				team.TeamName,
+				strconv.FormatBool(team.Created),
+				member.UserId.String(),
+				member.Username,
+				strconv.FormatBool(member.IsActive),
+			})
+		}
+	}
+	return t
+}
+
+func userTable(user *dto.User) table {
+	return table{
+		{"USER_ID", "USERNAME", "TEAM", "ROLE", "ACTIVE"},
+		{user.UserId.String(), user.Username, user.TeamName, string(user.Role), strconv.FormatBool(user.IsActive)},
+	}
+}
+
+func reviewsTable(reviews *dto.UserReviewResponse) table {
+	t := table{{"PULL_REQUEST_ID", "NAME", "AUTHOR_ID", "STATUS"}}
+	for _, pr := range reviews.PullRequests {
+		t = append(t, []string{pr.PullRequestId.String(), pr.PullRequestName, pr.AuthorId.String(), string(pr.Status)})
+	}
+	return t
+}
+
+func pullRequestTable(pr *dto.PullRequest) table {
+	return table{
+		{"PULL_REQUEST_ID", "NAME", "AUTHOR_ID", "STATUS", "REVIEWERS"},
+		{pr.PullRequestId.String(), pr.PullRequestName, pr.AuthorId.String(), string(pr.Status), joinIDs(pr.AssignedReviewers)},
+	}
+}
+
+func reassignTable(resp *dto.ReassignPullRequestResponse) table {
+	t := pullRequestTable(&resp.Pr)
+	t[0] = append(t[0], "REPLACED_BY")
+	t[1] = append(t[1], resp.ReplacedBy.String())
+	return t
+}
+
+func joinIDs(ids []uuid.UUID) string {
+	if len(ids) == 0 {
+		return "-"
+	}
+	parts := make([]string, 0, len(ids))
+	for _, id := range ids {
+		parts = append(parts, id.String())
+	}
+	return strings.Join(parts, ",")
+}
@@ -0,0 +1,216 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"service-pr-reviewer-assignment/internal/service/entities"
+
+	"github.com/AlekSi/pointer"
+	"github.com/google/uuid"
+)
+
+const apiKeySecretBytes = 32
+
+// CreateAPIKey создаёт ключ и возвращает его секрет. Секрет нигде не сохраняется
+// и не может быть получен повторно.
+func (s *Service) CreateAPIKey(
+	ctx context.Context,
+	name string,
+	scopes []entities.Scope,
+) (_ *entities.APIKey, _ string, err error) {
+	ctx, span := tracer.Start(ctx, "Service.CreateAPIKey")
+	defer func() { finishSpan(span, err) }()
+
+	if err := validateAPIKey(name, scopes); err != nil {
+		return nil, "", fmt.Errorf("validate api key: %w", err)
+	}
+
+	secret, err := generateAPIKeySecret()
+	if err != nil {
+		return nil, "", fmt.Errorf("generate api key secret: %w", err)
+	}
+
+	var key *entities.APIKey
+	err = s.txManager.Write(ctx, func(ctx context.Context) error {
+		var err error
+		key, err = s.insertAPIKey(ctx, name, hashAPIKeySecret(secret), scopes)
+		return err
+	})
+	if err != nil {
+		return nil, "", fmt.Errorf("create api key transaction: %w", err)
+	}
+
+	return key, secret, nil
+}
+
+// EnsureAPIKey регистрирует заранее известный секрет, если он ещё не сохранён.
+// Используется для начального ключа администратора из конфигурации.
+func (s *Service) EnsureAPIKey(
+	ctx context.Context,
+	name string,
+	secret string,
+	scopes []entities.Scope,
+) (err error) {
+	ctx, span := tracer.Start(ctx, "Service.EnsureAPIKey")
+	defer func() { finishSpan(span, err) }()
+
+	if err := validateAPIKey(name, scopes); err != nil {
+		return fmt.Errorf("validate api key: %w", err)
+	}
+
+	keyHash := hashAPIKeySecret(secret)
+	err = s.txManager.Write(ctx, func(ctx context.Context) error {
+		_, err := s.storage.GetAPIKeyByHash(ctx, keyHash)
+		var notFound *entities.ErrAPIKeyNotFound
+		switch {
+		case err == nil:
+			return nil
+		case !errors.As(err, &notFound):
+			return fmt.Errorf("get api key: %w", err)
+		}
+
+		_, err = s.insertAPIKey(ctx, name, keyHash, scopes)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("ensure api key: %w", err)
+	}
+
+	return nil
+}
+
+// insertAPIKey сохраняет ключ в организации из контекста. Вызывается внутри транзакции.
+func (s *Service) insertAPIKey(
+	ctx context.Context,
+	name string,
+	keyHash string,
+	scopes []entities.Scope,
+) (*entities.APIKey, error) {
+	key, err := s.storage.CreateAPIKey(ctx, &entities.APIKey{
+		ID:        uuid.New(),
+		Name:      name,
+		Scopes:    scopes,
+		CreatedAt: timeNowFunc(),
+	}, keyHash)
+	if err != nil {
+		return nil, fmt.Errorf("create api key: %w", err)
+	}
+
+	if err := s.audit(ctx, entities.AuditActionAPIKeyCreate, key.ID.String()); err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
+
+func (s *Service) ListAPIKeys(ctx context.Context) (_ []entities.APIKey, err error) {
+	ctx, span := tracer.Start(ctx, "Service.ListAPIKeys")
+	defer func() { finishSpan(span, err) }()
+
+	var keys []entities.APIKey
+	err = s.txManager.Read(ctx, func(ctx context.Context) error {
+		var err error
+		keys, err = s.storage.GetAPIKeys(ctx)
+		if err != nil {
+			return fmt.Errorf("get api keys: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list api keys: %w", err)
+	}
+
+	return keys, nil
+}
+
+func (s *Service) RevokeAPIKey(ctx context.Context, id uuid.UUID) (_ *entities.APIKey, err error) {
+	ctx, span := tracer.Start(ctx, "Service.RevokeAPIKey")
+	defer func() { finishSpan(span, err) }()
+
+	var key *entities.APIKey
+	err = s.txManager.Write(ctx, func(ctx context.Context) error {
+		var err error
+		key, err = s.storage.RevokeAPIKey(ctx, id, timeNowFunc())
+		if err != nil {
+			return fmt.Errorf("revoke api key: %w", err)
+		}
+
+		return s.audit(ctx, entities.AuditActionAPIKeyRevoke, id.String())
+	})
+	if err != nil {
+		return nil, fmt.Errorf("revoke api key transaction: %w", err)
+	}
+
+	return key, nil
+}
+
+// AuthenticateAPIKey находит действующий ключ по секрету. Для неизвестного
+// и отозванного ключа возвращает entities.ErrInvalidCredentials.
+func (s *Service) AuthenticateAPIKey(ctx context.Context, secret string) (_ *entities.Identity, err error) {
+	ctx, span := tracer.Start(ctx, "Service.AuthenticateAPIKey")
+	defer func() { finishSpan(span, err) }()
+
+	var key *entities.APIKey
+	err = s.txManager.Read(ctx, func(ctx context.Context) error {
+		var err error
+		key, err = s.storage.GetAPIKeyByHash(ctx, hashAPIKeySecret(secret))
+		var notFound *entities.ErrAPIKeyNotFound
+		switch {
+		case errors.As(err, &notFound):
+			return entities.ErrInvalidCredentials
+		case err != nil:
+			return fmt.Errorf("get api key: %w", err)
+		case key.IsRevoked():
+			return entities.ErrInvalidCredentials
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("authenticate api key: %w", err)
+	}
+
+	return &entities.Identity{
+		Subject:  "api_key:" + key.ID.String(),
+		APIKeyID: pointer.To(key.ID),
+		OrgID:    pointer.To(key.OrgID),
+		Scopes:   key.Scopes,
+	}, nil
+}
+
+func generateAPIKeySecret() (string, error) {
+	buf := make([]byte, apiKeySecretBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return entities.APIKeyPrefix + base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+func hashAPIKeySecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+func validateAPIKey(name string, scopes []entities.Scope) error {
+	if len(name) < 2 {
+		return &entities.ErrAPIKeyValidation{Reason: "name too short"}
+	}
+	if len(name) > 100 {
+		return &entities.ErrAPIKeyValidation{Reason: "name too long"}
+	}
+	if len(scopes) == 0 {
+		return &entities.ErrAPIKeyValidation{Reason: "at least one scope is required"}
+	}
+	for _, scope := range scopes {
+		if !scope.IsValid() {
+			return &entities.ErrAPIKeyValidation{Reason: fmt.Sprintf("unknown scope %q", scope)}
+		}
+	}
+
+	return nil
+}
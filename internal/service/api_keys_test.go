@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"service-pr-reviewer-assignment/internal/service/entities"
+
+	"github.com/AlekSi/pointer"
+	"github.com/google/uuid"
+)
+
+// apiKeyStorage хранит ключи по хэшу секрета.
+type apiKeyStorage struct {
+	storage
+
+	keys map[string]*entities.APIKey
+	err  error
+}
+
+func (s *apiKeyStorage) GetAPIKeyByHash(_ context.Context, keyHash string) (*entities.APIKey, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	if key, ok := s.keys[keyHash]; ok {
+		return key, nil
+	}
+	return nil, &entities.ErrAPIKeyNotFound{}
+}
+
+func TestAuthenticateAPIKey(t *testing.T) {
+	const (
+		activeSecret  = entities.APIKeyPrefix + "active"
+		revokedSecret = entities.APIKeyPrefix + "revoked"
+	)
+	active := &entities.APIKey{
+		ID:     uuid.MustParse("650e8400-e29b-41d4-a716-446655440001"),
+		OrgID:  entities.DefaultOrganizationID,
+		Scopes: []entities.Scope{entities.ScopeRead, entities.ScopeWriteTeams},
+	}
+	revoked := &entities.APIKey{
+		ID:        uuid.MustParse("650e8400-e29b-41d4-a716-446655440002"),
+		Scopes:    []entities.Scope{entities.ScopeAdmin},
+		RevokedAt: pointer.To(time.Now()),
+	}
+	storage := &apiKeyStorage{keys: map[string]*entities.APIKey{
+		hashAPIKeySecret(activeSecret):  active,
+		hashAPIKeySecret(revokedSecret): revoked,
+	}}
+	s := Must(storage, directTxManager{}, noopMetrics{}, AssignmentPolicy{MaxReviewers: 2}, false)
+
+	caller, err := s.AuthenticateAPIKey(context.Background(), activeSecret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if caller.Subject != "api_key:"+active.ID.String() {
+		t.Errorf("subject = %q", caller.Subject)
+	}
+	if caller.APIKeyID == nil || *caller.APIKeyID != active.ID || caller.OrgID == nil || *caller.OrgID != active.OrgID {
+		t.Errorf("caller = %+v, want key and organization of %s", caller, active.ID)
+	}
+	if !caller.HasScope(entities.ScopeWriteTeams) || caller.HasScope(entities.ScopeWritePullRequests) {
+		t.Errorf("scopes = %v, want the key scopes", caller.Scopes)
+	}
+
+	for name, secret := range map[string]string{
+		"unknown": entities.APIKeyPrefix + "unknown",
+		"revoked": revokedSecret,
+	} {
+		if _, err := s.AuthenticateAPIKey(context.Background(), secret); !errors.Is(err, entities.ErrInvalidCredentials) {
+			t.Errorf("%s key: err = %v, want ErrInvalidCredentials", name, err)
+		}
+	}
+}
+
+func TestAuthenticateAPIKeyStorageError(t *testing.T) {
+	storageErr := errors.New("connection reset")
+	s := Must(&apiKeyStorage{err: storageErr}, directTxManager{}, noopMetrics{}, AssignmentPolicy{MaxReviewers: 2}, false)
+
+	_, err := s.AuthenticateAPIKey(context.Background(), entities.APIKeyPrefix+"any")
+	if !errors.Is(err, storageErr) || errors.Is(err, entities.ErrInvalidCredentials) {
+		t.Errorf("err = %v, want the storage error, not invalid credentials", err)
+	}
+}
+
+func TestGenerateAPIKeySecret(t *testing.T) {
+	first, err := generateAPIKeySecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := generateAPIKeySecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.HasPrefix(first, entities.APIKeyPrefix) {
+		t.Errorf("secret %q lacks prefix %q", first, entities.APIKeyPrefix)
+	}
+	if first == second {
+		t.Error("two secrets are equal")
+	}
+	if hashAPIKeySecret(first) == first {
+		t.Error("hash equals the secret")
+	}
+}
+
+func TestValidateAPIKey(t *testing.T) {
+	read := []entities.Scope{entities.ScopeRead}
+	tests := []struct {
+		name    string
+		keyName string
+		scopes  []entities.Scope
+		wantErr bool
+	}{
+		{name: "valid", keyName: "ci", scopes: read},
+		{name: "name too short", keyName: "c", scopes: read, wantErr: true},
+		{name: "name too long", keyName: strings.Repeat("a", 101), scopes: read, wantErr: true},
+		{name: "no scopes", keyName: "ci", wantErr: true},
+		{name: "unknown scope", keyName: "ci", scopes: []entities.Scope{"write:all"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateAPIKey(tt.keyName, tt.scopes)
+
+			var validation *entities.ErrAPIKeyValidation
+			if tt.wantErr != errors.As(err, &validation) {
+				t.Errorf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"service-pr-reviewer-assignment/internal/pkg/identity"
+	"service-pr-reviewer-assignment/internal/service/entities"
+)
+
+// systemActor автор операций, выполненных вне HTTP-запроса (например, при старте).
+const systemActor = "system"
+
+// audit записывает в журнал вызывающего из контекста. Вызывается внутри транзакции
+// операции, чтобы запись не появилась без самого изменения.
+func (s *Service) audit(ctx context.Context, action entities.AuditAction, entityID string) error {
+	actor := systemActor
+	if caller, ok := identity.FromContext(ctx); ok {
+		actor = caller.Subject
+	}
+
+	err := s.storage.CreateAuditLogEntry(ctx, &entities.AuditLogEntry{
+		Actor:     actor,
+		Action:    action,
+		EntityID:  entityID,
+		CreatedAt: timeNowFunc(),
+	})
+	if err != nil {
+		return fmt.Errorf("audit %s: %w", action, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"service-pr-reviewer-assignment/internal/pkg/identity"
+	"service-pr-reviewer-assignment/internal/service/entities"
+
+	"github.com/google/uuid"
+)
+
+// caller вызывающий операцию вместе с его пользователем сервиса, если он есть.
+type caller struct {
+	user    *entities.User
+	isAdmin bool
+
+	// scopes права вызывающего без пользователя сервиса, например API-ключа интеграции.
+	// Такой вызывающий действует в любой команде организации в пределах своих scope-ов:
+	// write:teams меняет состав команд и активность пользователей, write:prs выбирает
+	// ревьювера при переназначении. Операции администратора ему недоступны.
+	scopes []entities.Scope
+}
+
+// resolveCaller определяет права вызывающего. Вызов без identity в контексте
+// (например, при старте сервиса) и ключ со scope admin имеют права администратора.
+// Права пользователя сервиса задаёт его роль, а пользователь из токена, которого нет
+// в организации, прав не имеет. Вызывается внутри транзакции операции, чтобы роль
+// читалась согласованно с данными.
+func (s *Service) resolveCaller(ctx context.Context) (*caller, error) {
+	id, ok := identity.FromContext(ctx)
+	if !ok || id.HasScope(entities.ScopeAdmin) {
+		return &caller{isAdmin: true}, nil
+	}
+	if id.UserID == nil {
+		return &caller{scopes: id.Scopes}, nil
+	}
+
+	user, err := s.storage.GetUserByID(ctx, *id.UserID)
+	var notFound *entities.ErrUserNotFound
+	switch {
+	case errors.As(err, &notFound):
+		return &caller{}, nil
+	case err != nil:
+		return nil, fmt.Errorf("get caller user: %w", err)
+	}
+
+	return &caller{
+		user:    user,
+		isAdmin: user.Role == entities.UserRoleOrgAdmin,
+	}, nil
+}
+
+func (c *caller) isUser(userID uuid.UUID) bool {
+	return c.user != nil && c.user.ID == userID
+}
+
+// isTeamLead сообщает, что вызывающий — лид команды teamName.
+func (c *caller) isTeamLead(teamName string) bool {
+	return c.user != nil && c.user.Role == entities.UserRoleTeamLead && c.user.TeamName == teamName
+}
+
+// canManageTeam сообщает, может ли вызывающий менять состав и участников команды.
+func (c *caller) canManageTeam(teamName string) bool {
+	return c.isAdmin || c.isTeamLead(teamName) || slices.Contains(c.scopes, entities.ScopeWriteTeams)
+}
+
+func (c *caller) requireTeamManager(teamName string) error {
+	if !c.canManageTeam(teamName) {
+		return &entities.ErrForbidden{Reason: fmt.Sprintf("only leads of team %s or admins can do this", teamName)}
+	}
+	return nil
+}
+
+// requireReviewerChooser проверяет, что вызывающий может сам выбрать ревьювера из команды teamName.
+func (c *caller) requireReviewerChooser(teamName string) error {
+	if c.isAdmin || c.isTeamLead(teamName) || slices.Contains(c.scopes, entities.ScopeWritePullRequests) {
+		return nil
+	}
+	return &entities.ErrForbidden{Reason: fmt.Sprintf("only leads of team %s or admins can choose a reviewer", teamName)}
+}
+
+func (c *caller) requireAdmin() error {
+	if !c.isAdmin {
+		return &entities.ErrForbidden{Reason: "only admins can do this"}
+	}
+	return nil
+}
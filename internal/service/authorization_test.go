@@ -0,0 +1,353 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+	"testing"
+
+	"service-pr-reviewer-assignment/internal/pkg/identity"
+	"service-pr-reviewer-assignment/internal/pkg/tenant"
+	"service-pr-reviewer-assignment/internal/service/entities"
+
+	"github.com/AlekSi/pointer"
+	"github.com/google/uuid"
+)
+
+// memoryStorage хранит команды, пользователей и PR одной организации в памяти.
+// Методы, которые не нужны тестам, не реализованы.
+type memoryStorage struct {
+	storage
+
+	teams     map[string]bool
+	users     map[uuid.UUID]entities.User
+	prs       map[uuid.UUID]entities.PullRequest
+	reviewers map[uuid.UUID][]uuid.UUID
+	audit     []entities.AuditLogEntry
+}
+
+func newMemoryStorage(teams []string, users []entities.User) *memoryStorage {
+	s := &memoryStorage{
+		teams:     make(map[string]bool),
+		users:     make(map[uuid.UUID]entities.User),
+		prs:       make(map[uuid.UUID]entities.PullRequest),
+		reviewers: make(map[uuid.UUID][]uuid.UUID),
+	}
+	for _, team := range teams {
+		s.teams[team] = true
+	}
+	for _, user := range users {
+		s.users[user.ID] = user
+	}
+	return s
+}
+
+func (s *memoryStorage) CreateOrganization(_ context.Context, org *entities.Organization) (*entities.Organization, error) {
+	return org, nil
+}
+
+func (s *memoryStorage) CreateAPIKey(_ context.Context, key *entities.APIKey, _ string) (*entities.APIKey, error) {
+	return key, nil
+}
+
+func (s *memoryStorage) CreateTeam(_ context.Context, teamName string) (*entities.Team, error) {
+	if s.teams[teamName] {
+		return nil, &entities.ErrTeamAlreadyExists{Name: teamName}
+	}
+	s.teams[teamName] = true
+	return &entities.Team{Name: teamName}, nil
+}
+
+func (s *memoryStorage) IsTeamExists(_ context.Context, teamName string) (bool, error) {
+	return s.teams[teamName], nil
+}
+
+func (s *memoryStorage) DeleteTeam(_ context.Context, teamName string) error {
+	if !s.teams[teamName] {
+		return &entities.ErrTeamNotFound{Name: teamName}
+	}
+	delete(s.teams, teamName)
+	for id, user := range s.users {
+		if user.TeamName == teamName {
+			user.TeamName = ""
+			s.users[id] = user
+		}
+	}
+	return nil
+}
+
+func (s *memoryStorage) CreateOrUpdateUsers(_ context.Context, users []entities.User) ([]entities.User, error) {
+	for i, user := range users {
+		if existing, ok := s.users[user.ID]; ok && user.Role == "" {
+			user.Role = existing.Role
+		}
+		if user.Role == "" {
+			user.Role = entities.UserRoleMember
+		}
+		s.users[user.ID] = user
+		users[i] = user
+	}
+	return users, nil
+}
+
+func (s *memoryStorage) GetUsersByTeamName(_ context.Context, teamName string) ([]entities.User, error) {
+	var users []entities.User
+	for _, id := range slices.SortedFunc(maps.Keys(s.users), compareIDs) {
+		if user := s.users[id]; user.TeamName == teamName {
+			users = append(users, user)
+		}
+	}
+	return users, nil
+}
+
+func (s *memoryStorage) GetUserByID(_ context.Context, userID uuid.UUID) (*entities.User, error) {
+	user, ok := s.users[userID]
+	if !ok {
+		return nil, &entities.ErrUserNotFound{UserID: &userID}
+	}
+	return &user, nil
+}
+
+func (s *memoryStorage) GetUsers(_ context.Context, filter entities.UserFilter, _, _ uint64) ([]entities.User, error) {
+	var users []entities.User
+	for _, id := range slices.SortedFunc(maps.Keys(s.users), compareIDs) {
+		user := s.users[id]
+		if (filter.Name == nil || user.Name == *filter.Name) && (filter.ExcludeID == nil || user.ID != *filter.ExcludeID) {
+			users = append(users, user)
+		}
+	}
+	return users, nil
+}
+
+func (s *memoryStorage) UpdateUser(_ context.Context, user *entities.User) (*entities.User, error) {
+	if _, ok := s.users[user.ID]; !ok {
+		return nil, &entities.ErrUserNotFound{UserID: &user.ID}
+	}
+	s.users[user.ID] = *user
+	return user, nil
+}
+
+func (s *memoryStorage) GetPullRequestByID(_ context.Context, id uuid.UUID) (*entities.PullRequest, error) {
+	pr, ok := s.prs[id]
+	if !ok {
+		return nil, &entities.ErrPullRequestNotFound{ID: id}
+	}
+	return &pr, nil
+}
+
+func (s *memoryStorage) GetPullRequestReviewerIDs(_ context.Context, pullRequestID uuid.UUID) ([]uuid.UUID, error) {
+	return slices.Clone(s.reviewers[pullRequestID]), nil
+}
+
+func (s *memoryStorage) CreatePullRequestReviewers(_ context.Context, pullRequestID uuid.UUID, reviewerIDs []uuid.UUID) error {
+	s.reviewers[pullRequestID] = append(s.reviewers[pullRequestID], reviewerIDs...)
+	return nil
+}
+
+func (s *memoryStorage) DeletePullRequestReviewersByReviewerID(_ context.Context, reviewerID uuid.UUID) error {
+	for id, reviewers := range s.reviewers {
+		s.reviewers[id] = slices.DeleteFunc(reviewers, func(r uuid.UUID) bool { return r == reviewerID })
+	}
+	return nil
+}
+
+func (s *memoryStorage) DeletePullRequestReviewerByPullRequestIDAndReviewerID(
+	_ context.Context,
+	pullRequestID uuid.UUID,
+	reviewerID uuid.UUID,
+) error {
+	s.reviewers[pullRequestID] = slices.DeleteFunc(s.reviewers[pullRequestID], func(r uuid.UUID) bool { return r == reviewerID })
+	return nil
+}
+
+func (s *memoryStorage) CreateAuditLogEntry(_ context.Context, entry *entities.AuditLogEntry) error {
+	s.audit = append(s.audit, *entry)
+	return nil
+}
+
+func compareIDs(a, b uuid.UUID) int {
+	return slices.Compare(a[:], b[:])
+}
+
+var (
+	adaID  = uuid.MustParse("a50e8400-e29b-41d4-a716-446655440001")
+	lenaID = uuid.MustParse("a50e8400-e29b-41d4-a716-446655440002")
+	maxID  = uuid.MustParse("a50e8400-e29b-41d4-a716-446655440003")
+	miaID  = uuid.MustParse("a50e8400-e29b-41d4-a716-446655440004")
+	piaID  = uuid.MustParse("a50e8400-e29b-41d4-a716-446655440005")
+	samID  = uuid.MustParse("a50e8400-e29b-41d4-a716-446655440006")
+	sidID  = uuid.MustParse("a50e8400-e29b-41d4-a716-446655440007")
+	prID   = uuid.MustParse("b50e8400-e29b-41d4-a716-446655440001")
+)
+
+// newRBACStorage команды payments (лид Lena) и search (лид Sam), администратор Ada вне команд
+// и PR участника payments Max, на ревью у Mia.
+func newRBACStorage() *memoryStorage {
+	s := newMemoryStorage([]string{"payments", "search"}, []entities.User{
+		{ID: adaID, Name: "Ada", IsActive: true, Role: entities.UserRoleOrgAdmin},
+		{ID: lenaID, Name: "Lena", TeamName: "payments", IsActive: true, Role: entities.UserRoleTeamLead},
+		{ID: maxID, Name: "Max", TeamName: "payments", IsActive: true, Role: entities.UserRoleMember},
+		{ID: miaID, Name: "Mia", TeamName: "payments", IsActive: true, Role: entities.UserRoleMember},
+		{ID: piaID, Name: "Pia", TeamName: "payments", IsActive: true, Role: entities.UserRoleMember},
+		{ID: samID, Name: "Sam", TeamName: "search", IsActive: true, Role: entities.UserRoleTeamLead},
+		{ID: sidID, Name: "Sid", TeamName: "search", IsActive: true, Role: entities.UserRoleMember},
+	})
+	s.prs[prID] = entities.PullRequest{ID: prID, Name: "Add search", AuthorID: maxID, Status: entities.PullRequestStatusOpen}
+	s.reviewers[prID] = []uuid.UUID{miaID}
+	return s
+}
+
+var rbacCallers = map[string]*entities.Identity{
+	"admin key":    {Subject: "api_key:admin", Scopes: []entities.Scope{entities.ScopeAdmin}},
+	"org admin":    userIdentity(adaID),
+	"lead":         userIdentity(lenaID),
+	"other lead":   userIdentity(samID),
+	"member":       userIdentity(maxID),
+	"unknown user": userIdentity(uuid.MustParse("a50e8400-e29b-41d4-a716-446655440099")),
+	"teams key":    {Subject: "api_key:teams", Scopes: []entities.Scope{entities.ScopeRead, entities.ScopeWriteTeams}},
+	"prs key":      {Subject: "api_key:prs", Scopes: []entities.Scope{entities.ScopeRead, entities.ScopeWritePullRequests}},
+}
+
+func userIdentity(id uuid.UUID) *entities.Identity {
+	return &entities.Identity{
+		Subject: "user:" + id.String(),
+		UserID:  pointer.To(id),
+		Scopes:  []entities.Scope{entities.ScopeRead, entities.ScopeWriteTeams, entities.ScopeWritePullRequests},
+	}
+}
+
+func TestAuthorization(t *testing.T) {
+	tests := []struct {
+		name    string
+		call    func(ctx context.Context, s *Service) error
+		allowed []string
+	}{
+		{
+			name: "deactivate another user",
+			call: func(ctx context.Context, s *Service) error {
+				_, err := s.SetUserActiveStatus(ctx, miaID, false)
+				return err
+			},
+			allowed: []string{"admin key", "org admin", "lead", "teams key"},
+		},
+		{
+			name: "deactivate yourself",
+			call: func(ctx context.Context, s *Service) error {
+				_, err := s.SetUserActiveStatus(ctx, maxID, false)
+				return err
+			},
+			allowed: []string{"admin key", "org admin", "lead", "member", "teams key"},
+		},
+		{
+			name: "rename another user",
+			call: func(ctx context.Context, s *Service) error {
+				_, err := s.UpdateUser(ctx, miaID, entities.UserUpdate{Name: pointer.To("Mira")})
+				return err
+			},
+			allowed: []string{"admin key", "org admin", "lead", "teams key"},
+		},
+		{
+			name: "remove a team member",
+			call: func(ctx context.Context, s *Service) error {
+				_, err := s.UpdateTeamMembers(ctx, "payments", entities.TeamMembersPatch{Remove: []uuid.UUID{miaID}})
+				return err
+			},
+			allowed: []string{"admin key", "org admin", "lead", "teams key"},
+		},
+		{
+			name: "take a member of another team",
+			call: func(ctx context.Context, s *Service) error {
+				_, err := s.UpdateTeamMembers(ctx, "payments", entities.TeamMembersPatch{Add: []uuid.UUID{sidID}})
+				return err
+			},
+			allowed: []string{"admin key", "org admin", "teams key"},
+		},
+		{
+			name: "create a team from team members",
+			call: func(ctx context.Context, s *Service) error {
+				_, err := s.CreateTeamWithUsers(ctx, "platform", []uuid.UUID{piaID})
+				return err
+			},
+			allowed: []string{"admin key", "org admin", "lead", "teams key"},
+		},
+		{
+			name: "import into an existing team",
+			call: func(ctx context.Context, s *Service) error {
+				_, err := s.ImportTeams(ctx, []entities.TeamImportRow{
+					{Row: 2, TeamName: "payments", UserID: uuid.New(), Username: "Uma", IsActive: true},
+				}, entities.TeamImportOptions{Upsert: true})
+				return err
+			},
+			allowed: []string{"admin key", "org admin", "lead", "teams key"},
+		},
+		{
+			name: "choose a reviewer",
+			call: func(ctx context.Context, s *Service) error {
+				_, _, _, err := s.ReassignReviewer(ctx, prID, miaID, pointer.To(piaID))
+				return err
+			},
+			allowed: []string{"admin key", "org admin", "lead", "prs key"},
+		},
+		{
+			name: "delete a team",
+			call: func(ctx context.Context, s *Service) error {
+				return s.DeleteTeam(ctx, "search")
+			},
+			allowed: []string{"admin key", "org admin"},
+		},
+		{
+			name: "create a user",
+			call: func(ctx context.Context, s *Service) error {
+				_, err := s.CreateUser(ctx, "Uma", true)
+				return err
+			},
+			allowed: []string{"admin key", "org admin"},
+		},
+		{
+			name: "set a role",
+			call: func(ctx context.Context, s *Service) error {
+				_, err := s.SetUserRole(ctx, maxID, entities.UserRoleTeamLead)
+				return err
+			},
+			allowed: []string{"admin key", "org admin"},
+		},
+		{
+			name: "create an organization",
+			call: func(ctx context.Context, s *Service) error {
+				_, _, _, err := s.CreateOrganization(ctx, "acme")
+				return err
+			},
+			allowed: []string{"admin key", "org admin"},
+		},
+	}
+
+	for _, tt := range tests {
+		for _, callerName := range slices.Sorted(maps.Keys(rbacCallers)) {
+			t.Run(tt.name+"/"+callerName, func(t *testing.T) {
+				s := Must(newRBACStorage(), directTxManager{}, noopMetrics{}, AssignmentPolicy{MaxReviewers: 2}, false)
+				ctx := tenant.WithOrgID(context.Background(), entities.DefaultOrganizationID)
+				ctx = identity.WithIdentity(ctx, rbacCallers[callerName])
+
+				err := tt.call(ctx, s)
+
+				var forbidden *entities.ErrForbidden
+				if slices.Contains(tt.allowed, callerName) {
+					if err != nil {
+						t.Errorf("err = %v, want allowed", err)
+					}
+				} else if !errors.As(err, &forbidden) {
+					t.Errorf("err = %v, want ErrForbidden", err)
+				}
+			})
+		}
+	}
+}
+
+func TestAuthorizationWithoutIdentity(t *testing.T) {
+	s := Must(newRBACStorage(), directTxManager{}, noopMetrics{}, AssignmentPolicy{MaxReviewers: 2}, false)
+
+	if err := s.DeleteTeam(context.Background(), "search"); err != nil {
+		t.Errorf("call without identity: %v, want admin rights", err)
+	}
+}
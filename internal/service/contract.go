@@ -10,14 +10,23 @@ import (
 )
 
 type storage interface {
+	// Organizations
+	CreateOrganization(ctx context.Context, organization *entities.Organization) (*entities.Organization, error)
+	GetOrganizationByID(ctx context.Context, id uuid.UUID) (*entities.Organization, error)
+
 	// Teams
 	CreateTeam(ctx context.Context, teamName string) (*entities.Team, error)
 	IsTeamExists(ctx context.Context, teamName string) (bool, error)
+	GetTeams(ctx context.Context, filter entities.TeamFilter, offset, limit uint64) ([]entities.Team, error)
+	CountTeams(ctx context.Context, filter entities.TeamFilter) (int, error)
+	DeleteTeam(ctx context.Context, teamName string) error
 
 	// Users
 	CreateOrUpdateUsers(ctx context.Context, users []entities.User) ([]entities.User, error)
 	GetUsersByTeamName(ctx context.Context, teamName string) ([]entities.User, error)
 	GetUserByID(ctx context.Context, userID uuid.UUID) (*entities.User, error)
+	GetUsers(ctx context.Context, filter entities.UserFilter, offset, limit uint64) ([]entities.User, error)
+	CountUsers(ctx context.Context, filter entities.UserFilter) (int, error)
 	IsUserExists(ctx context.Context, userID uuid.UUID) (bool, error)
 	UpdateUser(ctx context.Context, user *entities.User) (*entities.User, error)
 
@@ -30,6 +39,19 @@ type storage interface {
 	UpdatePullRequest(ctx context.Context, pullRequest *entities.PullRequest) (*entities.PullRequest, error)
 	DeletePullRequestReviewersByReviewerID(ctx context.Context, reviewerID uuid.UUID) error
 	DeletePullRequestReviewerByPullRequestIDAndReviewerID(ctx context.Context, pullRequestID uuid.UUID, reviewerID uuid.UUID) error
+
+	// APIKeys
+	CreateAPIKey(ctx context.Context, key *entities.APIKey, keyHash string) (*entities.APIKey, error)
+	GetAPIKeyByHash(ctx context.Context, keyHash string) (*entities.APIKey, error)
+	GetAPIKeys(ctx context.Context) ([]entities.APIKey, error)
+	RevokeAPIKey(ctx context.Context, id uuid.UUID, revokedAt time.Time) (*entities.APIKey, error)
+
+	// AuditLog
+	CreateAuditLogEntry(ctx context.Context, entry *entities.AuditLogEntry) error
+
+	// Events
+	CreateEvent(ctx context.Context, event *entities.Event) error
+	ListEventsAfter(ctx context.Context, afterID int64, filter entities.EventFilter, limit uint64) ([]entities.Event, error)
 }
 
 type txManager interface {
@@ -37,5 +59,11 @@ type txManager interface {
 	Write(ctx context.Context, fn func(ctx context.Context) error) error
 }
 
+type metrics interface {
+	ReviewersAssigned(count int)
+	NoReplacementCandidate()
+	PullRequestMerged()
+}
+
 // timeNowFunc для гибкой подмены в тестах
 var timeNowFunc = time.Now
@@ -0,0 +1,88 @@
+package entities
+
+import (
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// APIKeyPrefix начало секрета любого API-ключа. По нему ключ, переданный
+// bearer-токеном, отличается от JWT.
+const APIKeyPrefix = "prk_"
+
+// Scope задаёт право доступа API-ключа.
+type Scope string
+
+const (
+	// ScopeRead чтение команд, пользователей и PR.
+	ScopeRead Scope = "read"
+
+	// ScopeWriteTeams изменение команд и активности пользователей.
+	ScopeWriteTeams Scope = "write:teams"
+
+	// ScopeWritePullRequests создание, мердж и переназначение PR.
+	ScopeWritePullRequests Scope = "write:prs"
+
+	// ScopeAdmin управление API-ключами, включает все остальные scope.
+	ScopeAdmin Scope = "admin"
+)
+
+// IsValid сообщает, известен ли scope.
+func (s Scope) IsValid() bool {
+	switch s {
+	case ScopeRead, ScopeWriteTeams, ScopeWritePullRequests, ScopeAdmin:
+		return true
+	default:
+		return false
+	}
+}
+
+// APIKey представляет API-ключ. Сам секрет не хранится, только его хэш.
+type APIKey struct {
+	// ID уникальный идентификатор ключа.
+	ID uuid.UUID
+
+	// OrgID организация, к данным которой ключ даёт доступ.
+	OrgID uuid.UUID
+
+	// Name человекочитаемое имя ключа.
+	Name string
+
+	// Scopes права ключа.
+	Scopes []Scope
+
+	// CreatedAt время создания.
+	CreatedAt time.Time
+
+	// RevokedAt время отзыва, nil для действующего ключа.
+	RevokedAt *time.Time
+}
+
+// IsRevoked сообщает, отозван ли ключ.
+func (k *APIKey) IsRevoked() bool {
+	return k.RevokedAt != nil
+}
+
+// Identity описывает аутентифицированного вызывающего.
+type Identity struct {
+	// Subject идентификатор вызывающего для логов и аудита.
+	Subject string
+
+	// APIKeyID ключ, которым аутентифицирован вызов.
+	APIKeyID *uuid.UUID
+
+	// UserID пользователь сервиса, сопоставленный с claim-ом JWT.
+	UserID *uuid.UUID
+
+	// OrgID организация вызывающего, nil если аутентификация её не определяет.
+	OrgID *uuid.UUID
+
+	// Scopes права вызывающего.
+	Scopes []Scope
+}
+
+// HasScope сообщает, есть ли у вызывающего scope. Admin включает все остальные.
+func (i *Identity) HasScope(scope Scope) bool {
+	return slices.Contains(i.Scopes, ScopeAdmin) || slices.Contains(i.Scopes, scope)
+}
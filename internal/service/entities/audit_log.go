@@ -0,0 +1,37 @@
+package entities
+
+import "time"
+
+// AuditAction тип изменяющей операции.
+type AuditAction string
+
+const (
+	AuditActionOrganizationCreate  AuditAction = "organization.create"
+	AuditActionTeamCreate          AuditAction = "team.create"
+	AuditActionTeamUpdate          AuditAction = "team.update"
+	AuditActionTeamDelete          AuditAction = "team.delete"
+	AuditActionUserCreate          AuditAction = "user.create"
+	AuditActionUserUpdate          AuditAction = "user.update"
+	AuditActionUserSetIsActive     AuditAction = "user.set_is_active"
+	AuditActionUserSetRole         AuditAction = "user.set_role"
+	AuditActionPullRequestCreate   AuditAction = "pull_request.create"
+	AuditActionPullRequestMerge    AuditAction = "pull_request.merge"
+	AuditActionPullRequestReassign AuditAction = "pull_request.reassign"
+	AuditActionAPIKeyCreate        AuditAction = "api_key.create"
+	AuditActionAPIKeyRevoke        AuditAction = "api_key.revoke"
+)
+
+// AuditLogEntry запись о том, кто и когда выполнил изменяющую операцию.
+type AuditLogEntry struct {
+	// Actor идентификатор вызывающего, см. Identity.Subject.
+	Actor string
+
+	// Action выполненная операция.
+	Action AuditAction
+
+	// EntityID идентификатор изменённой сущности.
+	EntityID string
+
+	// CreatedAt время операции.
+	CreatedAt time.Time
+}
@@ -23,6 +23,14 @@ func (e *ErrTeamAlreadyExists) Error() string {
 	return fmt.Sprintf("team already exists: %s", e.Name)
 }
 
+type ErrUserAlreadyExists struct {
+	Name string
+}
+
+func (e *ErrUserAlreadyExists) Error() string {
+	return fmt.Sprintf("user already exists: %s", e.Name)
+}
+
 type ErrTeamNotFound struct {
 	Name string
 }
@@ -61,6 +69,21 @@ func (e *ErrTeamNameValidation) Error() string {
 	return fmt.Sprintf("team name is invalid: %s", e.Reason)
 }
 
+// ErrTeamImportValidation ошибки файла импорта команд, по одной на каждое нарушение.
+type ErrTeamImportValidation struct {
+	Errors []TeamImportRowError
+}
+
+func (e *ErrTeamImportValidation) Error() string {
+	if len(e.Errors) == 1 && e.Errors[0].Row > 0 {
+		return fmt.Sprintf("team import is invalid: row %d: %s", e.Errors[0].Row, e.Errors[0].Reason)
+	}
+	if len(e.Errors) == 1 {
+		return fmt.Sprintf("team import is invalid: %s", e.Errors[0].Reason)
+	}
+	return fmt.Sprintf("team import is invalid: %d errors", len(e.Errors))
+}
+
 type ErrPullRequestAlreadyExists struct {
 	ID uuid.UUID
 }
@@ -102,3 +125,75 @@ type ErrPullRequestNameValidation struct {
 func (e *ErrPullRequestNameValidation) Error() string {
 	return fmt.Sprintf("pull request name is invalid: %s", e.Reason)
 }
+
+var ErrInvalidCredentials = errors.New("invalid credentials")
+
+type ErrAPIKeyNotFound struct {
+	ID *uuid.UUID
+}
+
+func (e *ErrAPIKeyNotFound) Error() string {
+	if e.ID != nil {
+		return fmt.Sprintf("api key not found: %s", *e.ID)
+	}
+	return "api key not found"
+}
+
+type ErrAPIKeyValidation struct {
+	Reason string
+}
+
+func (e *ErrAPIKeyValidation) Error() string {
+	return fmt.Sprintf("api key is invalid: %s", e.Reason)
+}
+
+type ErrForbidden struct {
+	Reason string
+}
+
+func (e *ErrForbidden) Error() string {
+	return fmt.Sprintf("forbidden: %s", e.Reason)
+}
+
+type ErrUserRoleValidation struct {
+	Role UserRole
+}
+
+func (e *ErrUserRoleValidation) Error() string {
+	return fmt.Sprintf("unknown user role: %s", e.Role)
+}
+
+type ErrReassignTargetInvalid struct {
+	ID     uuid.UUID
+	Reason string
+}
+
+func (e *ErrReassignTargetInvalid) Error() string {
+	return fmt.Sprintf("user %s cannot be assigned as reviewer: %s", e.ID, e.Reason)
+}
+
+var ErrTenantNotResolved = errors.New("organization is not resolved")
+
+type ErrOrganizationNotFound struct {
+	ID uuid.UUID
+}
+
+func (e *ErrOrganizationNotFound) Error() string {
+	return fmt.Sprintf("organization not found: %s", e.ID)
+}
+
+type ErrOrganizationAlreadyExists struct {
+	Name string
+}
+
+func (e *ErrOrganizationAlreadyExists) Error() string {
+	return fmt.Sprintf("organization already exists: %s", e.Name)
+}
+
+type ErrOrganizationNameValidation struct {
+	Reason string
+}
+
+func (e *ErrOrganizationNameValidation) Error() string {
+	return fmt.Sprintf("organization name is invalid: %s", e.Reason)
+}
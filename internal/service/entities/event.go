@@ -0,0 +1,92 @@
+package entities
+
+import (
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EventType тип события об изменении назначений, которое получают подписчики /events/stream.
+type EventType string
+
+const (
+	// EventTypeReviewersAssigned PR создан и ему назначены ревьюверы.
+	EventTypeReviewersAssigned EventType = "pull_request.reviewers_assigned"
+
+	// EventTypeReviewerReassigned ревьювер PR заменён другим.
+	EventTypeReviewerReassigned EventType = "pull_request.reviewer_reassigned"
+
+	// EventTypePullRequestMerged PR смержен.
+	EventTypePullRequestMerged EventType = "pull_request.merged"
+
+	// EventTypeUserActivityChanged пользователь активирован или деактивирован.
+	// При деактивации он снимается со всех PR, где был ревьювером.
+	EventTypeUserActivityChanged EventType = "user.activity_changed"
+)
+
+// Event событие об изменении назначений. Поля заполняются в зависимости от типа.
+type Event struct {
+	// ID номер события, растёт в порядке коммита в пределах организации.
+	// Используется как Last-Event-ID.
+	ID int64
+
+	// Type тип события.
+	Type EventType
+
+	// TeamName команда, к которой относится событие: команда автора PR или пользователя.
+	TeamName string
+
+	// PullRequestID PR события, для событий пользователя не задан.
+	PullRequestID *uuid.UUID
+
+	// AuthorID автор PR.
+	AuthorID *uuid.UUID
+
+	// ReviewerIDs ревьюверы PR после изменения.
+	ReviewerIDs []uuid.UUID
+
+	// OldReviewerID и NewReviewerID заменённый и новый ревьювер при переназначении.
+	OldReviewerID *uuid.UUID
+	NewReviewerID *uuid.UUID
+
+	// UserID и IsActive пользователь и его новая активность для user.activity_changed.
+	UserID   *uuid.UUID
+	IsActive *bool
+
+	// CreatedAt время события.
+	CreatedAt time.Time
+}
+
+// UserIDs возвращает всех пользователей, которых касается событие: автора, ревьюверов,
+// заменённого ревьювера и пользователя, чья активность изменилась.
+func (e *Event) UserIDs() []uuid.UUID {
+	ids := slices.Clone(e.ReviewerIDs)
+	for _, id := range []*uuid.UUID{e.AuthorID, e.OldReviewerID, e.NewReviewerID, e.UserID} {
+		if id != nil && !slices.Contains(ids, *id) {
+			ids = append(ids, *id)
+		}
+	}
+	return ids
+}
+
+// EventFilter отбирает события для подписчика. Незаданные поля не ограничивают выборку.
+type EventFilter struct {
+	UserID        *uuid.UUID
+	TeamName      *string
+	PullRequestID *uuid.UUID
+}
+
+// Matches сообщает, подходит ли событие под фильтр.
+func (f EventFilter) Matches(e *Event) bool {
+	if f.UserID != nil && !slices.Contains(e.UserIDs(), *f.UserID) {
+		return false
+	}
+	if f.TeamName != nil && e.TeamName != *f.TeamName {
+		return false
+	}
+	if f.PullRequestID != nil && (e.PullRequestID == nil || *e.PullRequestID != *f.PullRequestID) {
+		return false
+	}
+	return true
+}
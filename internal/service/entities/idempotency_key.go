@@ -0,0 +1,29 @@
+package entities
+
+import "time"
+
+// IdempotencyKey представляет сохранённый результат запроса с ключом идемпотентности.
+type IdempotencyKey struct {
+	// Key значение заголовка Idempotency-Key.
+	Key string
+
+	// RequestHash хэш метода, пути и тела исходного запроса.
+	RequestHash string
+
+	// StatusCode HTTP-статус ответа, 0 пока запрос обрабатывается.
+	StatusCode int
+
+	// ContentType тип содержимого ответа.
+	ContentType string
+
+	// ResponseBody тело ответа.
+	ResponseBody []byte
+
+	// ExpiresAt время, после которого ключ может быть использован повторно.
+	ExpiresAt time.Time
+}
+
+// IsCompleted сообщает, сохранён ли ответ для ключа.
+func (k *IdempotencyKey) IsCompleted() bool {
+	return k.StatusCode != 0
+}
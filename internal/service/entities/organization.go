@@ -0,0 +1,24 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DefaultOrganizationID организация, к которой относятся данные, созданные до
+// появления организаций, и запросы без явно указанной организации.
+var DefaultOrganizationID = uuid.MustParse("00000000-0000-0000-0000-000000000001")
+
+// Organization изолированный арендатор: команды, пользователи и PR разных
+// организаций не видны друг другу.
+type Organization struct {
+	// ID уникальный идентификатор организации.
+	ID uuid.UUID
+
+	// Name уникальное имя организации.
+	Name string
+
+	// CreatedAt время создания.
+	CreatedAt time.Time
+}
@@ -1,5 +1,7 @@
 package entities
 
+import "github.com/google/uuid"
+
 // Team представляет команду — группу пользователей с уникальным именем.
 type Team struct {
 	// Name уникальное имя команды.
@@ -8,3 +10,18 @@ type Team struct {
 	// Members список пользователей команды.
 	Members []User
 }
+
+// TeamFilter условия выборки команд, nil означает отсутствие условия.
+type TeamFilter struct {
+	// Name точное имя команды.
+	Name *string
+}
+
+// TeamMembersPatch изменение состава команды. С Replace состав команды становится
+// равным Add, иначе Add добавляются в команду, а Remove исключаются из неё.
+// Исключённые пользователи остаются без команды.
+type TeamMembersPatch struct {
+	Replace bool
+	Add     []uuid.UUID
+	Remove  []uuid.UUID
+}
@@ -0,0 +1,59 @@
+package entities
+
+import "github.com/google/uuid"
+
+// TeamImportRow строка файла импорта: участник и команда, в которую он входит.
+type TeamImportRow struct {
+	// Row номер строки в файле, на него ссылаются ошибки импорта.
+	Row int
+
+	// TeamName имя команды.
+	TeamName string
+
+	// UserID идентификатор пользователя.
+	UserID uuid.UUID
+
+	// Username имя пользователя.
+	Username string
+
+	// IsActive флаг активности пользователя.
+	IsActive bool
+}
+
+// TeamImportOptions режим импорта команд.
+type TeamImportOptions struct {
+	// DryRun только проверить файл, ничего не меняя.
+	DryRun bool
+
+	// Upsert дополнять существующие команды участниками из файла вместо ошибки.
+	Upsert bool
+}
+
+// TeamImportResult итог импорта: команды в порядке их первого появления в файле.
+type TeamImportResult struct {
+	// DryRun импорт выполнен без изменений.
+	DryRun bool
+
+	// Teams импортированные команды.
+	Teams []TeamImportTeam
+}
+
+// TeamImportTeam команда из файла импорта.
+type TeamImportTeam struct {
+	Team
+
+	// Created команда создана импортом, иначе существующая команда дополнена.
+	Created bool
+}
+
+// TeamImportRowError нарушение в поле строки файла импорта.
+type TeamImportRowError struct {
+	// Row номер строки в файле.
+	Row int
+
+	// Field имя поля (колонки) строки.
+	Field string
+
+	// Reason описание нарушения.
+	Reason string
+}
@@ -2,6 +2,30 @@ package entities
 
 import "github.com/google/uuid"
 
+// UserRole роль пользователя, определяющая его права.
+type UserRole string
+
+const (
+	// UserRoleMember обычный участник команды.
+	UserRoleMember UserRole = "member"
+
+	// UserRoleTeamLead лид своей команды.
+	UserRoleTeamLead UserRole = "team_lead"
+
+	// UserRoleOrgAdmin администратор организации, имеет права во всех командах.
+	UserRoleOrgAdmin UserRole = "org_admin"
+)
+
+// IsValid сообщает, известна ли роль.
+func (r UserRole) IsValid() bool {
+	switch r {
+	case UserRoleMember, UserRoleTeamLead, UserRoleOrgAdmin:
+		return true
+	default:
+		return false
+	}
+}
+
 // User представляет участника команды.
 type User struct {
 	// ID уникальный идентификатор пользователя.
@@ -10,9 +34,31 @@ type User struct {
 	// Name имя пользователя.
 	Name string
 
-	// TeamName идентификатор команды пользователя.
+	// TeamName идентификатор команды пользователя. Пустая строка — пользователь
+	// ещё не добавлен в команду (например, создан через SCIM).
 	TeamName string
 
 	// IsActive флаг активности пользователя.
 	IsActive bool
+
+	// Role роль пользователя.
+	Role UserRole
+}
+
+// UserFilter условия выборки пользователей, nil означает отсутствие условия.
+type UserFilter struct {
+	// Name точное имя пользователя.
+	Name *string
+
+	// ExcludeID пользователь, который не попадает в выборку.
+	ExcludeID *uuid.UUID
+}
+
+// UserUpdate изменение пользователя, nil означает, что поле не меняется.
+type UserUpdate struct {
+	// Name новое имя пользователя.
+	Name *string
+
+	// IsActive новый флаг активности.
+	IsActive *bool
 }
@@ -0,0 +1,44 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"service-pr-reviewer-assignment/internal/service/entities"
+)
+
+// publish сохраняет событие для подписчиков /events/stream. Как и audit, вызывается
+// внутри транзакции операции: подписчики узнают о событии только после коммита.
+// Без потока событий их некому читать, и они не сохраняются.
+func (s *Service) publish(ctx context.Context, event *entities.Event) error {
+	if !s.publishEvents {
+		return nil
+	}
+
+	event.CreatedAt = timeNowFunc()
+
+	if err := s.storage.CreateEvent(ctx, event); err != nil {
+		return fmt.Errorf("publish %s: %w", event.Type, err)
+	}
+
+	return nil
+}
+
+// ListEventsAfter возвращает до limit событий с номером больше afterID, подходящих под filter.
+// Используется для возобновления потока по Last-Event-ID.
+func (s *Service) ListEventsAfter(
+	ctx context.Context,
+	afterID int64,
+	filter entities.EventFilter,
+	limit uint64,
+) (_ []entities.Event, err error) {
+	ctx, span := tracer.Start(ctx, "Service.ListEventsAfter")
+	defer func() { finishSpan(span, err) }()
+
+	events, err := s.storage.ListEventsAfter(ctx, afterID, filter, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list events: %w", err)
+	}
+
+	return events, nil
+}
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"service-pr-reviewer-assignment/internal/pkg/tenant"
+	"service-pr-reviewer-assignment/internal/service/entities"
+
+	"github.com/google/uuid"
+)
+
+// initialAPIKeyName имя admin-ключа, который создаётся вместе с организацией.
+const initialAPIKeyName = "initial admin"
+
+// CreateOrganization создаёт организацию и первый admin-ключ для неё. Организации
+// создают только администраторы организации по умолчанию.
+func (s *Service) CreateOrganization(
+	ctx context.Context,
+	name string,
+) (_ *entities.Organization, _ *entities.APIKey, _ string, err error) {
+	ctx, span := tracer.Start(ctx, "Service.CreateOrganization")
+	defer func() { finishSpan(span, err) }()
+
+	if err := validateOrganizationName(name); err != nil {
+		return nil, nil, "", fmt.Errorf("validate organization name: %w", err)
+	}
+
+	secret, err := generateAPIKeySecret()
+	if err != nil {
+		return nil, nil, "", fmt.Errorf("generate api key secret: %w", err)
+	}
+
+	var (
+		organization *entities.Organization
+		key          *entities.APIKey
+	)
+
+	err = s.txManager.Write(ctx, func(ctx context.Context) error {
+		caller, err := s.resolveCaller(ctx)
+		if err != nil {
+			return fmt.Errorf("resolve caller: %w", err)
+		}
+		if orgID, _ := tenant.FromContext(ctx); !caller.isAdmin || orgID != entities.DefaultOrganizationID {
+			return &entities.ErrForbidden{Reason: "only admins of the default organization can create organizations"}
+		}
+
+		organization, err = s.storage.CreateOrganization(ctx, &entities.Organization{
+			ID:        uuid.New(),
+			Name:      name,
+			CreatedAt: timeNowFunc(),
+		})
+		if err != nil {
+			return fmt.Errorf("create organization: %w", err)
+		}
+
+		if err := s.audit(ctx, entities.AuditActionOrganizationCreate, organization.ID.String()); err != nil {
+			return err
+		}
+
+		key, err = s.insertAPIKey(
+			tenant.WithOrgID(ctx, organization.ID),
+			initialAPIKeyName,
+			hashAPIKeySecret(secret),
+			[]entities.Scope{entities.ScopeAdmin},
+		)
+		return err
+	})
+	if err != nil {
+		return nil, nil, "", fmt.Errorf("create organization transaction: %w", err)
+	}
+
+	return organization, key, secret, nil
+}
+
+func (s *Service) GetOrganization(ctx context.Context, id uuid.UUID) (_ *entities.Organization, err error) {
+	ctx, span := tracer.Start(ctx, "Service.GetOrganization")
+	defer func() { finishSpan(span, err) }()
+
+	var organization *entities.Organization
+	err = s.txManager.Read(ctx, func(ctx context.Context) error {
+		var err error
+		organization, err = s.storage.GetOrganizationByID(ctx, id)
+		if err != nil {
+			return fmt.Errorf("get organization: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get organization: %w", err)
+	}
+
+	return organization, nil
+}
+
+func validateOrganizationName(name string) error {
+	if len(name) < 2 {
+		return &entities.ErrOrganizationNameValidation{Reason: "name too short"}
+	}
+	if len(name) > 100 {
+		return &entities.ErrOrganizationNameValidation{Reason: "name too long"}
+	}
+
+	return nil
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"service-pr-reviewer-assignment/internal/service/entities"
@@ -15,7 +16,11 @@ func (s *Service) CreatePullRequestAndAssignReviewers(
 	pullRequestID uuid.UUID,
 	pullRequestName string,
 	authorID uuid.UUID,
-) (*entities.PullRequest, []uuid.UUID, error) {
+	returnExisting bool,
+) (_ *entities.PullRequest, _ []uuid.UUID, err error) {
+	ctx, span := tracer.Start(ctx, "Service.CreatePullRequestAndAssignReviewers")
+	defer func() { finishSpan(span, err) }()
+
 	if err := validatePullRequestName(pullRequestName); err != nil {
 		return nil, nil, fmt.Errorf("invalid pull request name: %w", err)
 	}
@@ -23,9 +28,24 @@ func (s *Service) CreatePullRequestAndAssignReviewers(
 	var (
 		pr          *entities.PullRequest
 		reviewerIDs []uuid.UUID
+		created     bool
 	)
 
-	err := s.txManager.Write(ctx, func(ctx context.Context) error {
+	err = s.txManager.Write(ctx, func(ctx context.Context) error {
+		created = false
+
+		existing, existingReviewerIDs, err := s.getExistingPullRequest(ctx, pullRequestID, pullRequestName, authorID, returnExisting)
+		var notFound *entities.ErrPullRequestNotFound
+		switch {
+		case errors.As(err, &notFound):
+			// PR с таким id ещё нет, создаём новый.
+		case err != nil:
+			return err
+		default:
+			pr, reviewerIDs = existing, existingReviewerIDs
+			return nil
+		}
+
 		author, err := s.storage.GetUserByID(ctx, authorID)
 		if err != nil {
 			return fmt.Errorf("get author: %w", err)
@@ -49,27 +69,78 @@ func (s *Service) CreatePullRequestAndAssignReviewers(
 			return fmt.Errorf("create pull request: %w", err)
 		}
 
+		created = true
 		reviewerIDs = s.selectReviewers(authorID, teamMembers)
-		if len(reviewerIDs) == 0 {
-			return nil
+		if len(reviewerIDs) > 0 {
+			if err := s.storage.CreatePullRequestReviewers(ctx, pullRequestID, reviewerIDs); err != nil {
+				return fmt.Errorf("assign reviewers: %w", err)
+			}
 		}
 
-		if err := s.storage.CreatePullRequestReviewers(ctx, pullRequestID, reviewerIDs); err != nil {
-			return fmt.Errorf("assign reviewers: %w", err)
+		if err := s.audit(ctx, entities.AuditActionPullRequestCreate, pullRequestID.String()); err != nil {
+			return err
 		}
 
-		return nil
+		return s.publish(ctx, &entities.Event{
+			Type:          entities.EventTypeReviewersAssigned,
+			TeamName:      author.TeamName,
+			PullRequestID: &pr.ID,
+			AuthorID:      &pr.AuthorID,
+			ReviewerIDs:   reviewerIDs,
+		})
 	})
+
+	// Параллельный запрос успел создать тот же PR между проверкой и вставкой: вставка
+	// падает на уникальности, а транзакция прерывается, поэтому PR перечитывается в новой.
+	var alreadyExists *entities.ErrPullRequestAlreadyExists
+	if returnExisting && errors.As(err, &alreadyExists) {
+		err = s.txManager.Read(ctx, func(ctx context.Context) error {
+			var err error
+			pr, reviewerIDs, err = s.getExistingPullRequest(ctx, pullRequestID, pullRequestName, authorID, returnExisting)
+			return err
+		})
+	}
 	if err != nil {
 		return nil, nil, fmt.Errorf("create PR and assign reviewers: %w", err)
 	}
 
+	if created {
+		s.metrics.ReviewersAssigned(len(reviewerIDs))
+	}
+
 	return pr, reviewerIDs, nil
 }
 
-func (s *Service) selectReviewers(authorID uuid.UUID, team []entities.User) []uuid.UUID {
-	const maxReviewers = 2
+// getExistingPullRequest возвращает существующий PR с его ревьюверами, если returnExisting
+// и совпадают название и автор, иначе ErrPullRequestAlreadyExists. Если PR нет,
+// возвращает ErrPullRequestNotFound.
+func (s *Service) getExistingPullRequest(
+	ctx context.Context,
+	pullRequestID uuid.UUID,
+	pullRequestName string,
+	authorID uuid.UUID,
+	returnExisting bool,
+) (*entities.PullRequest, []uuid.UUID, error) {
+	existing, err := s.storage.GetPullRequestByID(ctx, pullRequestID)
+	var notFound *entities.ErrPullRequestNotFound
+	switch {
+	case errors.As(err, &notFound):
+		return nil, nil, err
+	case err != nil:
+		return nil, nil, fmt.Errorf("get pull request: %w", err)
+	case !returnExisting || existing.Name != pullRequestName || existing.AuthorID != authorID:
+		return nil, nil, &entities.ErrPullRequestAlreadyExists{ID: pullRequestID}
+	}
+
+	reviewerIDs, err := s.storage.GetPullRequestReviewerIDs(ctx, pullRequestID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("get reviewers: %w", err)
+	}
+
+	return existing, reviewerIDs, nil
+}
 
+func (s *Service) selectReviewers(authorID uuid.UUID, team []entities.User) []uuid.UUID {
 	var reviewers []uuid.UUID
 	for _, user := range team {
 		if user.ID == authorID || !user.IsActive {
@@ -77,7 +148,7 @@ func (s *Service) selectReviewers(authorID uuid.UUID, team []entities.User) []uu
 		}
 
 		reviewers = append(reviewers, user.ID)
-		if len(reviewers) == maxReviewers {
+		if len(reviewers) == s.policy.MaxReviewers {
 			break
 		}
 	}
@@ -88,13 +159,19 @@ func (s *Service) selectReviewers(authorID uuid.UUID, team []entities.User) []uu
 func (s *Service) MergePullRequestAndGetReviewers(
 	ctx context.Context,
 	pullRequestID uuid.UUID,
-) (*entities.PullRequest, []uuid.UUID, error) {
+) (_ *entities.PullRequest, _ []uuid.UUID, err error) {
+	ctx, span := tracer.Start(ctx, "Service.MergePullRequestAndGetReviewers")
+	defer func() { finishSpan(span, err) }()
+
 	var (
 		pullRequest *entities.PullRequest
 		reviewerIDs []uuid.UUID
+		merged      bool
 	)
 
-	err := s.txManager.Write(ctx, func(ctx context.Context) error {
+	err = s.txManager.Write(ctx, func(ctx context.Context) error {
+		merged = false
+
 		var err error
 		pullRequest, err = s.storage.GetPullRequestByID(ctx, pullRequestID)
 		if err != nil {
@@ -116,12 +193,32 @@ func (s *Service) MergePullRequestAndGetReviewers(
 			return fmt.Errorf("update pull request: %w", err)
 		}
 
-		return nil
+		merged = true
+		if err := s.audit(ctx, entities.AuditActionPullRequestMerge, pullRequestID.String()); err != nil {
+			return err
+		}
+
+		author, err := s.storage.GetUserByID(ctx, pullRequest.AuthorID)
+		if err != nil {
+			return fmt.Errorf("get author: %w", err)
+		}
+
+		return s.publish(ctx, &entities.Event{
+			Type:          entities.EventTypePullRequestMerged,
+			TeamName:      author.TeamName,
+			PullRequestID: &pullRequest.ID,
+			AuthorID:      &pullRequest.AuthorID,
+			ReviewerIDs:   reviewerIDs,
+		})
 	})
 	if err != nil {
 		return nil, nil, fmt.Errorf("merge pull request: %w", err)
 	}
 
+	if merged {
+		s.metrics.PullRequestMerged()
+	}
+
 	return pullRequest, reviewerIDs, nil
 }
 
@@ -129,14 +226,18 @@ func (s *Service) ReassignReviewer(
 	ctx context.Context,
 	pullRequestID uuid.UUID,
 	oldReviewerID uuid.UUID,
-) (*entities.PullRequest, []uuid.UUID, uuid.UUID, error) {
+	requestedReviewerID *uuid.UUID,
+) (_ *entities.PullRequest, _ []uuid.UUID, _ uuid.UUID, err error) {
+	ctx, span := tracer.Start(ctx, "Service.ReassignReviewer")
+	defer func() { finishSpan(span, err) }()
+
 	var (
 		pr            *entities.PullRequest
 		reviewerIDs   []uuid.UUID
 		newReviewerID uuid.UUID
 	)
 
-	err := s.txManager.Write(ctx, func(ctx context.Context) error {
+	err = s.txManager.Write(ctx, func(ctx context.Context) error {
 		pullRequest, err := s.storage.GetPullRequestByID(ctx, pullRequestID)
 		if err != nil {
 			return fmt.Errorf("get pull request: %w", err)
@@ -165,9 +266,26 @@ func (s *Service) ReassignReviewer(
 			return &entities.ErrReviewerNotAssigned{ID: oldReviewerID}
 		}
 
-		newReviewerID = s.findReplacementCandidate(oldReviewerID, currentReviewerIDs, teamMembers)
-		if newReviewerID == uuid.Nil {
-			return entities.ErrNoReplacementCandidate
+		if requestedReviewerID != nil {
+			caller, err := s.resolveCaller(ctx)
+			if err != nil {
+				return fmt.Errorf("resolve caller: %w", err)
+			}
+			if err := caller.requireReviewerChooser(oldReviewer.TeamName); err != nil {
+				return err
+			}
+
+			if err := validateRequestedReviewer(
+				*requestedReviewerID, pullRequest.AuthorID, currentReviewerIDs, teamMembers,
+			); err != nil {
+				return err
+			}
+			newReviewerID = *requestedReviewerID
+		} else {
+			newReviewerID = s.findReplacementCandidate(oldReviewerID, currentReviewerIDs, teamMembers)
+			if newReviewerID == uuid.Nil {
+				return entities.ErrNoReplacementCandidate
+			}
 		}
 
 		if err := s.storage.DeletePullRequestReviewerByPullRequestIDAndReviewerID(
@@ -184,12 +302,29 @@ func (s *Service) ReassignReviewer(
 
 		reviewerIDs = replaceReviewer(currentReviewerIDs, oldReviewerID, newReviewerID)
 		pr = pullRequest
-		return nil
+		if err := s.audit(ctx, entities.AuditActionPullRequestReassign, pullRequestID.String()); err != nil {
+			return err
+		}
+
+		return s.publish(ctx, &entities.Event{
+			Type:          entities.EventTypeReviewerReassigned,
+			TeamName:      oldReviewer.TeamName,
+			PullRequestID: &pr.ID,
+			AuthorID:      &pr.AuthorID,
+			ReviewerIDs:   reviewerIDs,
+			OldReviewerID: &oldReviewerID,
+			NewReviewerID: &newReviewerID,
+		})
 	})
 	if err != nil {
+		if errors.Is(err, entities.ErrNoReplacementCandidate) {
+			s.metrics.NoReplacementCandidate()
+		}
 		return nil, nil, uuid.Nil, fmt.Errorf("reassign reviewer: %w", err)
 	}
 
+	s.metrics.ReviewersAssigned(1)
+
 	return pr, reviewerIDs, newReviewerID, nil
 }
 
@@ -221,6 +356,34 @@ func (s *Service) findReplacementCandidate(
 	return uuid.Nil
 }
 
+// validateRequestedReviewer проверяет явно выбранного ревьювера по тем же правилам,
+// что и автоматический выбор: активный участник команды, не автор и не назначен ранее.
+func validateRequestedReviewer(
+	reviewerID uuid.UUID,
+	authorID uuid.UUID,
+	currentReviewerIDs []uuid.UUID,
+	teamMembers []entities.User,
+) error {
+	if reviewerID == authorID {
+		return &entities.ErrReassignTargetInvalid{ID: reviewerID, Reason: "user is the author"}
+	}
+	if contains(currentReviewerIDs, reviewerID) {
+		return &entities.ErrReassignTargetInvalid{ID: reviewerID, Reason: "user is already assigned"}
+	}
+
+	for _, user := range teamMembers {
+		if user.ID != reviewerID {
+			continue
+		}
+		if !user.IsActive {
+			return &entities.ErrReassignTargetInvalid{ID: reviewerID, Reason: "user is inactive"}
+		}
+		return nil
+	}
+
+	return &entities.ErrReassignTargetInvalid{ID: reviewerID, Reason: "user is not in the reviewer's team"}
+}
+
 func replaceReviewer(reviewerIDs []uuid.UUID, oldID, newID uuid.UUID) []uuid.UUID {
 	result := make([]uuid.UUID, 0, len(reviewerIDs))
 	for _, id := range reviewerIDs {
@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"service-pr-reviewer-assignment/internal/service/entities"
+
+	"github.com/google/uuid"
+)
+
+// racingStorage имитирует параллельный запрос, который создал PR между проверкой
+// существования и вставкой.
+type racingStorage struct {
+	storage
+
+	existing *entities.PullRequest
+	inserted bool
+}
+
+func (s *racingStorage) GetPullRequestByID(_ context.Context, id uuid.UUID) (*entities.PullRequest, error) {
+	if !s.inserted {
+		return nil, &entities.ErrPullRequestNotFound{ID: id}
+	}
+	return s.existing, nil
+}
+
+func (s *racingStorage) GetUserByID(_ context.Context, userID uuid.UUID) (*entities.User, error) {
+	return &entities.User{ID: userID, Name: "Alice", TeamName: "payments", IsActive: true}, nil
+}
+
+func (s *racingStorage) GetUsersByTeamName(context.Context, string) ([]entities.User, error) {
+	return nil, nil
+}
+
+func (s *racingStorage) CreatePullRequest(_ context.Context, pr *entities.PullRequest) (*entities.PullRequest, error) {
+	s.inserted = true
+	return nil, &entities.ErrPullRequestAlreadyExists{ID: pr.ID}
+}
+
+func (s *racingStorage) GetPullRequestReviewerIDs(context.Context, uuid.UUID) ([]uuid.UUID, error) {
+	return []uuid.UUID{reviewerID}, nil
+}
+
+type directTxManager struct{}
+
+func (directTxManager) Read(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func (directTxManager) Write(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+type noopMetrics struct{}
+
+func (noopMetrics) ReviewersAssigned(int)   {}
+func (noopMetrics) NoReplacementCandidate() {}
+func (noopMetrics) PullRequestMerged()      {}
+
+var (
+	pullRequestID = uuid.MustParse("450e8400-e29b-41d4-a716-446655440001")
+	authorID      = uuid.MustParse("550e8400-e29b-41d4-a716-446655440001")
+	reviewerID    = uuid.MustParse("550e8400-e29b-41d4-a716-446655440002")
+)
+
+func TestCreatePullRequestReturnExistingAfterRace(t *testing.T) {
+	tests := []struct {
+		name           string
+		existingName   string
+		returnExisting bool
+		wantExisting   bool
+	}{
+		{name: "return existing", existingName: "Add search", returnExisting: true, wantExisting: true},
+		{name: "different name", existingName: "Other", returnExisting: true},
+		{name: "return existing not requested", existingName: "Add search"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage := &racingStorage{existing: &entities.PullRequest{
+				ID:       pullRequestID,
+				Name:     tt.existingName,
+				AuthorID: authorID,
+				Status:   entities.PullRequestStatusOpen,
+			}}
+			s := Must(storage, directTxManager{}, noopMetrics{}, AssignmentPolicy{MaxReviewers: 2}, false)
+
+			pr, reviewerIDs, err := s.CreatePullRequestAndAssignReviewers(
+				context.Background(), pullRequestID, "Add search", authorID, tt.returnExisting,
+			)
+
+			if !tt.wantExisting {
+				var alreadyExists *entities.ErrPullRequestAlreadyExists
+				if !errors.As(err, &alreadyExists) {
+					t.Fatalf("err = %v, want ErrPullRequestAlreadyExists", err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if pr != storage.existing {
+				t.Errorf("pr = %+v, want the existing pull request", pr)
+			}
+			if len(reviewerIDs) != 1 || reviewerIDs[0] != reviewerID {
+				t.Errorf("reviewerIDs = %v, want [%s]", reviewerIDs, reviewerID)
+			}
+		})
+	}
+}
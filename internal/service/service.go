@@ -1,13 +1,25 @@
 package service
 
+// AssignmentPolicy параметры автоматического назначения ревьюверов.
+type AssignmentPolicy struct {
+	MaxReviewers int
+}
+
 type Service struct {
-	storage   storage
-	txManager txManager
+	storage       storage
+	txManager     txManager
+	metrics       metrics
+	policy        AssignmentPolicy
+	publishEvents bool
 }
 
-func Must(storage storage, txManager txManager) *Service {
+// Must создаёт сервис. С выключенным publishEvents события для /events/stream не сохраняются.
+func Must(storage storage, txManager txManager, metrics metrics, policy AssignmentPolicy, publishEvents bool) *Service {
 	return &Service{
-		storage:   storage,
-		txManager: txManager,
+		storage:       storage,
+		txManager:     txManager,
+		metrics:       metrics,
+		policy:        policy,
+		publishEvents: publishEvents,
 	}
 }
@@ -0,0 +1,199 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"service-pr-reviewer-assignment/internal/service/entities"
+
+	"github.com/google/uuid"
+)
+
+// maxTeamImportRows ограничивает размер импорта: все участники команды вставляются
+// одним запросом, число параметров которого ограничено в Postgres.
+const maxTeamImportRows = 5000
+
+// plannedTeam команда из файла импорта вместе со строкой, где она встретилась впервые.
+type plannedTeam struct {
+	row  int
+	team entities.TeamImportTeam
+}
+
+// ImportTeams создаёт команды с участниками из файла импорта. Сначала проверяются все
+// строки и команды, и при любом нарушении возвращается ErrTeamImportValidation со всеми
+// ошибками сразу. Затем команды создаются в одной транзакции, а с DryRun — только проверяются.
+func (s *Service) ImportTeams(
+	ctx context.Context,
+	rows []entities.TeamImportRow,
+	opts entities.TeamImportOptions,
+) (_ *entities.TeamImportResult, err error) {
+	ctx, span := tracer.Start(ctx, "Service.ImportTeams")
+	defer func() { finishSpan(span, err) }()
+
+	teams, err := planTeamImport(rows)
+	if err != nil {
+		return nil, fmt.Errorf("validate rows: %w", err)
+	}
+
+	importTeams := func(ctx context.Context) error {
+		if err := s.checkTeamImport(ctx, teams, opts.Upsert); err != nil {
+			return err
+		}
+		if opts.DryRun {
+			return nil
+		}
+
+		for i := range teams {
+			if err := s.applyTeamImport(ctx, &teams[i].team); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if opts.DryRun {
+		err = s.txManager.Read(ctx, importTeams)
+	} else {
+		err = s.txManager.Write(ctx, importTeams)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("import teams transaction: %w", err)
+	}
+
+	result := &entities.TeamImportResult{
+		DryRun: opts.DryRun,
+		Teams:  make([]entities.TeamImportTeam, 0, len(teams)),
+	}
+	for _, planned := range teams {
+		result.Teams = append(result.Teams, planned.team)
+	}
+
+	return result, nil
+}
+
+// planTeamImport проверяет строки без обращения к базе и группирует участников по командам.
+func planTeamImport(rows []entities.TeamImportRow) ([]plannedTeam, error) {
+	if len(rows) == 0 {
+		return nil, &entities.ErrTeamImportValidation{Errors: []entities.TeamImportRowError{
+			{Field: "team", Reason: "import file has no rows"},
+		}}
+	}
+	if len(rows) > maxTeamImportRows {
+		return nil, &entities.ErrTeamImportValidation{Errors: []entities.TeamImportRowError{
+			{Row: rows[maxTeamImportRows].Row, Field: "team", Reason: fmt.Sprintf("import is limited to %d rows", maxTeamImportRows)},
+		}}
+	}
+
+	var rowErrors []entities.TeamImportRowError
+	var teams []plannedTeam
+	teamIndex := make(map[string]int)
+	userRows := make(map[uuid.UUID]int, len(rows))
+
+	for _, row := range rows {
+		valid := true
+		if err := validateTeamName(row.TeamName); err != nil {
+			rowErrors = append(rowErrors, entities.TeamImportRowError{Row: row.Row, Field: "team", Reason: err.Error()})
+			valid = false
+		}
+		if err := validateUsername(row.Username); err != nil {
+			rowErrors = append(rowErrors, entities.TeamImportRowError{Row: row.Row, Field: "username", Reason: err.Error()})
+			valid = false
+		}
+		if first, ok := userRows[row.UserID]; ok {
+			rowErrors = append(rowErrors, entities.TeamImportRowError{
+				Row:    row.Row,
+				Field:  "user_id",
+				Reason: fmt.Sprintf("user %s is already listed in row %d", row.UserID, first),
+			})
+			valid = false
+		} else {
+			userRows[row.UserID] = row.Row
+		}
+		if !valid {
+			continue
+		}
+
+		i, ok := teamIndex[row.TeamName]
+		if !ok {
+			i = len(teams)
+			teamIndex[row.TeamName] = i
+			teams = append(teams, plannedTeam{
+				row:  row.Row,
+				team: entities.TeamImportTeam{Team: entities.Team{Name: row.TeamName}},
+			})
+		}
+		teams[i].team.Members = append(teams[i].team.Members, entities.User{
+			ID:       row.UserID,
+			Name:     row.Username,
+			TeamName: row.TeamName,
+			IsActive: row.IsActive,
+		})
+	}
+
+	if len(rowErrors) > 0 {
+		return nil, &entities.ErrTeamImportValidation{Errors: rowErrors}
+	}
+
+	return teams, nil
+}
+
+// checkTeamImport сверяет команды с базой и проверяет права вызывающего.
+// Отмечает, какие команды будут созданы.
+func (s *Service) checkTeamImport(ctx context.Context, teams []plannedTeam, upsert bool) error {
+	caller, err := s.resolveCaller(ctx)
+	if err != nil {
+		return fmt.Errorf("resolve caller: %w", err)
+	}
+
+	var rowErrors []entities.TeamImportRowError
+	for i := range teams {
+		team := &teams[i].team
+
+		exists, err := s.storage.IsTeamExists(ctx, team.Name)
+		if err != nil {
+			return fmt.Errorf("check team exists: %w", err)
+		}
+		if exists && !upsert {
+			rowErrors = append(rowErrors, entities.TeamImportRowError{
+				Row:    teams[i].row,
+				Field:  "team",
+				Reason: (&entities.ErrTeamAlreadyExists{Name: team.Name}).Error(),
+			})
+			continue
+		}
+		team.Created = !exists
+
+		if exists {
+			if err := caller.requireTeamManager(team.Name); err != nil {
+				return err
+			}
+		}
+		if err := s.authorizeMembersMove(ctx, team.Name, team.Members); err != nil {
+			return err
+		}
+	}
+
+	if len(rowErrors) > 0 {
+		return &entities.ErrTeamImportValidation{Errors: rowErrors}
+	}
+
+	return nil
+}
+
+func (s *Service) applyTeamImport(ctx context.Context, team *entities.TeamImportTeam) error {
+	action := entities.AuditActionTeamUpdate
+	if team.Created {
+		if _, err := s.storage.CreateTeam(ctx, team.Name); err != nil {
+			return fmt.Errorf("create team: %w", err)
+		}
+		action = entities.AuditActionTeamCreate
+	}
+
+	members, err := s.storage.CreateOrUpdateUsers(ctx, team.Members)
+	if err != nil {
+		return fmt.Errorf("create or update users: %w", err)
+	}
+	team.Members = members
+
+	return s.audit(ctx, action, team.Name)
+}
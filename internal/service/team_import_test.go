@@ -0,0 +1,216 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"service-pr-reviewer-assignment/internal/pkg/identity"
+	"service-pr-reviewer-assignment/internal/pkg/tenant"
+	"service-pr-reviewer-assignment/internal/service/entities"
+
+	"github.com/google/uuid"
+)
+
+var (
+	umaID = uuid.MustParse("c50e8400-e29b-41d4-a716-446655440001")
+	tomID = uuid.MustParse("c50e8400-e29b-41d4-a716-446655440002")
+	kaiID = uuid.MustParse("c50e8400-e29b-41d4-a716-446655440003")
+)
+
+// rowErrors возвращает нарушения ErrTeamImportValidation в виде "строка/поле".
+func rowErrors(t *testing.T, err error) []string {
+	t.Helper()
+
+	var validation *entities.ErrTeamImportValidation
+	if !errors.As(err, &validation) {
+		t.Fatalf("err = %v, want ErrTeamImportValidation", err)
+	}
+	result := make([]string, 0, len(validation.Errors))
+	for _, rowErr := range validation.Errors {
+		result = append(result, fmt.Sprintf("%d/%s", rowErr.Row, rowErr.Field))
+	}
+	return result
+}
+
+func TestPlanTeamImportGroupsMembersByTeam(t *testing.T) {
+	teams, err := planTeamImport([]entities.TeamImportRow{
+		{Row: 2, TeamName: "platform", UserID: umaID, Username: "Uma", IsActive: true},
+		{Row: 3, TeamName: "mobile", UserID: tomID, Username: "Tom", IsActive: true},
+		{Row: 4, TeamName: "platform", UserID: kaiID, Username: "Kai"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(teams) != 2 || teams[0].team.Name != "platform" || teams[1].team.Name != "mobile" {
+		t.Fatalf("teams = %+v, want platform and mobile in file order", teams)
+	}
+	if teams[0].row != 2 || teams[1].row != 3 {
+		t.Errorf("first rows = %d, %d, want 2, 3", teams[0].row, teams[1].row)
+	}
+	platform := teams[0].team.Members
+	if len(platform) != 2 || platform[0].ID != umaID || platform[1].ID != kaiID {
+		t.Fatalf("platform members = %+v", platform)
+	}
+	if platform[1].TeamName != "platform" || platform[1].IsActive {
+		t.Errorf("member = %+v, want an inactive member of platform", platform[1])
+	}
+}
+
+func TestPlanTeamImportCollectsAllErrors(t *testing.T) {
+	tooMany := make([]entities.TeamImportRow, maxTeamImportRows+1)
+	for i := range tooMany {
+		tooMany[i] = entities.TeamImportRow{Row: i + 2, TeamName: "platform", UserID: uuid.New(), Username: "Uma"}
+	}
+
+	tests := []struct {
+		name string
+		rows []entities.TeamImportRow
+		want string
+	}{
+		{name: "no rows", want: "[0/team]"},
+		{name: "too many rows", rows: tooMany, want: fmt.Sprintf("[%d/team]", maxTeamImportRows+2)},
+		{
+			name: "invalid rows",
+			rows: []entities.TeamImportRow{
+				{Row: 2, TeamName: "platform", UserID: umaID, Username: "Uma"},
+				{Row: 3, TeamName: "p", UserID: tomID, Username: "Tom1"},
+				{Row: 4, TeamName: "mobile", UserID: umaID, Username: "Uma"},
+			},
+			want: "[3/team 3/username 4/user_id]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := planTeamImport(tt.rows)
+			if got := fmt.Sprint(rowErrors(t, err)); got != tt.want {
+				t.Errorf("errors = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+// newImportService сервис поверх newRBACStorage, вызывающий — администратор организации.
+func newImportService(t *testing.T) (*Service, *memoryStorage, context.Context) {
+	t.Helper()
+
+	storage := newRBACStorage()
+	s := Must(storage, directTxManager{}, noopMetrics{}, AssignmentPolicy{MaxReviewers: 2}, false)
+	ctx := tenant.WithOrgID(context.Background(), entities.DefaultOrganizationID)
+	ctx = identity.WithIdentity(ctx, userIdentity(adaID))
+	return s, storage, ctx
+}
+
+func TestImportTeamsCreatesTeams(t *testing.T) {
+	s, storage, ctx := newImportService(t)
+
+	result, err := s.ImportTeams(ctx, []entities.TeamImportRow{
+		{Row: 2, TeamName: "platform", UserID: umaID, Username: "Uma", IsActive: true},
+		{Row: 3, TeamName: "platform", UserID: tomID, Username: "Tom", IsActive: true},
+	}, entities.TeamImportOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result.DryRun || len(result.Teams) != 1 || !result.Teams[0].Created || len(result.Teams[0].Members) != 2 {
+		t.Fatalf("result = %+v", result)
+	}
+	if !storage.teams["platform"] || storage.users[umaID].TeamName != "platform" || storage.users[tomID].Role != entities.UserRoleMember {
+		t.Errorf("platform was not created with its members: %+v", storage.users)
+	}
+	if len(storage.audit) != 1 || storage.audit[0].Action != entities.AuditActionTeamCreate || storage.audit[0].EntityID != "platform" {
+		t.Errorf("audit = %+v, want one team.create", storage.audit)
+	}
+}
+
+func TestImportTeamsDryRun(t *testing.T) {
+	s, storage, ctx := newImportService(t)
+
+	result, err := s.ImportTeams(ctx, []entities.TeamImportRow{
+		{Row: 2, TeamName: "platform", UserID: umaID, Username: "Uma", IsActive: true},
+		{Row: 3, TeamName: "payments", UserID: tomID, Username: "Tom", IsActive: true},
+	}, entities.TeamImportOptions{DryRun: true, Upsert: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !result.DryRun || len(result.Teams) != 2 || !result.Teams[0].Created || result.Teams[1].Created {
+		t.Errorf("result = %+v, want platform created and payments updated", result)
+	}
+	if storage.teams["platform"] {
+		t.Error("dry run created a team")
+	}
+	if _, ok := storage.users[umaID]; ok {
+		t.Error("dry run created a user")
+	}
+	if len(storage.audit) != 0 {
+		t.Errorf("dry run wrote audit entries: %+v", storage.audit)
+	}
+}
+
+func TestImportTeamsDryRunReportsExistingTeams(t *testing.T) {
+	s, _, ctx := newImportService(t)
+
+	_, err := s.ImportTeams(ctx, []entities.TeamImportRow{
+		{Row: 2, TeamName: "platform", UserID: umaID, Username: "Uma", IsActive: true},
+		{Row: 3, TeamName: "payments", UserID: tomID, Username: "Tom", IsActive: true},
+		{Row: 4, TeamName: "search", UserID: kaiID, Username: "Kai", IsActive: true},
+	}, entities.TeamImportOptions{DryRun: true})
+
+	if got := fmt.Sprint(rowErrors(t, err)); got != "[3/team 4/team]" {
+		t.Errorf("errors = %s, want the first rows of payments and search", got)
+	}
+}
+
+func TestImportTeamsWithoutUpsertChangesNothing(t *testing.T) {
+	s, storage, ctx := newImportService(t)
+
+	_, err := s.ImportTeams(ctx, []entities.TeamImportRow{
+		{Row: 2, TeamName: "platform", UserID: umaID, Username: "Uma", IsActive: true},
+		{Row: 3, TeamName: "payments", UserID: tomID, Username: "Tom", IsActive: true},
+	}, entities.TeamImportOptions{})
+
+	if got := fmt.Sprint(rowErrors(t, err)); got != "[3/team]" {
+		t.Errorf("errors = %s, want [3/team]", got)
+	}
+	if storage.teams["platform"] || len(storage.audit) != 0 {
+		t.Error("a failed import changed the storage")
+	}
+}
+
+func TestImportTeamsUpsert(t *testing.T) {
+	s, storage, ctx := newImportService(t)
+
+	result, err := s.ImportTeams(ctx, []entities.TeamImportRow{
+		{Row: 2, TeamName: "payments", UserID: umaID, Username: "Uma", IsActive: true},
+		{Row: 3, TeamName: "payments", UserID: maxID, Username: "Max"},
+		{Row: 4, TeamName: "platform", UserID: tomID, Username: "Tom", IsActive: true},
+	}, entities.TeamImportOptions{Upsert: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(result.Teams) != 2 || result.Teams[0].Created || !result.Teams[1].Created {
+		t.Fatalf("result = %+v, want payments updated and platform created", result)
+	}
+
+	// Участники из файла добавлены или обновлены, остальные участники команды остались.
+	payments, _ := storage.GetUsersByTeamName(ctx, "payments")
+	if len(payments) != 5 {
+		t.Errorf("payments has %d members, want 5", len(payments))
+	}
+	if maxUser := storage.users[maxID]; maxUser.IsActive || maxUser.Role != entities.UserRoleMember {
+		t.Errorf("Max = %+v, want an inactive member", maxUser)
+	}
+	if lena := storage.users[lenaID]; lena.TeamName != "payments" || lena.Role != entities.UserRoleTeamLead {
+		t.Errorf("Lena = %+v, want the lead of payments", lena)
+	}
+
+	want := []entities.AuditAction{entities.AuditActionTeamUpdate, entities.AuditActionTeamCreate}
+	if len(storage.audit) != len(want) || storage.audit[0].Action != want[0] || storage.audit[1].Action != want[1] {
+		t.Errorf("audit = %+v, want %v", storage.audit, want)
+	}
+}
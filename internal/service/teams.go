@@ -2,17 +2,23 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"unicode"
 
 	"service-pr-reviewer-assignment/internal/service/entities"
+
+	"github.com/google/uuid"
 )
 
 func (s *Service) CreateTeam(
 	ctx context.Context,
 	teamName string,
 	members []entities.User,
-) (*entities.Team, error) {
+) (_ *entities.Team, err error) {
+	ctx, span := tracer.Start(ctx, "Service.CreateTeam")
+	defer func() { finishSpan(span, err) }()
+
 	if err := validateTeamName(teamName); err != nil {
 		return nil, fmt.Errorf("validate team name: %w", err)
 	}
@@ -24,7 +30,11 @@ func (s *Service) CreateTeam(
 	}
 
 	var updatedMembers []entities.User
-	err := s.txManager.Write(ctx, func(ctx context.Context) error {
+	err = s.txManager.Write(ctx, func(ctx context.Context) error {
+		if err := s.authorizeMembersMove(ctx, teamName, members); err != nil {
+			return err
+		}
+
 		if _, err := s.storage.CreateTeam(ctx, teamName); err != nil {
 			return fmt.Errorf("create team: %w", err)
 		}
@@ -38,7 +48,8 @@ func (s *Service) CreateTeam(
 		if err != nil {
 			return fmt.Errorf("create or update users: %w", err)
 		}
-		return nil
+
+		return s.audit(ctx, entities.AuditActionTeamCreate, teamName)
 	})
 	if err != nil {
 		return nil, fmt.Errorf("create team transaction: %w", err)
@@ -53,9 +64,12 @@ func (s *Service) CreateTeam(
 func (s *Service) GetTeam(
 	ctx context.Context,
 	teamName string,
-) (*entities.Team, error) {
+) (_ *entities.Team, err error) {
+	ctx, span := tracer.Start(ctx, "Service.GetTeam")
+	defer func() { finishSpan(span, err) }()
+
 	var members []entities.User
-	err := s.txManager.Read(ctx, func(ctx context.Context) error {
+	err = s.txManager.Read(ctx, func(ctx context.Context) error {
 		exists, err := s.storage.IsTeamExists(ctx, teamName)
 		if err != nil {
 			return fmt.Errorf("check team exists: %w", err)
@@ -80,6 +94,284 @@ func (s *Service) GetTeam(
 	}, nil
 }
 
+// ListTeams возвращает страницу команд с участниками по фильтру и общее число команд,
+// подходящих под фильтр.
+func (s *Service) ListTeams(
+	ctx context.Context,
+	filter entities.TeamFilter,
+	offset uint64,
+	limit uint64,
+) (_ []entities.Team, _ int, err error) {
+	ctx, span := tracer.Start(ctx, "Service.ListTeams")
+	defer func() { finishSpan(span, err) }()
+
+	var teams []entities.Team
+	var total int
+
+	err = s.txManager.Read(ctx, func(ctx context.Context) error {
+		var err error
+		total, err = s.storage.CountTeams(ctx, filter)
+		if err != nil {
+			return fmt.Errorf("count teams: %w", err)
+		}
+
+		teams, err = s.storage.GetTeams(ctx, filter, offset, limit)
+		if err != nil {
+			return fmt.Errorf("get teams: %w", err)
+		}
+
+		for i := range teams {
+			teams[i].Members, err = s.storage.GetUsersByTeamName(ctx, teams[i].Name)
+			if err != nil {
+				return fmt.Errorf("get team members: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("list teams: %w", err)
+	}
+
+	return teams, total, nil
+}
+
+// CreateTeamWithUsers создаёт команду из уже существующих пользователей, в отличие
+// от CreateTeam, которая создаёт и самих пользователей.
+func (s *Service) CreateTeamWithUsers(
+	ctx context.Context,
+	teamName string,
+	userIDs []uuid.UUID,
+) (_ *entities.Team, err error) {
+	ctx, span := tracer.Start(ctx, "Service.CreateTeamWithUsers")
+	defer func() { finishSpan(span, err) }()
+
+	if err := validateTeamName(teamName); err != nil {
+		return nil, fmt.Errorf("validate team name: %w", err)
+	}
+	if err := checkDuplicateIDs(userIDs); err != nil {
+		return nil, fmt.Errorf("check duplicate users: %w", err)
+	}
+
+	var members []entities.User
+	err = s.txManager.Write(ctx, func(ctx context.Context) error {
+		users, err := s.getUsersByIDs(ctx, userIDs)
+		if err != nil {
+			return err
+		}
+
+		if err := s.authorizeMembersMove(ctx, teamName, users); err != nil {
+			return err
+		}
+
+		if _, err := s.storage.CreateTeam(ctx, teamName); err != nil {
+			return fmt.Errorf("create team: %w", err)
+		}
+
+		for i := range users {
+			users[i].TeamName = teamName
+		}
+
+		members, err = s.storage.CreateOrUpdateUsers(ctx, users)
+		if err != nil {
+			return fmt.Errorf("update users: %w", err)
+		}
+
+		return s.audit(ctx, entities.AuditActionTeamCreate, teamName)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create team with users transaction: %w", err)
+	}
+
+	return &entities.Team{
+		Name:    teamName,
+		Members: members,
+	}, nil
+}
+
+// UpdateTeamMembers меняет состав команды. Менять его могут лиды команды и администраторы,
+// забирать пользователей из других команд — лиды тех команд и администраторы.
+func (s *Service) UpdateTeamMembers(
+	ctx context.Context,
+	teamName string,
+	patch entities.TeamMembersPatch,
+) (_ *entities.Team, err error) {
+	ctx, span := tracer.Start(ctx, "Service.UpdateTeamMembers")
+	defer func() { finishSpan(span, err) }()
+
+	if err := checkDuplicateIDs(patch.Add); err != nil {
+		return nil, fmt.Errorf("check duplicate users: %w", err)
+	}
+
+	var members []entities.User
+	err = s.txManager.Write(ctx, func(ctx context.Context) error {
+		exists, err := s.storage.IsTeamExists(ctx, teamName)
+		if err != nil {
+			return fmt.Errorf("check team exists: %w", err)
+		}
+		if !exists {
+			return &entities.ErrTeamNotFound{Name: teamName}
+		}
+
+		caller, err := s.resolveCaller(ctx)
+		if err != nil {
+			return fmt.Errorf("resolve caller: %w", err)
+		}
+		if err := caller.requireTeamManager(teamName); err != nil {
+			return err
+		}
+
+		current, err := s.storage.GetUsersByTeamName(ctx, teamName)
+		if err != nil {
+			return fmt.Errorf("get team members: %w", err)
+		}
+
+		inTeam := make(map[uuid.UUID]bool, len(current))
+		for _, user := range current {
+			inTeam[user.ID] = true
+		}
+
+		var addIDs []uuid.UUID
+		for _, id := range patch.Add {
+			if !inTeam[id] {
+				addIDs = append(addIDs, id)
+			}
+		}
+		added, err := s.getUsersByIDs(ctx, addIDs)
+		if err != nil {
+			return err
+		}
+		if err := s.authorizeMembersMove(ctx, teamName, added); err != nil {
+			return err
+		}
+
+		remove := make(map[uuid.UUID]bool)
+		if patch.Replace {
+			keep := make(map[uuid.UUID]bool, len(patch.Add))
+			for _, id := range patch.Add {
+				keep[id] = true
+			}
+			for _, user := range current {
+				if !keep[user.ID] {
+					remove[user.ID] = true
+				}
+			}
+		} else {
+			for _, id := range patch.Remove {
+				remove[id] = true
+			}
+		}
+
+		changed := make([]entities.User, 0, len(added)+len(remove))
+		for _, user := range added {
+			user.TeamName = teamName
+			changed = append(changed, user)
+		}
+		for _, user := range current {
+			if remove[user.ID] {
+				user.TeamName = ""
+				changed = append(changed, user)
+			}
+		}
+		if len(changed) == 0 {
+			members = current
+			return nil
+		}
+
+		if _, err := s.storage.CreateOrUpdateUsers(ctx, changed); err != nil {
+			return fmt.Errorf("update users: %w", err)
+		}
+
+		members, err = s.storage.GetUsersByTeamName(ctx, teamName)
+		if err != nil {
+			return fmt.Errorf("get team members: %w", err)
+		}
+
+		return s.audit(ctx, entities.AuditActionTeamUpdate, teamName)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("update team members: %w", err)
+	}
+
+	return &entities.Team{
+		Name:    teamName,
+		Members: members,
+	}, nil
+}
+
+// DeleteTeam удаляет команду, её участники остаются без команды. Удалять команды
+// могут только администраторы.
+func (s *Service) DeleteTeam(ctx context.Context, teamName string) (err error) {
+	ctx, span := tracer.Start(ctx, "Service.DeleteTeam")
+	defer func() { finishSpan(span, err) }()
+
+	err = s.txManager.Write(ctx, func(ctx context.Context) error {
+		caller, err := s.resolveCaller(ctx)
+		if err != nil {
+			return fmt.Errorf("resolve caller: %w", err)
+		}
+		if err := caller.requireAdmin(); err != nil {
+			return err
+		}
+
+		if err := s.storage.DeleteTeam(ctx, teamName); err != nil {
+			return fmt.Errorf("delete team: %w", err)
+		}
+
+		return s.audit(ctx, entities.AuditActionTeamDelete, teamName)
+	})
+	if err != nil {
+		return fmt.Errorf("delete team: %w", err)
+	}
+
+	return nil
+}
+
+// getUsersByIDs возвращает пользователей в порядке userIDs или ErrUserNotFound для первого
+// отсутствующего.
+func (s *Service) getUsersByIDs(ctx context.Context, userIDs []uuid.UUID) ([]entities.User, error) {
+	users := make([]entities.User, 0, len(userIDs))
+	for _, id := range userIDs {
+		user, err := s.storage.GetUserByID(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("get user: %w", err)
+		}
+		users = append(users, *user)
+	}
+	return users, nil
+}
+
+// authorizeMembersMove проверяет, что вызывающий может забрать существующих
+// пользователей из их текущих команд.
+func (s *Service) authorizeMembersMove(ctx context.Context, teamName string, members []entities.User) error {
+	caller, err := s.resolveCaller(ctx)
+	if err != nil {
+		return fmt.Errorf("resolve caller: %w", err)
+	}
+	if caller.isAdmin {
+		return nil
+	}
+
+	for _, member := range members {
+		existing, err := s.storage.GetUserByID(ctx, member.ID)
+		var notFound *entities.ErrUserNotFound
+		switch {
+		case errors.As(err, &notFound):
+			continue
+		case err != nil:
+			return fmt.Errorf("get member: %w", err)
+		}
+
+		if existing.TeamName == teamName {
+			continue
+		}
+		if err := caller.requireTeamManager(existing.TeamName); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func validateMembers(members []entities.User) error {
 	for _, member := range members {
 		if err := validateUsername(member.Name); err != nil {
@@ -0,0 +1,229 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"service-pr-reviewer-assignment/internal/pkg/pgtest"
+	"service-pr-reviewer-assignment/internal/pkg/tenant"
+	"service-pr-reviewer-assignment/internal/service/entities"
+	storagepkg "service-pr-reviewer-assignment/internal/storage"
+	"service-pr-reviewer-assignment/pkg/log"
+	"service-pr-reviewer-assignment/pkg/querier"
+	"service-pr-reviewer-assignment/pkg/tx"
+
+	"github.com/AlekSi/pointer"
+	"github.com/avito-tech/go-transaction-manager/pgxv5"
+	"github.com/google/uuid"
+)
+
+// tenantFixture данные одной организации: команда и пользователи с теми же именами,
+// что и в другой организации, и открытый PR.
+type tenantFixture struct {
+	ctx         context.Context
+	members     []entities.User
+	pullRequest *entities.PullRequest
+	reviewerIDs []uuid.UUID
+	apiKeyID    uuid.UUID
+}
+
+func newTenantFixture(t *testing.T, s *Service, ctx context.Context) tenantFixture {
+	t.Helper()
+
+	members := []entities.User{
+		{ID: uuid.New(), Name: "Alice", IsActive: true},
+		{ID: uuid.New(), Name: "Bob", IsActive: true},
+		{ID: uuid.New(), Name: "Carol", IsActive: true},
+		{ID: uuid.New(), Name: "Dave", IsActive: true},
+	}
+	if _, err := s.CreateTeam(ctx, "payments", members); err != nil {
+		t.Fatalf("create team: %v", err)
+	}
+
+	pullRequest, reviewerIDs, err := s.CreatePullRequestAndAssignReviewers(
+		ctx, uuid.New(), "Add search", members[0].ID, false,
+	)
+	if err != nil {
+		t.Fatalf("create pull request: %v", err)
+	}
+	if len(reviewerIDs) == 0 {
+		t.Fatalf("no reviewers assigned")
+	}
+
+	key, _, err := s.CreateAPIKey(ctx, "ci", []entities.Scope{entities.ScopeAdmin})
+	if err != nil {
+		t.Fatalf("create api key: %v", err)
+	}
+
+	return tenantFixture{
+		ctx:         ctx,
+		members:     members,
+		pullRequest: pullRequest,
+		reviewerIDs: reviewerIDs,
+		apiKeyID:    key.ID,
+	}
+}
+
+func userIDs(users []entities.User) []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(users))
+	for _, user := range users {
+		ids = append(ids, user.ID)
+	}
+	return ids
+}
+
+// TestTenantIsolation проверяет, что чтения и изменения в организации A не видят
+// и не меняют данные организации B с теми же именами команд и пользователей.
+func TestTenantIsolation(t *testing.T) {
+	pool := pgtest.New(t)
+	txManager := tx.Must(pool, log.Must(log.Options{}))
+	storage := storagepkg.Must(querier.Must(pool, pgxv5.DefaultCtxGetter))
+	s := Must(storage, txManager, noopMetrics{}, AssignmentPolicy{MaxReviewers: 2}, true)
+
+	ctxA := tenant.WithOrgID(context.Background(), entities.DefaultOrganizationID)
+	organization, _, _, err := s.CreateOrganization(ctxA, "beta")
+	if err != nil {
+		t.Fatalf("create organization: %v", err)
+	}
+	ctxB := tenant.WithOrgID(context.Background(), organization.ID)
+
+	a := newTenantFixture(t, s, ctxA)
+	b := newTenantFixture(t, s, ctxB)
+
+	t.Run("GetTeam", func(t *testing.T) {
+		team, err := s.GetTeam(a.ctx, "payments")
+		if err != nil {
+			t.Fatalf("get team: %v", err)
+		}
+		if got, want := userIDs(team.Members), userIDs(a.members); !sameIDs(got, want) {
+			t.Errorf("members = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("ListUsers", func(t *testing.T) {
+		users, total, err := s.ListUsers(a.ctx, entities.UserFilter{Name: pointer.To("Bob")}, 0, 10)
+		if err != nil {
+			t.Fatalf("list users: %v", err)
+		}
+		if total != 1 || len(users) != 1 || users[0].ID != a.members[1].ID {
+			t.Errorf("users = %v (total %d), want only %s", userIDs(users), total, a.members[1].ID)
+		}
+	})
+
+	t.Run("GetPullRequestsByReviewerID", func(t *testing.T) {
+		pullRequests, err := s.GetUserPullRequestReviewRequests(a.ctx, a.reviewerIDs[0])
+		if err != nil {
+			t.Fatalf("get review requests: %v", err)
+		}
+		for _, pullRequest := range pullRequests {
+			if pullRequest.ID != a.pullRequest.ID {
+				t.Errorf("got pull request %s of another organization", pullRequest.ID)
+			}
+		}
+
+		_, err = s.GetUserPullRequestReviewRequests(a.ctx, b.reviewerIDs[0])
+		var notFound *entities.ErrUserNotFound
+		if !errors.As(err, &notFound) {
+			t.Errorf("reviewer of another organization: err = %v, want ErrUserNotFound", err)
+		}
+	})
+
+	t.Run("ListEventsAfter", func(t *testing.T) {
+		events, err := s.ListEventsAfter(a.ctx, 0, entities.EventFilter{}, 100)
+		if err != nil {
+			t.Fatalf("list events: %v", err)
+		}
+		if len(events) == 0 {
+			t.Fatalf("no events")
+		}
+		for _, event := range events {
+			if event.PullRequestID != nil && *event.PullRequestID == b.pullRequest.ID {
+				t.Errorf("got event %d of another organization", event.ID)
+			}
+		}
+	})
+
+	t.Run("ListAPIKeys", func(t *testing.T) {
+		keys, err := s.ListAPIKeys(a.ctx)
+		if err != nil {
+			t.Fatalf("list api keys: %v", err)
+		}
+		for _, key := range keys {
+			if key.OrgID != entities.DefaultOrganizationID || key.ID == b.apiKeyID {
+				t.Errorf("got api key %s of organization %s", key.ID, key.OrgID)
+			}
+		}
+	})
+
+	t.Run("SetUserActiveStatus", func(t *testing.T) {
+		_, err := s.SetUserActiveStatus(a.ctx, b.members[1].ID, false)
+		var notFound *entities.ErrUserNotFound
+		if !errors.As(err, &notFound) {
+			t.Errorf("user of another organization: err = %v, want ErrUserNotFound", err)
+		}
+
+		if _, err := s.SetUserActiveStatus(a.ctx, a.members[1].ID, false); err != nil {
+			t.Fatalf("deactivate user: %v", err)
+		}
+		user, err := s.GetUser(b.ctx, b.members[1].ID)
+		if err != nil {
+			t.Fatalf("get user: %v", err)
+		}
+		if !user.IsActive {
+			t.Errorf("user %s of organization B was deactivated", user.ID)
+		}
+	})
+
+	t.Run("ReassignReviewer", func(t *testing.T) {
+		_, _, _, err := s.ReassignReviewer(a.ctx, b.pullRequest.ID, b.reviewerIDs[0], nil)
+		var notFound *entities.ErrPullRequestNotFound
+		if !errors.As(err, &notFound) {
+			t.Errorf("pull request of another organization: err = %v, want ErrPullRequestNotFound", err)
+		}
+	})
+
+	t.Run("MergePullRequest", func(t *testing.T) {
+		_, _, err := s.MergePullRequestAndGetReviewers(a.ctx, b.pullRequest.ID)
+		var notFound *entities.ErrPullRequestNotFound
+		if !errors.As(err, &notFound) {
+			t.Errorf("pull request of another organization: err = %v, want ErrPullRequestNotFound", err)
+		}
+
+		if _, _, err := s.MergePullRequestAndGetReviewers(a.ctx, a.pullRequest.ID); err != nil {
+			t.Fatalf("merge pull request: %v", err)
+		}
+		pullRequests, err := s.GetUserPullRequestReviewRequests(b.ctx, b.reviewerIDs[0])
+		if err != nil {
+			t.Fatalf("get review requests: %v", err)
+		}
+		for _, pullRequest := range pullRequests {
+			if pullRequest.ID == b.pullRequest.ID && pullRequest.Status != entities.PullRequestStatusOpen {
+				t.Errorf("pull request of organization B has status %s", pullRequest.Status)
+			}
+		}
+	})
+
+	t.Run("DeleteTeam", func(t *testing.T) {
+		if err := s.DeleteTeam(a.ctx, "payments"); err != nil {
+			t.Fatalf("delete team: %v", err)
+		}
+
+		team, err := s.GetTeam(b.ctx, "payments")
+		if err != nil {
+			t.Fatalf("team of organization B: %v", err)
+		}
+		if got, want := userIDs(team.Members), userIDs(b.members); !sameIDs(got, want) {
+			t.Errorf("members = %v, want %v", got, want)
+		}
+	})
+}
+
+func sameIDs(got, want []uuid.UUID) bool {
+	less := func(a, b uuid.UUID) int { return slices.Compare(a[:], b[:]) }
+	got, want = slices.Clone(got), slices.Clone(want)
+	slices.SortFunc(got, less)
+	slices.SortFunc(want, less)
+	return slices.Equal(got, want)
+}
@@ -14,10 +14,13 @@ import (
 func (s *Service) GetUserPullRequestReviewRequests(
 	ctx context.Context,
 	userID uuid.UUID,
-) ([]entities.PullRequest, error) {
+) (_ []entities.PullRequest, err error) {
+	ctx, span := tracer.Start(ctx, "Service.GetUserPullRequestReviewRequests")
+	defer func() { finishSpan(span, err) }()
+
 	var userPRs []entities.PullRequest
 
-	err := s.txManager.Read(ctx, func(ctx context.Context) error {
+	err = s.txManager.Read(ctx, func(ctx context.Context) error {
 		exists, err := s.storage.IsUserExists(ctx, userID)
 		if err != nil {
 			return fmt.Errorf("check user exists: %w", err)
@@ -39,40 +42,310 @@ func (s *Service) GetUserPullRequestReviewRequests(
 	return userPRs, nil
 }
 
-func (s *Service) SetUserActiveStatus(
+// ListUsers возвращает страницу пользователей организации по фильтру и общее число
+// пользователей, подходящих под фильтр.
+func (s *Service) ListUsers(
+	ctx context.Context,
+	filter entities.UserFilter,
+	offset uint64,
+	limit uint64,
+) (_ []entities.User, _ int, err error) {
+	ctx, span := tracer.Start(ctx, "Service.ListUsers")
+	defer func() { finishSpan(span, err) }()
+
+	var users []entities.User
+	var total int
+
+	err = s.txManager.Read(ctx, func(ctx context.Context) error {
+		var err error
+		total, err = s.storage.CountUsers(ctx, filter)
+		if err != nil {
+			return fmt.Errorf("count users: %w", err)
+		}
+
+		users, err = s.storage.GetUsers(ctx, filter, offset, limit)
+		if err != nil {
+			return fmt.Errorf("get users: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("list users: %w", err)
+	}
+
+	return users, total, nil
+}
+
+func (s *Service) GetUser(
 	ctx context.Context,
 	userID uuid.UUID,
+) (_ *entities.User, err error) {
+	ctx, span := tracer.Start(ctx, "Service.GetUser")
+	defer func() { finishSpan(span, err) }()
+
+	var user *entities.User
+
+	err = s.txManager.Read(ctx, func(ctx context.Context) error {
+		var err error
+		user, err = s.storage.GetUserByID(ctx, userID)
+		if err != nil {
+			return fmt.Errorf("get user: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get user: %w", err)
+	}
+
+	return user, nil
+}
+
+// CreateUser создаёт пользователя вне команды, в команду его добавляют отдельно.
+// Имя должно быть уникальным в организации. Создавать пользователей могут только администраторы.
+func (s *Service) CreateUser(
+	ctx context.Context,
+	name string,
 	isActive bool,
-) (*entities.User, error) {
+) (_ *entities.User, err error) {
+	ctx, span := tracer.Start(ctx, "Service.CreateUser")
+	defer func() { finishSpan(span, err) }()
+
+	if err := validateUsername(name); err != nil {
+		return nil, fmt.Errorf("validate username: %w", err)
+	}
+
+	var user *entities.User
+
+	err = s.txManager.Write(ctx, func(ctx context.Context) error {
+		caller, err := s.resolveCaller(ctx)
+		if err != nil {
+			return fmt.Errorf("resolve caller: %w", err)
+		}
+		if err := caller.requireAdmin(); err != nil {
+			return err
+		}
+
+		if err := s.requireUniqueUsername(ctx, name, nil); err != nil {
+			return err
+		}
+
+		users, err := s.storage.CreateOrUpdateUsers(ctx, []entities.User{{
+			ID:       uuid.New(),
+			Name:     name,
+			IsActive: isActive,
+		}})
+		if err != nil {
+			return fmt.Errorf("create user: %w", err)
+		}
+		user = &users[0]
+
+		return s.audit(ctx, entities.AuditActionUserCreate, user.ID.String())
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create user: %w", err)
+	}
+
+	return user, nil
+}
+
+// UpdateUser меняет имя и активность пользователя. Деактивация снимает пользователя
+// с ревью так же, как SetUserActiveStatus.
+func (s *Service) UpdateUser(
+	ctx context.Context,
+	userID uuid.UUID,
+	update entities.UserUpdate,
+) (_ *entities.User, err error) {
+	ctx, span := tracer.Start(ctx, "Service.UpdateUser")
+	defer func() { finishSpan(span, err) }()
+
+	if update.Name != nil {
+		if err := validateUsername(*update.Name); err != nil {
+			return nil, fmt.Errorf("validate username: %w", err)
+		}
+	}
+
 	var user *entities.User
 
-	err := s.txManager.Write(ctx, func(ctx context.Context) error {
+	err = s.txManager.Write(ctx, func(ctx context.Context) error {
 		var err error
 		user, err = s.storage.GetUserByID(ctx, userID)
 		if err != nil {
 			return fmt.Errorf("get user: %w", err)
 		}
 
-		if user.IsActive == isActive {
-			return nil
+		caller, err := s.resolveCaller(ctx)
+		if err != nil {
+			return fmt.Errorf("resolve caller: %w", err)
+		}
+		if !caller.isUser(userID) {
+			if err := caller.requireTeamManager(user.TeamName); err != nil {
+				return err
+			}
+		}
+
+		if update.Name != nil && *update.Name != user.Name {
+			if err := s.requireUniqueUsername(ctx, *update.Name, &userID); err != nil {
+				return err
+			}
+
+			user.Name = *update.Name
+			user, err = s.storage.UpdateUser(ctx, user)
+			if err != nil {
+				return fmt.Errorf("update user: %w", err)
+			}
+
+			if err := s.audit(ctx, entities.AuditActionUserUpdate, userID.String()); err != nil {
+				return err
+			}
+		}
+
+		if update.IsActive != nil {
+			user, err = s.changeUserActiveStatus(ctx, user, *update.IsActive)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("update user: %w", err)
+	}
+
+	return user, nil
+}
+
+// requireUniqueUsername проверяет, что имя не занято другим пользователем организации,
+// кроме exceptID. Уникальность userName нужна SCIM: IdP находит пользователя фильтром
+// userName eq. Ограничения в базе нет, поэтому повторы имён, созданные через /team/add
+// и импорт команд, остаются как есть, а проверка выполняется только при создании
+// и переименовании пользователя.
+func (s *Service) requireUniqueUsername(ctx context.Context, name string, exceptID *uuid.UUID) error {
+	existing, err := s.storage.GetUsers(ctx, entities.UserFilter{Name: &name, ExcludeID: exceptID}, 0, 1)
+	if err != nil {
+		return fmt.Errorf("get users by name: %w", err)
+	}
+	if len(existing) > 0 {
+		return &entities.ErrUserAlreadyExists{Name: name}
+	}
+	return nil
+}
+
+func (s *Service) SetUserActiveStatus(
+	ctx context.Context,
+	userID uuid.UUID,
+	isActive bool,
+) (_ *entities.User, err error) {
+	ctx, span := tracer.Start(ctx, "Service.SetUserActiveStatus")
+	defer func() { finishSpan(span, err) }()
+
+	var user *entities.User
+
+	err = s.txManager.Write(ctx, func(ctx context.Context) error {
+		var err error
+		user, err = s.storage.GetUserByID(ctx, userID)
+		if err != nil {
+			return fmt.Errorf("get user: %w", err)
 		}
 
-		if user.IsActive && !isActive {
-			if err := s.storage.DeletePullRequestReviewersByReviewerID(ctx, userID); err != nil {
-				return fmt.Errorf("delete PR reviewers: %w", err)
+		caller, err := s.resolveCaller(ctx)
+		if err != nil {
+			return fmt.Errorf("resolve caller: %w", err)
+		}
+		if !caller.isUser(userID) {
+			if err := caller.requireTeamManager(user.TeamName); err != nil {
+				return err
 			}
 		}
 
-		user.IsActive = isActive
+		user, err = s.changeUserActiveStatus(ctx, user, isActive)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("set user active status: %w", err)
+	}
+
+	return user, nil
+}
+
+// changeUserActiveStatus меняет активность пользователя. Деактивированный пользователь
+// снимается со всех ревью. Вызывается внутри транзакции после проверки прав.
+func (s *Service) changeUserActiveStatus(ctx context.Context, user *entities.User, isActive bool) (*entities.User, error) {
+	if user.IsActive == isActive {
+		return user, nil
+	}
+
+	if user.IsActive && !isActive {
+		if err := s.storage.DeletePullRequestReviewersByReviewerID(ctx, user.ID); err != nil {
+			return nil, fmt.Errorf("delete PR reviewers: %w", err)
+		}
+	}
+
+	user.IsActive = isActive
+	user, err := s.storage.UpdateUser(ctx, user)
+	if err != nil {
+		return nil, fmt.Errorf("update user: %w", err)
+	}
+
+	if err := s.audit(ctx, entities.AuditActionUserSetIsActive, user.ID.String()); err != nil {
+		return nil, err
+	}
+
+	err = s.publish(ctx, &entities.Event{
+		Type:     entities.EventTypeUserActivityChanged,
+		TeamName: user.TeamName,
+		UserID:   &user.ID,
+		IsActive: &user.IsActive,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (s *Service) SetUserRole(
+	ctx context.Context,
+	userID uuid.UUID,
+	role entities.UserRole,
+) (_ *entities.User, err error) {
+	ctx, span := tracer.Start(ctx, "Service.SetUserRole")
+	defer func() { finishSpan(span, err) }()
+
+	if !role.IsValid() {
+		return nil, &entities.ErrUserRoleValidation{Role: role}
+	}
+
+	var user *entities.User
+
+	err = s.txManager.Write(ctx, func(ctx context.Context) error {
+		caller, err := s.resolveCaller(ctx)
+		if err != nil {
+			return fmt.Errorf("resolve caller: %w", err)
+		}
+		if err := caller.requireAdmin(); err != nil {
+			return err
+		}
+
+		user, err = s.storage.GetUserByID(ctx, userID)
+		if err != nil {
+			return fmt.Errorf("get user: %w", err)
+		}
+
+		if user.Role == role {
+			return nil
+		}
+
+		user.Role = role
 		user, err = s.storage.UpdateUser(ctx, user)
 		if err != nil {
 			return fmt.Errorf("update user: %w", err)
 		}
 
-		return nil
+		return s.audit(ctx, entities.AuditActionUserSetRole, userID.String())
 	})
 	if err != nil {
-		return nil, fmt.Errorf("set user active status: %w", err)
+		return nil, fmt.Errorf("set user role: %w", err)
 	}
 
 	return user, nil
@@ -96,14 +369,22 @@ func validateUsername(username string) error {
 }
 
 func checkDuplicateUserIDs(users []entities.User) error {
+	ids := make([]uuid.UUID, 0, len(users))
+	for _, user := range users {
+		ids = append(ids, user.ID)
+	}
+	return checkDuplicateIDs(ids)
+}
+
+func checkDuplicateIDs(ids []uuid.UUID) error {
 	seen := make(map[uuid.UUID]bool)
 	var duplicates []uuid.UUID
 
-	for _, user := range users {
-		if seen[user.ID] {
-			duplicates = append(duplicates, user.ID)
+	for _, id := range ids {
+		if seen[id] {
+			duplicates = append(duplicates, id)
 		}
-		seen[user.ID] = true
+		seen[id] = true
 	}
 
 	if len(duplicates) > 0 {
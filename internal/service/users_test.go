@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"service-pr-reviewer-assignment/internal/pkg/identity"
+	"service-pr-reviewer-assignment/internal/pkg/tenant"
+	"service-pr-reviewer-assignment/internal/service/entities"
+
+	"github.com/AlekSi/pointer"
+	"github.com/google/uuid"
+)
+
+// newAdminService сервис поверх newRBACStorage, вызывающий — API-ключ со scope admin.
+func newAdminService(t *testing.T) (*Service, *memoryStorage, context.Context) {
+	t.Helper()
+
+	storage := newRBACStorage()
+	s := Must(storage, directTxManager{}, noopMetrics{}, AssignmentPolicy{MaxReviewers: 2}, false)
+	ctx := tenant.WithOrgID(context.Background(), entities.DefaultOrganizationID)
+	ctx = identity.WithIdentity(ctx, rbacCallers["admin key"])
+	return s, storage, ctx
+}
+
+func TestCreateUserRejectsTakenName(t *testing.T) {
+	s, _, ctx := newAdminService(t)
+
+	_, err := s.CreateUser(ctx, "Mia", true)
+
+	var exists *entities.ErrUserAlreadyExists
+	if !errors.As(err, &exists) || exists.Name != "Mia" {
+		t.Fatalf("err = %v, want ErrUserAlreadyExists for Mia", err)
+	}
+}
+
+func TestUpdateUserRejectsNameOfAnotherUser(t *testing.T) {
+	s, storage, ctx := newAdminService(t)
+
+	_, err := s.UpdateUser(ctx, maxID, entities.UserUpdate{Name: pointer.To("Mia")})
+
+	var exists *entities.ErrUserAlreadyExists
+	if !errors.As(err, &exists) {
+		t.Fatalf("err = %v, want ErrUserAlreadyExists", err)
+	}
+	if storage.users[maxID].Name != "Max" {
+		t.Errorf("name = %q, want Max", storage.users[maxID].Name)
+	}
+}
+
+func TestUpdateUserKeepsExistingDuplicateName(t *testing.T) {
+	s, storage, ctx := newAdminService(t)
+	// Повтор имени, созданный устаревшим API до SCIM.
+	twin := entities.User{ID: uuid.MustParse("a50e8400-e29b-41d4-a716-446655440010"), Name: "Mia", TeamName: "search", IsActive: true}
+	storage.users[twin.ID] = twin
+
+	user, err := s.UpdateUser(ctx, twin.ID, entities.UserUpdate{Name: pointer.To("Mia"), IsActive: pointer.To(false)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user.Name != "Mia" || user.IsActive {
+		t.Errorf("user = %+v, want inactive Mia", user)
+	}
+}
+
+func TestLegacyTeamAllowsDuplicateNames(t *testing.T) {
+	s, storage, ctx := newAdminService(t)
+
+	newID := uuid.MustParse("a50e8400-e29b-41d4-a716-446655440011")
+	_, err := s.CreateTeam(ctx, "platform", []entities.User{{ID: newID, Name: "Mia", IsActive: true}})
+	if err != nil {
+		t.Fatalf("create team: %v", err)
+	}
+	if storage.users[newID].Name != "Mia" {
+		t.Errorf("user = %+v, want a second Mia", storage.users[newID])
+	}
+}
@@ -0,0 +1,190 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"service-pr-reviewer-assignment/internal/service/entities"
+
+	"github.com/AlekSi/pointer"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+type apiKeyDB struct {
+	ID        uuid.UUID
+	OrgID     uuid.UUID
+	Name      string
+	Scopes    []string
+	CreatedAt time.Time
+	RevokedAt *time.Time
+}
+
+func (s *Storage) CreateAPIKey(ctx context.Context, key *entities.APIKey, keyHash string) (*entities.APIKey, error) {
+	orgID, err := orgIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	const query = `
+		INSERT INTO api_keys (id, org_id, name, key_hash, scopes, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, org_id, name, scopes, created_at, revoked_at
+	`
+
+	var keyDB apiKeyDB
+	err = s.querier.QueryRow(
+		ctx,
+		query,
+		key.ID,
+		orgID,
+		key.Name,
+		keyHash,
+		convertScopesToDB(key.Scopes),
+		key.CreatedAt,
+	).Scan(
+		&keyDB.ID,
+		&keyDB.OrgID,
+		&keyDB.Name,
+		&keyDB.Scopes,
+		&keyDB.CreatedAt,
+		&keyDB.RevokedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("create api key: %w", err)
+	}
+
+	created := convertAPIKeyDBToEntity(keyDB)
+	return &created, nil
+}
+
+// GetAPIKeyByHash ищет ключ во всех организациях: по ключу как раз и определяется
+// организация запроса.
+func (s *Storage) GetAPIKeyByHash(ctx context.Context, keyHash string) (*entities.APIKey, error) {
+	const query = `SELECT id, org_id, name, scopes, created_at, revoked_at FROM api_keys WHERE key_hash = $1`
+
+	var keyDB apiKeyDB
+	err := s.querier.QueryRow(ctx, query, keyHash).Scan(
+		&keyDB.ID,
+		&keyDB.OrgID,
+		&keyDB.Name,
+		&keyDB.Scopes,
+		&keyDB.CreatedAt,
+		&keyDB.RevokedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, &entities.ErrAPIKeyNotFound{}
+		}
+		return nil, fmt.Errorf("get api key by hash: %w", err)
+	}
+
+	key := convertAPIKeyDBToEntity(keyDB)
+	return &key, nil
+}
+
+func (s *Storage) GetAPIKeys(ctx context.Context) ([]entities.APIKey, error) {
+	orgID, err := orgIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	const query = `
+		SELECT id, org_id, name, scopes, created_at, revoked_at
+		FROM api_keys
+		WHERE org_id = $1
+		ORDER BY created_at
+	`
+
+	rows, err := s.querier.Query(ctx, query, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("query api keys: %w", err)
+	}
+	defer rows.Close()
+
+	var keysDB []apiKeyDB
+	for rows.Next() {
+		var keyDB apiKeyDB
+		if err := rows.Scan(
+			&keyDB.ID,
+			&keyDB.OrgID,
+			&keyDB.Name,
+			&keyDB.Scopes,
+			&keyDB.CreatedAt,
+			&keyDB.RevokedAt,
+		); err != nil {
+			return nil, fmt.Errorf("scan api key: %w", err)
+		}
+		keysDB = append(keysDB, keyDB)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	out := make([]entities.APIKey, 0, len(keysDB))
+	for _, keyDB := range keysDB {
+		out = append(out, convertAPIKeyDBToEntity(keyDB))
+	}
+	return out, nil
+}
+
+// RevokeAPIKey помечает ключ отозванным. Повторный отзыв не меняет время отзыва.
+func (s *Storage) RevokeAPIKey(ctx context.Context, id uuid.UUID, revokedAt time.Time) (*entities.APIKey, error) {
+	orgID, err := orgIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	const query = `
+		UPDATE api_keys
+		SET revoked_at = COALESCE(revoked_at, $3)
+		WHERE org_id = $1 AND id = $2
+		RETURNING id, org_id, name, scopes, created_at, revoked_at
+	`
+
+	var keyDB apiKeyDB
+	err = s.querier.QueryRow(ctx, query, orgID, id, revokedAt).Scan(
+		&keyDB.ID,
+		&keyDB.OrgID,
+		&keyDB.Name,
+		&keyDB.Scopes,
+		&keyDB.CreatedAt,
+		&keyDB.RevokedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, &entities.ErrAPIKeyNotFound{ID: pointer.To(id)}
+		}
+		return nil, fmt.Errorf("revoke api key: %w", err)
+	}
+
+	key := convertAPIKeyDBToEntity(keyDB)
+	return &key, nil
+}
+
+func convertScopesToDB(scopes []entities.Scope) []string {
+	out := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		out = append(out, string(scope))
+	}
+	return out
+}
+
+func convertAPIKeyDBToEntity(keyDB apiKeyDB) entities.APIKey {
+	scopes := make([]entities.Scope, 0, len(keyDB.Scopes))
+	for _, scope := range keyDB.Scopes {
+		scopes = append(scopes, entities.Scope(scope))
+	}
+
+	return entities.APIKey{
+		ID:        keyDB.ID,
+		OrgID:     keyDB.OrgID,
+		Name:      keyDB.Name,
+		Scopes:    scopes,
+		CreatedAt: keyDB.CreatedAt,
+		RevokedAt: keyDB.RevokedAt,
+	}
+}
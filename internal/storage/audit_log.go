@@ -0,0 +1,27 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"service-pr-reviewer-assignment/internal/service/entities"
+)
+
+func (s *Storage) CreateAuditLogEntry(ctx context.Context, entry *entities.AuditLogEntry) error {
+	orgID, err := orgIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	const query = `
+		INSERT INTO audit_log (org_id, actor, action, entity_id, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+
+	_, err = s.querier.Exec(ctx, query, orgID, entry.Actor, string(entry.Action), entry.EntityID, entry.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("create audit log entry: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,213 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"service-pr-reviewer-assignment/internal/service/entities"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// eventPayloadDB поля события, которые не участвуют в фильтрах и хранятся в payload.
+type eventPayloadDB struct {
+	AuthorID      *uuid.UUID  `json:"author_id,omitempty"`
+	ReviewerIDs   []uuid.UUID `json:"reviewer_ids,omitempty"`
+	OldReviewerID *uuid.UUID  `json:"old_reviewer_id,omitempty"`
+	NewReviewerID *uuid.UUID  `json:"new_reviewer_id,omitempty"`
+	UserID        *uuid.UUID  `json:"user_id,omitempty"`
+	IsActive      *bool       `json:"is_active,omitempty"`
+}
+
+type eventDB struct {
+	ID            int64
+	Type          string
+	TeamName      string
+	PullRequestID *uuid.UUID
+	Payload       []byte
+	CreatedAt     time.Time
+}
+
+// CreateEvent сохраняет событие. Триггер events_notify оповещает подписчиков после коммита.
+//
+// Вызывается внутри транзакции. Перед вставкой берётся advisory lock организации до конца
+// транзакции: следующая транзакция получит номер события только после коммита этой, поэтому
+// номера событий организации растут в порядке коммита. Иначе событие с меньшим номером могло
+// бы закоммититься позже большего, и ListEventsAfter по Last-Event-ID его пропустил бы.
+func (s *Storage) CreateEvent(ctx context.Context, event *entities.Event) error {
+	orgID, err := orgIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	const lockQuery = `SELECT pg_advisory_xact_lock(hashtextextended('events:' || $1::text, 0))`
+
+	if _, err := s.querier.Exec(ctx, lockQuery, orgID); err != nil {
+		return fmt.Errorf("lock events: %w", err)
+	}
+
+	payload, err := json.Marshal(eventPayloadDB{
+		AuthorID:      event.AuthorID,
+		ReviewerIDs:   event.ReviewerIDs,
+		OldReviewerID: event.OldReviewerID,
+		NewReviewerID: event.NewReviewerID,
+		UserID:        event.UserID,
+		IsActive:      event.IsActive,
+	})
+	if err != nil {
+		return fmt.Errorf("marshal event payload: %w", err)
+	}
+
+	const query = `
+		INSERT INTO events (org_id, type, team_name, pull_request_id, user_ids, payload, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	_, err = s.querier.Exec(ctx, query,
+		orgID, string(event.Type), event.TeamName, event.PullRequestID, event.UserIDs(), payload, event.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("create event: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Storage) GetEventByID(ctx context.Context, id int64) (*entities.Event, error) {
+	orgID, err := orgIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	const query = `
+		SELECT id, type, team_name, pull_request_id, payload, created_at
+		FROM events
+		WHERE org_id = $1 AND id = $2
+	`
+
+	var eventDB eventDB
+	err = s.querier.QueryRow(ctx, query, orgID, id).Scan(
+		&eventDB.ID,
+		&eventDB.Type,
+		&eventDB.TeamName,
+		&eventDB.PullRequestID,
+		&eventDB.Payload,
+		&eventDB.CreatedAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("event %d not found: %w", id, err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get event: %w", err)
+	}
+
+	return convertEventDBToEntity(eventDB)
+}
+
+// ListEventsAfter возвращает до limit событий организации с номером больше afterID по возрастанию номера.
+// Номера выдаются в порядке коммита (см. CreateEvent), поэтому событие с номером меньше
+// afterID не может появиться после того, как клиент получил afterID.
+func (s *Storage) ListEventsAfter(
+	ctx context.Context,
+	afterID int64,
+	filter entities.EventFilter,
+	limit uint64,
+) ([]entities.Event, error) {
+	orgID, err := orgIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	builder := s.stmtBuilder.
+		Select("id", "type", "team_name", "pull_request_id", "payload", "created_at").
+		From("events").
+		Where(squirrel.Eq{"org_id": orgID}).
+		Where(squirrel.Gt{"id": afterID}).
+		OrderBy("id").
+		Limit(limit)
+	if filter.UserID != nil {
+		builder = builder.Where("? = ANY(user_ids)", *filter.UserID)
+	}
+	if filter.TeamName != nil {
+		builder = builder.Where(squirrel.Eq{"team_name": *filter.TeamName})
+	}
+	if filter.PullRequestID != nil {
+		builder = builder.Where(squirrel.Eq{"pull_request_id": *filter.PullRequestID})
+	}
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build select query: %w", err)
+	}
+
+	rows, err := s.querier.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("list events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []entities.Event
+	for rows.Next() {
+		var eventDB eventDB
+		if err := rows.Scan(
+			&eventDB.ID,
+			&eventDB.Type,
+			&eventDB.TeamName,
+			&eventDB.PullRequestID,
+			&eventDB.Payload,
+			&eventDB.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("scan event: %w", err)
+		}
+
+		event, err := convertEventDBToEntity(eventDB)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, *event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate events: %w", err)
+	}
+
+	return events, nil
+}
+
+// DeleteEventsBefore удаляет события всех организаций старше before. После этого
+// возобновить поток с более ранним Last-Event-ID уже нельзя.
+func (s *Storage) DeleteEventsBefore(ctx context.Context, before time.Time) (int64, error) {
+	const query = `DELETE FROM events WHERE created_at < $1`
+
+	tag, err := s.querier.Exec(ctx, query, before)
+	if err != nil {
+		return 0, fmt.Errorf("delete expired events: %w", err)
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+func convertEventDBToEntity(eventDB eventDB) (*entities.Event, error) {
+	var payload eventPayloadDB
+	if err := json.Unmarshal(eventDB.Payload, &payload); err != nil {
+		return nil, fmt.Errorf("unmarshal event %d payload: %w", eventDB.ID, err)
+	}
+
+	return &entities.Event{
+		ID:            eventDB.ID,
+		Type:          entities.EventType(eventDB.Type),
+		TeamName:      eventDB.TeamName,
+		PullRequestID: eventDB.PullRequestID,
+		AuthorID:      payload.AuthorID,
+		ReviewerIDs:   payload.ReviewerIDs,
+		OldReviewerID: payload.OldReviewerID,
+		NewReviewerID: payload.NewReviewerID,
+		UserID:        payload.UserID,
+		IsActive:      payload.IsActive,
+		CreatedAt:     eventDB.CreatedAt,
+	}, nil
+}
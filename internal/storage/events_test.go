@@ -0,0 +1,74 @@
+package storage_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"service-pr-reviewer-assignment/internal/pkg/pgtest"
+	"service-pr-reviewer-assignment/internal/pkg/tenant"
+	"service-pr-reviewer-assignment/internal/service/entities"
+	"service-pr-reviewer-assignment/internal/storage"
+	"service-pr-reviewer-assignment/pkg/log"
+	"service-pr-reviewer-assignment/pkg/querier"
+	"service-pr-reviewer-assignment/pkg/tx"
+
+	"github.com/avito-tech/go-transaction-manager/pgxv5"
+)
+
+// TestCreateEventCommitOrder проверяет, что событие параллельной транзакции получает номер
+// только после коммита транзакции, которая сохранила событие раньше.
+func TestCreateEventCommitOrder(t *testing.T) {
+	pool := pgtest.New(t)
+	txManager := tx.Must(pool, log.Must(log.Options{}))
+	storage := storage.Must(querier.Must(pool, pgxv5.DefaultCtxGetter))
+	ctx := tenant.WithOrgID(context.Background(), entities.DefaultOrganizationID)
+
+	newEvent := func() *entities.Event {
+		return &entities.Event{Type: entities.EventTypeUserActivityChanged, TeamName: "payments", CreatedAt: time.Now()}
+	}
+
+	firstCreated := make(chan struct{})
+	commitFirst := make(chan struct{})
+	firstDone := make(chan error, 1)
+	go func() {
+		firstDone <- txManager.Write(ctx, func(ctx context.Context) error {
+			if err := storage.CreateEvent(ctx, newEvent()); err != nil {
+				return err
+			}
+			close(firstCreated)
+			<-commitFirst
+			return nil
+		})
+	}()
+	<-firstCreated
+
+	secondDone := make(chan error, 1)
+	go func() {
+		secondDone <- txManager.Write(ctx, func(ctx context.Context) error {
+			return storage.CreateEvent(ctx, newEvent())
+		})
+	}()
+
+	select {
+	case err := <-secondDone:
+		t.Fatalf("second event was saved before the first transaction committed: %v", err)
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	close(commitFirst)
+	if err := <-firstDone; err != nil {
+		t.Fatalf("first transaction: %v", err)
+	}
+	if err := <-secondDone; err != nil {
+		t.Fatalf("second transaction: %v", err)
+	}
+
+	events, err := storage.ListEventsAfter(ctx, 0, entities.EventFilter{}, 10)
+	if err != nil {
+		t.Fatalf("list events: %v", err)
+	}
+	if len(events) != 2 || events[0].ID >= events[1].ID {
+		t.Errorf("events = %+v, want two events in commit order", events)
+	}
+}
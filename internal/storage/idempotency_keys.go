@@ -0,0 +1,148 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"service-pr-reviewer-assignment/internal/service/entities"
+
+	"github.com/AlekSi/pointer"
+	"github.com/jackc/pgx/v5"
+)
+
+// maxReserveAttempts сколько раз ReserveIdempotencyKey пытается занять ключ, который
+// удаляют параллельно.
+const maxReserveAttempts = 3
+
+type idempotencyKeyDB struct {
+	Key          string
+	RequestHash  string
+	StatusCode   *int
+	ContentType  *string
+	ResponseBody []byte
+	ExpiresAt    time.Time
+}
+
+// ReserveIdempotencyKey занимает ключ под новый запрос. Если ключ уже занят и не истёк,
+// возвращает сохранённую запись и false.
+//
+// Между конфликтом вставки и чтением записи её может удалить очистка истёкших ключей
+// или освобождение ключа после 5xx: тогда резервирование повторяется. Если запись
+// так и не удалось ни занять, ни прочитать, возвращается незавершённая запись с тем
+// же хешем запроса, и вызывающий получает ответ «запрос выполняется».
+func (s *Storage) ReserveIdempotencyKey(
+	ctx context.Context,
+	key entities.IdempotencyKey,
+) (*entities.IdempotencyKey, bool, error) {
+	const query = `
+		INSERT INTO idempotency_keys (key, request_hash, expires_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (key) DO UPDATE
+		SET
+			request_hash = EXCLUDED.request_hash,
+			status_code = NULL,
+			content_type = NULL,
+			response_body = NULL,
+			created_at = NOW(),
+			expires_at = EXCLUDED.expires_at
+		WHERE idempotency_keys.expires_at <= NOW()
+		RETURNING key
+	`
+
+	for attempt := 1; attempt <= maxReserveAttempts; attempt++ {
+		var reserved string
+		err := s.querier.QueryRow(ctx, query, key.Key, key.RequestHash, key.ExpiresAt).Scan(&reserved)
+		if err == nil {
+			return &key, true, nil
+		}
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return nil, false, fmt.Errorf("reserve idempotency key: %w", err)
+		}
+
+		existing, err := s.getIdempotencyKey(ctx, key.Key)
+		if errors.Is(err, pgx.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			return nil, false, err
+		}
+
+		return existing, false, nil
+	}
+
+	return &entities.IdempotencyKey{Key: key.Key, RequestHash: key.RequestHash, ExpiresAt: key.ExpiresAt}, false, nil
+}
+
+func (s *Storage) getIdempotencyKey(ctx context.Context, key string) (*entities.IdempotencyKey, error) {
+	const query = `
+		SELECT key, request_hash, status_code, content_type, response_body, expires_at
+		FROM idempotency_keys
+		WHERE key = $1
+	`
+
+	var keyDB idempotencyKeyDB
+	err := s.querier.QueryRow(ctx, query, key).Scan(
+		&keyDB.Key,
+		&keyDB.RequestHash,
+		&keyDB.StatusCode,
+		&keyDB.ContentType,
+		&keyDB.ResponseBody,
+		&keyDB.ExpiresAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("get idempotency key: %w", err)
+	}
+
+	result := convertIdempotencyKeyDBToEntity(keyDB)
+	return &result, nil
+}
+
+func (s *Storage) SaveIdempotencyKeyResponse(ctx context.Context, key entities.IdempotencyKey) error {
+	const query = `
+		UPDATE idempotency_keys
+		SET status_code = $2, content_type = $3, response_body = $4
+		WHERE key = $1
+	`
+
+	_, err := s.querier.Exec(ctx, query, key.Key, key.StatusCode, key.ContentType, key.ResponseBody)
+	if err != nil {
+		return fmt.Errorf("save idempotency key response: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Storage) DeleteIdempotencyKey(ctx context.Context, key string) error {
+	const query = `DELETE FROM idempotency_keys WHERE key = $1`
+
+	_, err := s.querier.Exec(ctx, query, key)
+	if err != nil {
+		return fmt.Errorf("delete idempotency key: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Storage) DeleteExpiredIdempotencyKeys(ctx context.Context) (int64, error) {
+	const query = `DELETE FROM idempotency_keys WHERE expires_at <= NOW()`
+
+	tag, err := s.querier.Exec(ctx, query)
+	if err != nil {
+		return 0, fmt.Errorf("delete expired idempotency keys: %w", err)
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+func convertIdempotencyKeyDBToEntity(keyDB idempotencyKeyDB) entities.IdempotencyKey {
+	return entities.IdempotencyKey{
+		Key:          keyDB.Key,
+		RequestHash:  keyDB.RequestHash,
+		StatusCode:   pointer.Get(keyDB.StatusCode),
+		ContentType:  pointer.Get(keyDB.ContentType),
+		ResponseBody: keyDB.ResponseBody,
+		ExpiresAt:    keyDB.ExpiresAt,
+	}
+}
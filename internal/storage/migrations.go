@@ -0,0 +1,32 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// IsMigrationApplied проверяет по таблице goose, что миграция с указанной версией применена
+// и не была откачена.
+func (s *Storage) IsMigrationApplied(ctx context.Context, version int64) (bool, error) {
+	const query = `
+		SELECT is_applied
+		FROM goose_db_version
+		WHERE version_id = $1
+		ORDER BY id DESC
+		LIMIT 1
+	`
+
+	var applied bool
+	err := s.querier.QueryRow(ctx, query, version).Scan(&applied)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil
+		}
+		return false, fmt.Errorf("get migration status: %w", err)
+	}
+
+	return applied, nil
+}
@@ -0,0 +1,74 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"service-pr-reviewer-assignment/internal/service/entities"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+type organizationDB struct {
+	ID        uuid.UUID
+	Name      string
+	CreatedAt time.Time
+}
+
+func (s *Storage) CreateOrganization(
+	ctx context.Context,
+	organization *entities.Organization,
+) (*entities.Organization, error) {
+	const query = `
+		INSERT INTO organizations (id, name, created_at)
+		VALUES ($1, $2, $3)
+		RETURNING id, name, created_at
+	`
+
+	var orgDB organizationDB
+	err := s.querier.QueryRow(
+		ctx,
+		query,
+		organization.ID,
+		organization.Name,
+		organization.CreatedAt,
+	).Scan(&orgDB.ID, &orgDB.Name, &orgDB.CreatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+			return nil, &entities.ErrOrganizationAlreadyExists{Name: organization.Name}
+		}
+		return nil, fmt.Errorf("create organization: %w", err)
+	}
+
+	result := convertOrganizationDBToEntity(orgDB)
+	return &result, nil
+}
+
+func (s *Storage) GetOrganizationByID(ctx context.Context, id uuid.UUID) (*entities.Organization, error) {
+	const query = `SELECT id, name, created_at FROM organizations WHERE id = $1`
+
+	var orgDB organizationDB
+	err := s.querier.QueryRow(ctx, query, id).Scan(&orgDB.ID, &orgDB.Name, &orgDB.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, &entities.ErrOrganizationNotFound{ID: id}
+		}
+		return nil, fmt.Errorf("get organization by id: %w", err)
+	}
+
+	result := convertOrganizationDBToEntity(orgDB)
+	return &result, nil
+}
+
+func convertOrganizationDBToEntity(orgDB organizationDB) entities.Organization {
+	return entities.Organization{
+		ID:        orgDB.ID,
+		Name:      orgDB.Name,
+		CreatedAt: orgDB.CreatedAt,
+	}
+}
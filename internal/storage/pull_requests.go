@@ -2,12 +2,15 @@ package storage
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"service-pr-reviewer-assignment/internal/service/entities"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 )
 
 type pullRequestDB struct {
@@ -22,17 +25,22 @@ func (s *Storage) GetPullRequestsByReviewerID(
 	ctx context.Context,
 	userID uuid.UUID,
 ) ([]entities.PullRequest, error) {
+	orgID, err := orgIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	const query = `
 		SELECT id, name, author_id, created_at, merged_at
 		FROM pull_requests
-		WHERE id IN (
+		WHERE org_id = $1 AND id IN (
 			SELECT pull_request_id
 			FROM pull_request_reviewers
-			WHERE reviewer_id = $1
+			WHERE org_id = $1 AND reviewer_id = $2
 		)
 	`
 
-	rows, err := s.querier.Query(ctx, query, userID)
+	rows, err := s.querier.Query(ctx, query, orgID, userID)
 	if err != nil {
 		return nil, fmt.Errorf("query pull requests by reviewer: %w", err)
 	}
@@ -55,16 +63,22 @@ func (s *Storage) GetPullRequestsByReviewerID(
 }
 
 func (s *Storage) CreatePullRequest(ctx context.Context, pullRequest *entities.PullRequest) (*entities.PullRequest, error) {
+	orgID, err := orgIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	const query = `
-		INSERT INTO pull_requests (id, name, author_id, created_at, merged_at)
-		VALUES ($1, $2, $3, $4, $5)
+		INSERT INTO pull_requests (org_id, id, name, author_id, created_at, merged_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
 		RETURNING id, name, author_id, created_at, merged_at
 	`
 
 	var prDB pullRequestDB
-	err := s.querier.QueryRow(
+	err = s.querier.QueryRow(
 		ctx,
 		query,
+		orgID,
 		pullRequest.ID,
 		pullRequest.Name,
 		pullRequest.AuthorID,
@@ -72,6 +86,10 @@ func (s *Storage) CreatePullRequest(ctx context.Context, pullRequest *entities.P
 		pullRequest.MergedAt,
 	).Scan(&prDB.ID, &prDB.Name, &prDB.AuthorID, &prDB.CreatedAt, &prDB.MergedAt)
 	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+			return nil, &entities.ErrPullRequestAlreadyExists{ID: pullRequest.ID}
+		}
 		return nil, fmt.Errorf("create pull request: %w", err)
 	}
 
@@ -84,12 +102,17 @@ func (s *Storage) CreatePullRequestReviewers(ctx context.Context, pullRequestID
 		return nil
 	}
 
+	orgID, err := orgIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
 	builder := s.stmtBuilder.
 		Insert("pull_request_reviewers").
-		Columns("pull_request_id", "reviewer_id")
+		Columns("org_id", "pull_request_id", "reviewer_id")
 
 	for _, reviewerID := range reviewerIDs {
-		builder = builder.Values(pullRequestID, reviewerID)
+		builder = builder.Values(orgID, pullRequestID, reviewerID)
 	}
 
 	query, args, err := builder.ToSql()
@@ -106,10 +129,15 @@ func (s *Storage) CreatePullRequestReviewers(ctx context.Context, pullRequestID
 }
 
 func (s *Storage) GetPullRequestByID(ctx context.Context, id uuid.UUID) (*entities.PullRequest, error) {
-	const query = `SELECT id, name, author_id, created_at, merged_at FROM pull_requests WHERE id = $1`
+	orgID, err := orgIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	const query = `SELECT id, name, author_id, created_at, merged_at FROM pull_requests WHERE org_id = $1 AND id = $2`
 
 	var prDB pullRequestDB
-	err := s.querier.QueryRow(ctx, query, id).Scan(
+	err = s.querier.QueryRow(ctx, query, orgID, id).Scan(
 		&prDB.ID,
 		&prDB.Name,
 		&prDB.AuthorID,
@@ -117,6 +145,9 @@ func (s *Storage) GetPullRequestByID(ctx context.Context, id uuid.UUID) (*entiti
 		&prDB.MergedAt,
 	)
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, &entities.ErrPullRequestNotFound{ID: id}
+		}
 		return nil, fmt.Errorf("get pull request by id: %w", err)
 	}
 
@@ -125,9 +156,14 @@ func (s *Storage) GetPullRequestByID(ctx context.Context, id uuid.UUID) (*entiti
 }
 
 func (s *Storage) GetPullRequestReviewerIDs(ctx context.Context, pullRequestID uuid.UUID) ([]uuid.UUID, error) {
-	const query = `SELECT reviewer_id FROM pull_request_reviewers WHERE pull_request_id = $1`
+	orgID, err := orgIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	const query = `SELECT reviewer_id FROM pull_request_reviewers WHERE org_id = $1 AND pull_request_id = $2`
 
-	rows, err := s.querier.Query(ctx, query, pullRequestID)
+	rows, err := s.querier.Query(ctx, query, orgID, pullRequestID)
 	if err != nil {
 		return nil, fmt.Errorf("query reviewers: %w", err)
 	}
@@ -150,17 +186,23 @@ func (s *Storage) GetPullRequestReviewerIDs(ctx context.Context, pullRequestID u
 }
 
 func (s *Storage) UpdatePullRequest(ctx context.Context, pullRequest *entities.PullRequest) (*entities.PullRequest, error) {
+	orgID, err := orgIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	const query = `
 		UPDATE pull_requests 
-		SET name = $2, author_id = $3, merged_at = $4
-		WHERE id = $1
+		SET name = $3, author_id = $4, merged_at = $5
+		WHERE org_id = $1 AND id = $2
 		RETURNING id, name, author_id, created_at, merged_at
 	`
 
 	var prDB pullRequestDB
-	err := s.querier.QueryRow(
+	err = s.querier.QueryRow(
 		ctx,
 		query,
+		orgID,
 		pullRequest.ID,
 		pullRequest.Name,
 		pullRequest.AuthorID,
@@ -175,9 +217,14 @@ func (s *Storage) UpdatePullRequest(ctx context.Context, pullRequest *entities.P
 }
 
 func (s *Storage) DeletePullRequestReviewersByReviewerID(ctx context.Context, reviewerID uuid.UUID) error {
-	const query = `DELETE FROM pull_request_reviewers WHERE reviewer_id = $1`
+	orgID, err := orgIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	const query = `DELETE FROM pull_request_reviewers WHERE org_id = $1 AND reviewer_id = $2`
 
-	_, err := s.querier.Exec(ctx, query, reviewerID)
+	_, err = s.querier.Exec(ctx, query, orgID, reviewerID)
 	if err != nil {
 		return fmt.Errorf("delete reviewers by reviewer id: %w", err)
 	}
@@ -190,9 +237,14 @@ func (s *Storage) DeletePullRequestReviewerByPullRequestIDAndReviewerID(
 	pullRequestID uuid.UUID,
 	reviewerID uuid.UUID,
 ) error {
-	const query = `DELETE FROM pull_request_reviewers WHERE pull_request_id = $1 AND reviewer_id = $2`
+	orgID, err := orgIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	const query = `DELETE FROM pull_request_reviewers WHERE org_id = $1 AND pull_request_id = $2 AND reviewer_id = $3`
 
-	_, err := s.querier.Exec(ctx, query, pullRequestID, reviewerID)
+	_, err = s.querier.Exec(ctx, query, orgID, pullRequestID, reviewerID)
 	if err != nil {
 		return fmt.Errorf("delete reviewer: %w", err)
 	}
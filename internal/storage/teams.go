@@ -7,6 +7,8 @@ import (
 
 	"service-pr-reviewer-assignment/internal/service/entities"
 
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgconn"
 )
 
@@ -15,10 +17,15 @@ type teamDB struct {
 }
 
 func (s *Storage) CreateTeam(ctx context.Context, teamName string) (*entities.Team, error) {
-	const query = `INSERT INTO teams (name) VALUES ($1) RETURNING name`
+	orgID, err := orgIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	const query = `INSERT INTO teams (org_id, name) VALUES ($1, $2) RETURNING name`
 
 	var teamDB teamDB
-	err := s.querier.QueryRow(ctx, query, teamName).Scan(&teamDB.Name)
+	err = s.querier.QueryRow(ctx, query, orgID, teamName).Scan(&teamDB.Name)
 	if err != nil {
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
@@ -32,10 +39,15 @@ func (s *Storage) CreateTeam(ctx context.Context, teamName string) (*entities.Te
 }
 
 func (s *Storage) IsTeamExists(ctx context.Context, teamName string) (bool, error) {
-	const query = `SELECT EXISTS(SELECT 1 FROM teams WHERE name = $1)`
+	orgID, err := orgIDFromContext(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	const query = `SELECT EXISTS(SELECT 1 FROM teams WHERE org_id = $1 AND name = $2)`
 
 	var exists bool
-	err := s.querier.QueryRow(ctx, query, teamName).Scan(&exists)
+	err = s.querier.QueryRow(ctx, query, orgID, teamName).Scan(&exists)
 	if err != nil {
 		return false, fmt.Errorf("check team exists: %w", err)
 	}
@@ -43,6 +55,91 @@ func (s *Storage) IsTeamExists(ctx context.Context, teamName string) (bool, erro
 	return exists, nil
 }
 
+// GetTeams возвращает команды организации по фильтру, упорядоченные по имени, без участников.
+func (s *Storage) GetTeams(ctx context.Context, filter entities.TeamFilter, offset, limit uint64) ([]entities.Team, error) {
+	orgID, err := orgIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query, args, err := teamsWhere(s.stmtBuilder.Select("name").From("teams"), orgID, filter).
+		OrderBy("name").
+		Offset(offset).
+		Limit(limit).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build select query: %w", err)
+	}
+
+	rows, err := s.querier.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query teams: %w", err)
+	}
+	defer rows.Close()
+
+	var teams []entities.Team
+	for rows.Next() {
+		var teamDB teamDB
+		if err := rows.Scan(&teamDB.Name); err != nil {
+			return nil, fmt.Errorf("scan team: %w", err)
+		}
+		teams = append(teams, convertTeamDBToEntity(teamDB))
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return teams, nil
+}
+
+func (s *Storage) CountTeams(ctx context.Context, filter entities.TeamFilter) (int, error) {
+	orgID, err := orgIDFromContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	query, args, err := teamsWhere(s.stmtBuilder.Select("COUNT(*)").From("teams"), orgID, filter).ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("build count query: %w", err)
+	}
+
+	var count int
+	if err := s.querier.QueryRow(ctx, query, args...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("count teams: %w", err)
+	}
+
+	return count, nil
+}
+
+// DeleteTeam удаляет команду. Её участники остаются без команды (ON DELETE SET NULL).
+func (s *Storage) DeleteTeam(ctx context.Context, teamName string) error {
+	orgID, err := orgIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	const query = `DELETE FROM teams WHERE org_id = $1 AND name = $2`
+
+	tag, err := s.querier.Exec(ctx, query, orgID, teamName)
+	if err != nil {
+		return fmt.Errorf("delete team: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return &entities.ErrTeamNotFound{Name: teamName}
+	}
+
+	return nil
+}
+
+func teamsWhere(builder squirrel.SelectBuilder, orgID uuid.UUID, filter entities.TeamFilter) squirrel.SelectBuilder {
+	builder = builder.Where(squirrel.Eq{"org_id": orgID})
+	if filter.Name != nil {
+		builder = builder.Where(squirrel.Eq{"name": *filter.Name})
+	}
+	return builder
+}
+
 func convertTeamDBToEntity(teamDB teamDB) entities.Team {
 	return entities.Team{
 		Name: teamDB.Name,
@@ -0,0 +1,21 @@
+package storage
+
+import (
+	"context"
+
+	"service-pr-reviewer-assignment/internal/pkg/tenant"
+	"service-pr-reviewer-assignment/internal/service/entities"
+
+	"github.com/google/uuid"
+)
+
+// orgIDFromContext возвращает организацию, которой ограничиваются запросы.
+// Без организации в контексте запрос не выполняется, чтобы случайно не
+// прочитать или не изменить данные всех организаций.
+func orgIDFromContext(ctx context.Context) (uuid.UUID, error) {
+	orgID, ok := tenant.FromContext(ctx)
+	if !ok {
+		return uuid.Nil, entities.ErrTenantNotResolved
+	}
+	return orgID, nil
+}
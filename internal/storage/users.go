@@ -8,6 +8,7 @@ import (
 	"service-pr-reviewer-assignment/internal/service/entities"
 
 	"github.com/AlekSi/pointer"
+	"github.com/Masterminds/squirrel"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 )
@@ -17,6 +18,7 @@ type userDB struct {
 	Name     string
 	TeamName string
 	IsActive bool
+	Role     string
 }
 
 func (s *Storage) CreateOrUpdateUsers(ctx context.Context, users []entities.User) ([]entities.User, error) {
@@ -24,24 +26,32 @@ func (s *Storage) CreateOrUpdateUsers(ctx context.Context, users []entities.User
 		return nil, nil
 	}
 
+	orgID, err := orgIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	builder := s.stmtBuilder.
 		Insert("users").
-		Columns("id", "name", "team_name", "is_active")
+		Columns("org_id", "id", "name", "team_name", "is_active", "role")
 
 	for _, user := range users {
 		if user.ID == uuid.Nil {
 			user.ID = uuid.New()
 		}
-		builder = builder.Values(user.ID, user.Name, user.TeamName, user.IsActive)
+		if user.Role == "" {
+			user.Role = entities.UserRoleMember
+		}
+		builder = builder.Values(orgID, user.ID, user.Name, teamNameDB(user.TeamName), user.IsActive, string(user.Role))
 	}
 
 	query, args, err := builder.Suffix(`
-        ON CONFLICT (id) DO UPDATE
+        ON CONFLICT (org_id, id) DO UPDATE
         SET
             name = EXCLUDED.name,
             team_name = EXCLUDED.team_name,
             is_active = EXCLUDED.is_active
-        RETURNING id, name, team_name, is_active
+        RETURNING id, name, COALESCE(team_name, ''), is_active, role
     `).ToSql()
 	if err != nil {
 		return nil, fmt.Errorf("build upsert query: %w", err)
@@ -56,7 +66,7 @@ func (s *Storage) CreateOrUpdateUsers(ctx context.Context, users []entities.User
 	var usersDB []userDB
 	for rows.Next() {
 		var userDB userDB
-		if err := rows.Scan(&userDB.ID, &userDB.Name, &userDB.TeamName, &userDB.IsActive); err != nil {
+		if err := rows.Scan(&userDB.ID, &userDB.Name, &userDB.TeamName, &userDB.IsActive, &userDB.Role); err != nil {
 			return nil, fmt.Errorf("scan user: %w", err)
 		}
 		usersDB = append(usersDB, userDB)
@@ -70,9 +80,14 @@ func (s *Storage) CreateOrUpdateUsers(ctx context.Context, users []entities.User
 }
 
 func (s *Storage) GetUsersByTeamName(ctx context.Context, teamName string) ([]entities.User, error) {
-	const query = `SELECT id, name, team_name, is_active FROM users WHERE team_name = $1`
+	orgID, err := orgIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
 
-	rows, err := s.querier.Query(ctx, query, teamName)
+	const query = `SELECT id, name, COALESCE(team_name, ''), is_active, role FROM users WHERE org_id = $1 AND team_name = $2`
+
+	rows, err := s.querier.Query(ctx, query, orgID, teamName)
 	if err != nil {
 		return nil, fmt.Errorf("query users by team: %w", err)
 	}
@@ -81,7 +96,7 @@ func (s *Storage) GetUsersByTeamName(ctx context.Context, teamName string) ([]en
 	var usersDB []userDB
 	for rows.Next() {
 		var userDB userDB
-		if err := rows.Scan(&userDB.ID, &userDB.Name, &userDB.TeamName, &userDB.IsActive); err != nil {
+		if err := rows.Scan(&userDB.ID, &userDB.Name, &userDB.TeamName, &userDB.IsActive, &userDB.Role); err != nil {
 			return nil, fmt.Errorf("scan user: %w", err)
 		}
 		usersDB = append(usersDB, userDB)
@@ -95,10 +110,15 @@ func (s *Storage) GetUsersByTeamName(ctx context.Context, teamName string) ([]en
 }
 
 func (s *Storage) IsUserExists(ctx context.Context, userID uuid.UUID) (bool, error) {
-	const query = `SELECT EXISTS(SELECT 1 FROM users WHERE id = $1)`
+	orgID, err := orgIDFromContext(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	const query = `SELECT EXISTS(SELECT 1 FROM users WHERE org_id = $1 AND id = $2)`
 
 	var exists bool
-	err := s.querier.QueryRow(ctx, query, userID).Scan(&exists)
+	err = s.querier.QueryRow(ctx, query, orgID, userID).Scan(&exists)
 	if err != nil {
 		return false, fmt.Errorf("check user exists: %w", err)
 	}
@@ -107,10 +127,15 @@ func (s *Storage) IsUserExists(ctx context.Context, userID uuid.UUID) (bool, err
 }
 
 func (s *Storage) GetUserByID(ctx context.Context, userID uuid.UUID) (*entities.User, error) {
-	const query = `SELECT id, name, team_name, is_active FROM users WHERE id = $1`
+	orgID, err := orgIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	const query = `SELECT id, name, COALESCE(team_name, ''), is_active, role FROM users WHERE org_id = $1 AND id = $2`
 
 	var userDB userDB
-	err := s.querier.QueryRow(ctx, query, userID).Scan(&userDB.ID, &userDB.Name, &userDB.TeamName, &userDB.IsActive)
+	err = s.querier.QueryRow(ctx, query, orgID, userID).Scan(&userDB.ID, &userDB.Name, &userDB.TeamName, &userDB.IsActive, &userDB.Role)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, &entities.ErrUserNotFound{UserID: pointer.To(userID)}
@@ -123,26 +148,34 @@ func (s *Storage) GetUserByID(ctx context.Context, userID uuid.UUID) (*entities.
 }
 
 func (s *Storage) UpdateUser(ctx context.Context, user *entities.User) (*entities.User, error) {
+	orgID, err := orgIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	const query = `
         UPDATE users 
-        SET name = $2, team_name = $3, is_active = $4
-        WHERE id = $1
-        RETURNING id, name, team_name, is_active
+        SET name = $3, team_name = $4, is_active = $5, role = $6
+        WHERE org_id = $1 AND id = $2
+        RETURNING id, name, COALESCE(team_name, ''), is_active, role
     `
 
 	var userDB userDB
-	err := s.querier.QueryRow(
+	err = s.querier.QueryRow(
 		ctx,
 		query,
+		orgID,
 		user.ID,
 		user.Name,
-		user.TeamName,
+		teamNameDB(user.TeamName),
 		user.IsActive,
+		string(user.Role),
 	).Scan(
 		&userDB.ID,
 		&userDB.Name,
 		&userDB.TeamName,
 		&userDB.IsActive,
+		&userDB.Role,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("update user: %w", err)
@@ -152,6 +185,84 @@ func (s *Storage) UpdateUser(ctx context.Context, user *entities.User) (*entitie
 	return &updatedUser, nil
 }
 
+// GetUsers возвращает пользователей организации по фильтру, упорядоченных по имени.
+func (s *Storage) GetUsers(ctx context.Context, filter entities.UserFilter, offset, limit uint64) ([]entities.User, error) {
+	orgID, err := orgIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query, args, err := usersWhere(s.stmtBuilder.
+		Select("id", "name", "COALESCE(team_name, '')", "is_active", "role").
+		From("users"), orgID, filter).
+		OrderBy("name", "id").
+		Offset(offset).
+		Limit(limit).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build select query: %w", err)
+	}
+
+	rows, err := s.querier.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query users: %w", err)
+	}
+	defer rows.Close()
+
+	var usersDB []userDB
+	for rows.Next() {
+		var userDB userDB
+		if err := rows.Scan(&userDB.ID, &userDB.Name, &userDB.TeamName, &userDB.IsActive, &userDB.Role); err != nil {
+			return nil, fmt.Errorf("scan user: %w", err)
+		}
+		usersDB = append(usersDB, userDB)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return convertUsersDBToEntities(usersDB), nil
+}
+
+func (s *Storage) CountUsers(ctx context.Context, filter entities.UserFilter) (int, error) {
+	orgID, err := orgIDFromContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	query, args, err := usersWhere(s.stmtBuilder.Select("COUNT(*)").From("users"), orgID, filter).ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("build count query: %w", err)
+	}
+
+	var count int
+	if err := s.querier.QueryRow(ctx, query, args...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("count users: %w", err)
+	}
+
+	return count, nil
+}
+
+func usersWhere(builder squirrel.SelectBuilder, orgID uuid.UUID, filter entities.UserFilter) squirrel.SelectBuilder {
+	builder = builder.Where(squirrel.Eq{"org_id": orgID})
+	if filter.Name != nil {
+		builder = builder.Where(squirrel.Eq{"name": *filter.Name})
+	}
+	if filter.ExcludeID != nil {
+		builder = builder.Where(squirrel.NotEq{"id": *filter.ExcludeID})
+	}
+	return builder
+}
+
+// teamNameDB сохраняет пользователя без команды с team_name NULL.
+func teamNameDB(teamName string) *string {
+	if teamName == "" {
+		return nil
+	}
+	return &teamName
+}
+
 func convertUsersDBToEntities(usersDB []userDB) []entities.User {
 	out := make([]entities.User, 0, len(usersDB))
 	for _, userDB := range usersDB {
@@ -166,5 +277,6 @@ func convertUserDBToEntity(userDB userDB) entities.User {
 		Name:     userDB.Name,
 		TeamName: userDB.TeamName,
 		IsActive: userDB.IsActive,
+		Role:     entities.UserRole(userDB.Role),
 	}
 }
@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+                                                key TEXT PRIMARY KEY,
+                                                request_hash TEXT NOT NULL,
+                                                status_code INTEGER,
+                                                content_type TEXT,
+                                                response_body BYTEA,
+                                                created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+                                                expires_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_expires_at ON idempotency_keys(expires_at);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_idempotency_keys_expires_at;
+DROP TABLE IF EXISTS idempotency_keys;
+-- +goose StatementEnd
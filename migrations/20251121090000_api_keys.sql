@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS api_keys (
+                                        id UUID PRIMARY KEY,
+                                        name TEXT NOT NULL,
+                                        key_hash TEXT NOT NULL UNIQUE,
+                                        scopes TEXT[] NOT NULL,
+                                        created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+                                        revoked_at TIMESTAMPTZ
+);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS api_keys;
+-- +goose StatementEnd
@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS audit_log (
+                                         id BIGSERIAL PRIMARY KEY,
+                                         actor TEXT NOT NULL,
+                                         action TEXT NOT NULL,
+                                         entity_id TEXT NOT NULL,
+                                         created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_log_actor ON audit_log(actor);
+CREATE INDEX IF NOT EXISTS idx_audit_log_entity_id ON audit_log(entity_id);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_audit_log_entity_id;
+DROP INDEX IF EXISTS idx_audit_log_actor;
+DROP TABLE IF EXISTS audit_log;
+-- +goose StatementEnd
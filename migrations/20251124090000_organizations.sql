@@ -0,0 +1,122 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS organizations (
+                                             id UUID PRIMARY KEY,
+                                             name TEXT NOT NULL UNIQUE,
+                                             created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Организация по умолчанию, к ней относятся все данные, созданные до появления организаций.
+INSERT INTO organizations (id, name)
+VALUES ('00000000-0000-0000-0000-000000000001', 'default')
+ON CONFLICT DO NOTHING;
+
+ALTER TABLE teams ADD COLUMN org_id UUID NOT NULL DEFAULT '00000000-0000-0000-0000-000000000001' REFERENCES organizations(id);
+ALTER TABLE users ADD COLUMN org_id UUID NOT NULL DEFAULT '00000000-0000-0000-0000-000000000001' REFERENCES organizations(id);
+ALTER TABLE pull_requests ADD COLUMN org_id UUID NOT NULL DEFAULT '00000000-0000-0000-0000-000000000001' REFERENCES organizations(id);
+ALTER TABLE pull_request_reviewers ADD COLUMN org_id UUID NOT NULL DEFAULT '00000000-0000-0000-0000-000000000001' REFERENCES organizations(id);
+ALTER TABLE api_keys ADD COLUMN org_id UUID NOT NULL DEFAULT '00000000-0000-0000-0000-000000000001' REFERENCES organizations(id);
+ALTER TABLE audit_log ADD COLUMN org_id UUID NOT NULL DEFAULT '00000000-0000-0000-0000-000000000001' REFERENCES organizations(id);
+
+-- Значение по умолчанию нужно только для существующих строк, дальше org_id задаёт приложение.
+ALTER TABLE teams ALTER COLUMN org_id DROP DEFAULT;
+ALTER TABLE users ALTER COLUMN org_id DROP DEFAULT;
+ALTER TABLE pull_requests ALTER COLUMN org_id DROP DEFAULT;
+ALTER TABLE pull_request_reviewers ALTER COLUMN org_id DROP DEFAULT;
+ALTER TABLE api_keys ALTER COLUMN org_id DROP DEFAULT;
+ALTER TABLE audit_log ALTER COLUMN org_id DROP DEFAULT;
+
+ALTER TABLE pull_request_reviewers
+    DROP CONSTRAINT pull_request_reviewers_pull_request_id_fkey,
+    DROP CONSTRAINT pull_request_reviewers_reviewer_id_fkey,
+    DROP CONSTRAINT pull_request_reviewers_pkey;
+ALTER TABLE pull_requests
+    DROP CONSTRAINT pull_requests_author_id_fkey,
+    DROP CONSTRAINT pull_requests_pkey;
+ALTER TABLE users
+    DROP CONSTRAINT users_team_name_fkey,
+    DROP CONSTRAINT users_pkey;
+ALTER TABLE teams
+    DROP CONSTRAINT teams_pkey;
+
+DROP INDEX IF EXISTS idx_pr_reviewers_reviewer_id;
+DROP INDEX IF EXISTS idx_pull_requests_author_id;
+DROP INDEX IF EXISTS idx_users_team_name;
+
+ALTER TABLE teams ADD PRIMARY KEY (org_id, name);
+
+ALTER TABLE users
+    ADD PRIMARY KEY (org_id, id),
+    ADD CONSTRAINT users_team_fkey FOREIGN KEY (org_id, team_name)
+        REFERENCES teams(org_id, name) ON DELETE SET NULL (team_name);
+
+ALTER TABLE pull_requests
+    ADD PRIMARY KEY (org_id, id),
+    ADD CONSTRAINT pull_requests_author_fkey FOREIGN KEY (org_id, author_id)
+        REFERENCES users(org_id, id);
+
+ALTER TABLE pull_request_reviewers
+    ADD PRIMARY KEY (org_id, pull_request_id, reviewer_id),
+    ADD CONSTRAINT pull_request_reviewers_pull_request_fkey FOREIGN KEY (org_id, pull_request_id)
+        REFERENCES pull_requests(org_id, id) ON DELETE CASCADE,
+    ADD CONSTRAINT pull_request_reviewers_reviewer_fkey FOREIGN KEY (org_id, reviewer_id)
+        REFERENCES users(org_id, id) ON DELETE CASCADE;
+
+CREATE INDEX IF NOT EXISTS idx_users_org_team_name ON users(org_id, team_name);
+CREATE INDEX IF NOT EXISTS idx_pull_requests_org_author_id ON pull_requests(org_id, author_id);
+CREATE INDEX IF NOT EXISTS idx_pr_reviewers_org_reviewer_id ON pull_request_reviewers(org_id, reviewer_id);
+CREATE INDEX IF NOT EXISTS idx_api_keys_org_id ON api_keys(org_id);
+CREATE INDEX IF NOT EXISTS idx_audit_log_org_id ON audit_log(org_id);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+-- Откат возможен, только если данные разных организаций не пересекаются по ключам.
+DROP INDEX IF EXISTS idx_audit_log_org_id;
+DROP INDEX IF EXISTS idx_api_keys_org_id;
+DROP INDEX IF EXISTS idx_pr_reviewers_org_reviewer_id;
+DROP INDEX IF EXISTS idx_pull_requests_org_author_id;
+DROP INDEX IF EXISTS idx_users_org_team_name;
+
+ALTER TABLE pull_request_reviewers
+    DROP CONSTRAINT pull_request_reviewers_reviewer_fkey,
+    DROP CONSTRAINT pull_request_reviewers_pull_request_fkey,
+    DROP CONSTRAINT pull_request_reviewers_pkey;
+ALTER TABLE pull_requests
+    DROP CONSTRAINT pull_requests_author_fkey,
+    DROP CONSTRAINT pull_requests_pkey;
+ALTER TABLE users
+    DROP CONSTRAINT users_team_fkey,
+    DROP CONSTRAINT users_pkey;
+ALTER TABLE teams
+    DROP CONSTRAINT teams_pkey;
+
+ALTER TABLE teams ADD PRIMARY KEY (name);
+ALTER TABLE users
+    ADD PRIMARY KEY (id),
+    ADD CONSTRAINT users_team_name_fkey FOREIGN KEY (team_name) REFERENCES teams(name) ON DELETE SET NULL;
+ALTER TABLE pull_requests
+    ADD PRIMARY KEY (id),
+    ADD CONSTRAINT pull_requests_author_id_fkey FOREIGN KEY (author_id) REFERENCES users(id);
+ALTER TABLE pull_request_reviewers
+    ADD PRIMARY KEY (pull_request_id, reviewer_id),
+    ADD CONSTRAINT pull_request_reviewers_pull_request_id_fkey FOREIGN KEY (pull_request_id)
+        REFERENCES pull_requests(id) ON DELETE CASCADE,
+    ADD CONSTRAINT pull_request_reviewers_reviewer_id_fkey FOREIGN KEY (reviewer_id)
+        REFERENCES users(id) ON DELETE CASCADE;
+
+CREATE INDEX IF NOT EXISTS idx_users_team_name ON users(team_name);
+CREATE INDEX IF NOT EXISTS idx_pull_requests_author_id ON pull_requests(author_id);
+CREATE INDEX IF NOT EXISTS idx_pr_reviewers_reviewer_id ON pull_request_reviewers(reviewer_id);
+
+ALTER TABLE audit_log DROP COLUMN org_id;
+ALTER TABLE api_keys DROP COLUMN org_id;
+ALTER TABLE pull_request_reviewers DROP COLUMN org_id;
+ALTER TABLE pull_requests DROP COLUMN org_id;
+ALTER TABLE users DROP COLUMN org_id;
+ALTER TABLE teams DROP COLUMN org_id;
+
+DROP TABLE IF EXISTS organizations;
+-- +goose StatementEnd
@@ -0,0 +1,40 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS events (
+                                      id BIGSERIAL PRIMARY KEY,
+                                      org_id UUID NOT NULL REFERENCES organizations(id),
+                                      type TEXT NOT NULL,
+                                      team_name TEXT NOT NULL,
+                                      pull_request_id UUID,
+                                      user_ids UUID[] NOT NULL DEFAULT '{}',
+                                      payload JSONB NOT NULL DEFAULT '{}',
+                                      created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_events_org_id_id ON events(org_id, id);
+CREATE INDEX IF NOT EXISTS idx_events_created_at ON events(created_at);
+
+-- Уведомление уходит при коммите транзакции, поэтому реплики узнают только о сохранённых событиях.
+-- В payload только ключ события, само событие читается из таблицы.
+CREATE OR REPLACE FUNCTION notify_event() RETURNS TRIGGER AS $$
+BEGIN
+    PERFORM pg_notify('events', json_build_object('id', NEW.id, 'org_id', NEW.org_id)::text);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER events_notify
+    AFTER INSERT ON events
+    FOR EACH ROW EXECUTE FUNCTION notify_event();
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TRIGGER IF EXISTS events_notify ON events;
+DROP FUNCTION IF EXISTS notify_event();
+DROP INDEX IF EXISTS idx_events_created_at;
+DROP INDEX IF EXISTS idx_events_org_id_id;
+DROP TABLE IF EXISTS events;
+-- +goose StatementEnd
@@ -0,0 +1,8 @@
+package migrations
+
+import "embed"
+
+// FS содержит SQL-миграции goose, чтобы сервис мог сверить версию схемы с бинарником.
+//
+//go:embed *.sql
+var FS embed.FS
@@ -0,0 +1,111 @@
+package log
+
+import (
+	"context"
+	"log/slog"
+	"maps"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// state общее для логгера и всех его производных обработчиков состояние.
+type state struct {
+	levels  atomic.Pointer[levels]
+	sampler *sampler
+}
+
+func (s *state) setLevels(level slog.Level, packageLevels map[string]slog.Level) {
+	minLevel := level
+	for _, packageLevel := range packageLevels {
+		minLevel = min(minLevel, packageLevel)
+	}
+
+	s.levels.Store(&levels{
+		level:    level,
+		packages: maps.Clone(packageLevels),
+		min:      minLevel,
+	})
+}
+
+// levels неизменяемый набор уровней. При изменении уровней заменяется целиком
+// вместе с кэшем уровней по месту вызова.
+type levels struct {
+	level    slog.Level
+	packages map[string]slog.Level
+	min      slog.Level
+	byPC     sync.Map
+}
+
+func (l *levels) snapshot() (slog.Level, map[string]slog.Level) {
+	packages := maps.Clone(l.packages)
+	if packages == nil {
+		packages = make(map[string]slog.Level)
+	}
+	return l.level, packages
+}
+
+// levelFor возвращает уровень для места вызова: уровень самого длинного
+// подходящего пакета или общий уровень.
+func (l *levels) levelFor(pc uintptr) slog.Level {
+	if len(l.packages) == 0 || pc == 0 {
+		return l.level
+	}
+	if cached, ok := l.byPC.Load(pc); ok {
+		return cached.(slog.Level)
+	}
+
+	frame, _ := runtime.CallersFrames([]uintptr{pc}).Next()
+	pkg := packagePath(frame.Function)
+
+	level, matched := l.level, ""
+	for prefix, packageLevel := range l.packages {
+		if len(prefix) > len(matched) && (pkg == prefix || strings.HasSuffix(pkg, "/"+prefix)) {
+			level, matched = packageLevel, prefix
+		}
+	}
+
+	l.byPC.Store(pc, level)
+	return level
+}
+
+// packagePath выделяет путь пакета из полного имени функции,
+// например "module/internal/storage.(*Storage).GetTeam".
+func packagePath(function string) string {
+	lastSlash := strings.LastIndex(function, "/")
+	dot := strings.Index(function[lastSlash+1:], ".")
+	if dot < 0 {
+		return function
+	}
+	return function[:lastSlash+1+dot]
+}
+
+// filterHandler отбрасывает записи ниже уровня пакета вызывающего и
+// сэмплирует повторяющиеся сообщения перед передачей во вложенный обработчик.
+type filterHandler struct {
+	next  slog.Handler
+	state *state
+}
+
+func (h *filterHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.state.levels.Load().min
+}
+
+func (h *filterHandler) Handle(ctx context.Context, record slog.Record) error {
+	if record.Level < h.state.levels.Load().levelFor(record.PC) {
+		return nil
+	}
+	if skip, _ := ctx.Value(noSamplingKey).(bool); !skip && !h.state.sampler.allow(record) {
+		return nil
+	}
+	return h.next.Handle(ctx, record)
+}
+
+func (h *filterHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &filterHandler{next: h.next.WithAttrs(attrs), state: h.state}
+}
+
+func (h *filterHandler) WithGroup(name string) slog.Handler {
+	return &filterHandler{next: h.next.WithGroup(name), state: h.state}
+}
@@ -3,13 +3,34 @@ package log
 import (
 	"context"
 	"fmt"
+	"io"
 	"log/slog"
 	"os"
+	"runtime"
 	"sync"
+	"time"
 )
 
+type Format string
+
+const (
+	FormatJSON Format = "json"
+	FormatText Format = "text"
+)
+
+// Options настройки логгера. Нулевое значение — JSON в stdout с уровнем INFO без сэмплирования.
+type Options struct {
+	Level slog.Level
+	// PackageLevels переопределяет уровень для пакетов. Ключ — путь пакета или его
+	// суффикс по границе сегмента, например "internal/storage" или "pkg/tx".
+	PackageLevels map[string]slog.Level
+	Format        Format
+	Output        io.Writer
+	Sampling      Sampling
+}
+
 type Logger struct {
-	internal *slog.Logger
+	handler *filterHandler
 }
 
 var (
@@ -17,20 +38,44 @@ var (
 	once         sync.Once
 )
 
-func Must() *Logger {
-	handler := slog.NewJSONHandler(os.Stdout, nil)
+func Must(opts Options) *Logger {
+	output := opts.Output
+	if output == nil {
+		output = os.Stdout
+	}
+
+	var handler slog.Handler
+	if opts.Format == FormatText {
+		handler = slog.NewTextHandler(output, &slog.HandlerOptions{Level: slog.LevelDebug})
+	} else {
+		handler = slog.NewJSONHandler(output, &slog.HandlerOptions{Level: slog.LevelDebug})
+	}
+
+	state := &state{sampler: newSampler(opts.Sampling)}
+	state.setLevels(opts.Level, opts.PackageLevels)
+
 	return &Logger{
-		internal: slog.New(handler),
+		handler: &filterHandler{next: handler, state: state},
 	}
 }
 
 func getGlobal() *Logger {
 	once.Do(func() {
-		globalLogger = Must()
+		globalLogger = Must(Options{})
 	})
 	return globalLogger
 }
 
+// SetLevels меняет общий уровень и уровни пакетов на лету.
+func (l *Logger) SetLevels(level slog.Level, packageLevels map[string]slog.Level) {
+	l.handler.state.setLevels(level, packageLevels)
+}
+
+// Levels возвращает текущий общий уровень и копию уровней пакетов.
+func (l *Logger) Levels() (slog.Level, map[string]slog.Level) {
+	return l.handler.state.levels.Load().snapshot()
+}
+
 type ctxKeyType struct{}
 
 var ctxKey ctxKeyType
@@ -43,111 +88,172 @@ func (l *Logger) LogCtx(ctx context.Context, fields ...any) context.Context {
 	return context.WithValue(ctx, ctxKey, combined)
 }
 
-func (l *Logger) withContext(ctx context.Context) *slog.Logger {
+type noSamplingKeyType struct{}
+
+var noSamplingKey noSamplingKeyType
+
+// WithoutSampling помечает контекст: записи с ним не сэмплируются. Нужен журналам, где
+// важна каждая запись, например access log: все его записи идут из одного места вызова
+// с одним текстом и иначе попадают в один поток сэмплирования.
+func (l *Logger) WithoutSampling(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noSamplingKey, true)
+}
+
+func (l *Logger) withContext(ctx context.Context) slog.Handler {
 	if ctx == nil {
-		return l.internal
+		return l.handler
 	}
 
 	fields, _ := ctx.Value(ctxKey).([]any)
 	if len(fields) == 0 {
-		return l.internal
+		return l.handler
 	}
 
-	return l.internal.With(fields...)
+	return slog.New(l.handler).With(fields...).Handler()
+}
+
+// log пишет запись с PC вызывающего, чтобы уровни пакетов определялись по месту вызова,
+// а не по pkg/log. skip — число дополнительных кадров между вызывающим и публичным методом.
+func (l *Logger) log(ctx context.Context, level slog.Level, msg string, skip int) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if !l.handler.Enabled(ctx, level) {
+		return
+	}
+
+	var pcs [1]uintptr
+	// runtime.Callers, log и публичный метод.
+	runtime.Callers(3+skip, pcs[:])
+
+	record := slog.NewRecord(time.Now(), level, msg, pcs[0])
+	_ = l.withContext(ctx).Handle(ctx, record)
+}
+
+func (l *Logger) DebugContext(ctx context.Context, msg string) {
+	l.log(ctx, slog.LevelDebug, msg, 0)
 }
 
 func (l *Logger) InfoContext(ctx context.Context, msg string) {
-	l.withContext(ctx).Info(msg)
+	l.log(ctx, slog.LevelInfo, msg, 0)
 }
 
 func (l *Logger) WarnContext(ctx context.Context, msg string) {
-	l.withContext(ctx).Warn(msg)
+	l.log(ctx, slog.LevelWarn, msg, 0)
 }
 
 func (l *Logger) ErrorContext(ctx context.Context, msg string) {
-	l.withContext(ctx).Error(msg)
+	l.log(ctx, slog.LevelError, msg, 0)
+}
+
+func (l *Logger) DebugfContext(ctx context.Context, format string, args ...any) {
+	l.log(ctx, slog.LevelDebug, fmt.Sprintf(format, args...), 0)
 }
 
 func (l *Logger) InfofContext(ctx context.Context, format string, args ...any) {
-	l.withContext(ctx).Info(fmt.Sprintf(format, args...))
+	l.log(ctx, slog.LevelInfo, fmt.Sprintf(format, args...), 0)
 }
 
 func (l *Logger) WarnfContext(ctx context.Context, format string, args ...any) {
-	l.withContext(ctx).Warn(fmt.Sprintf(format, args...))
+	l.log(ctx, slog.LevelWarn, fmt.Sprintf(format, args...), 0)
 }
 
 func (l *Logger) ErrorfContext(ctx context.Context, format string, args ...any) {
-	l.withContext(ctx).Error(fmt.Sprintf(format, args...))
+	l.log(ctx, slog.LevelError, fmt.Sprintf(format, args...), 0)
+}
+
+func (l *Logger) Debug(msg string) {
+	l.log(context.Background(), slog.LevelDebug, msg, 0)
 }
 
 func (l *Logger) Info(msg string) {
-	l.internal.Info(msg)
+	l.log(context.Background(), slog.LevelInfo, msg, 0)
 }
 
 func (l *Logger) Warn(msg string) {
-	l.internal.Warn(msg)
+	l.log(context.Background(), slog.LevelWarn, msg, 0)
 }
 
 func (l *Logger) Error(msg string) {
-	l.internal.Error(msg)
+	l.log(context.Background(), slog.LevelError, msg, 0)
+}
+
+func (l *Logger) Debugf(format string, args ...any) {
+	l.log(context.Background(), slog.LevelDebug, fmt.Sprintf(format, args...), 0)
 }
 
 func (l *Logger) Infof(format string, args ...any) {
-	l.internal.Info(fmt.Sprintf(format, args...))
+	l.log(context.Background(), slog.LevelInfo, fmt.Sprintf(format, args...), 0)
 }
 
 func (l *Logger) Warnf(format string, args ...any) {
-	l.internal.Warn(fmt.Sprintf(format, args...))
+	l.log(context.Background(), slog.LevelWarn, fmt.Sprintf(format, args...), 0)
 }
 
 func (l *Logger) Errorf(format string, args ...any) {
-	l.internal.Error(fmt.Sprintf(format, args...))
+	l.log(context.Background(), slog.LevelError, fmt.Sprintf(format, args...), 0)
+}
+
+func DebugContext(ctx context.Context, msg string) {
+	getGlobal().log(ctx, slog.LevelDebug, msg, 1)
 }
 
 func InfoContext(ctx context.Context, msg string) {
-	getGlobal().InfoContext(ctx, msg)
+	getGlobal().log(ctx, slog.LevelInfo, msg, 1)
 }
 
 func WarnContext(ctx context.Context, msg string) {
-	getGlobal().WarnContext(ctx, msg)
+	getGlobal().log(ctx, slog.LevelWarn, msg, 1)
 }
 
 func ErrorContext(ctx context.Context, msg string) {
-	getGlobal().ErrorContext(ctx, msg)
+	getGlobal().log(ctx, slog.LevelError, msg, 1)
+}
+
+func DebugfContext(ctx context.Context, format string, args ...any) {
+	getGlobal().log(ctx, slog.LevelDebug, fmt.Sprintf(format, args...), 1)
 }
 
 func InfofContext(ctx context.Context, format string, args ...any) {
-	getGlobal().InfofContext(ctx, format, args...)
+	getGlobal().log(ctx, slog.LevelInfo, fmt.Sprintf(format, args...), 1)
 }
 
 func WarnfContext(ctx context.Context, format string, args ...any) {
-	getGlobal().WarnfContext(ctx, format, args...)
+	getGlobal().log(ctx, slog.LevelWarn, fmt.Sprintf(format, args...), 1)
 }
 
 func ErrorfContext(ctx context.Context, format string, args ...any) {
-	getGlobal().ErrorfContext(ctx, format, args...)
+	getGlobal().log(ctx, slog.LevelError, fmt.Sprintf(format, args...), 1)
+}
+
+func Debug(msg string) {
+	getGlobal().log(context.Background(), slog.LevelDebug, msg, 1)
 }
 
 func Info(msg string) {
-	getGlobal().Info(msg)
+	getGlobal().log(context.Background(), slog.LevelInfo, msg, 1)
 }
 
 func Warn(msg string) {
-	getGlobal().Warn(msg)
+	getGlobal().log(context.Background(), slog.LevelWarn, msg, 1)
 }
 
 func Error(msg string) {
-	getGlobal().Error(msg)
+	getGlobal().log(context.Background(), slog.LevelError, msg, 1)
+}
+
+func Debugf(format string, args ...any) {
+	getGlobal().log(context.Background(), slog.LevelDebug, fmt.Sprintf(format, args...), 1)
 }
 
 func Infof(format string, args ...any) {
-	getGlobal().Infof(format, args...)
+	getGlobal().log(context.Background(), slog.LevelInfo, fmt.Sprintf(format, args...), 1)
 }
 
 func Warnf(format string, args ...any) {
-	getGlobal().Warnf(format, args...)
+	getGlobal().log(context.Background(), slog.LevelWarn, fmt.Sprintf(format, args...), 1)
 }
 
 func Errorf(format string, args ...any) {
-	getGlobal().Errorf(format, args...)
+	getGlobal().log(context.Background(), slog.LevelError, fmt.Sprintf(format, args...), 1)
 }
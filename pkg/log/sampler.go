@@ -0,0 +1,71 @@
+package log
+
+import (
+	"log/slog"
+	"sync"
+	"time"
+)
+
+const defaultSamplingTick = time.Second
+
+// Sampling ограничивает поток однотипных сообщений: в каждом интервале Tick пишутся
+// первые Initial записей одного уровня из одного места вызова, затем каждая Thereafter-я.
+// Текст не учитывается: сообщения с разными аргументами формата считаются одним потоком.
+// Initial <= 0 выключает сэмплирование. Записи уровня ERROR и выше и записи с контекстом
+// из Logger.WithoutSampling не сэмплируются.
+type Sampling struct {
+	Initial    int
+	Thereafter int
+	Tick       time.Duration
+}
+
+// samplingKey определяет поток записей. msg заполняется, только если место вызова неизвестно.
+type samplingKey struct {
+	level slog.Level
+	pc    uintptr
+	msg   string
+}
+
+type sampler struct {
+	cfg Sampling
+
+	mu          sync.Mutex
+	windowStart time.Time
+	counts      map[samplingKey]int
+}
+
+func newSampler(cfg Sampling) *sampler {
+	if cfg.Tick <= 0 {
+		cfg.Tick = defaultSamplingTick
+	}
+	return &sampler{
+		cfg:    cfg,
+		counts: make(map[samplingKey]int),
+	}
+}
+
+func (s *sampler) allow(record slog.Record) bool {
+	if s.cfg.Initial <= 0 || record.Level >= slog.LevelError {
+		return true
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if record.Time.Sub(s.windowStart) >= s.cfg.Tick {
+		s.windowStart = record.Time
+		clear(s.counts)
+	}
+
+	key := samplingKey{level: record.Level, pc: record.PC}
+	if record.PC == 0 {
+		key.msg = record.Message
+	}
+	s.counts[key]++
+	n := s.counts[key]
+
+	if n <= s.cfg.Initial {
+		return true
+	}
+	return s.cfg.Thereafter > 0 && (n-s.cfg.Initial)%s.cfg.Thereafter == 0
+}
@@ -0,0 +1,54 @@
+package log
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSamplerKeysOnCallSite(t *testing.T) {
+	var output bytes.Buffer
+	logger := Must(Options{
+		Output:   &output,
+		Sampling: Sampling{Initial: 2, Thereafter: 0, Tick: time.Hour},
+	})
+
+	for i := range 10 {
+		logger.InfofContext(context.Background(), "request %d failed", i)
+	}
+	logger.InfoContext(context.Background(), "other call site")
+
+	lines := strings.Split(strings.TrimSpace(output.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("got %d lines, want 2 sampled and 1 from another call site:\n%s", len(lines), output.String())
+	}
+	if !strings.Contains(lines[2], "other call site") {
+		t.Errorf("last line = %s, want the other call site", lines[2])
+	}
+}
+
+func TestSamplerSkipsContextWithoutSampling(t *testing.T) {
+	var output bytes.Buffer
+	logger := Must(Options{
+		Output:   &output,
+		Sampling: Sampling{Initial: 2, Thereafter: 0, Tick: time.Hour},
+	})
+
+	ctx := logger.WithoutSampling(context.Background())
+	for range 10 {
+		logger.InfoContext(logger.LogCtx(ctx, "status", 200), "http request")
+	}
+	for range 10 {
+		logger.InfoContext(context.Background(), "sampled")
+	}
+
+	got := strings.Count(output.String(), "http request")
+	if got != 10 {
+		t.Errorf("got %d unsampled lines, want 10:\n%s", got, output.String())
+	}
+	if sampled := strings.Count(output.String(), "sampled"); sampled != 2 {
+		t.Errorf("got %d sampled lines, want 2", sampled)
+	}
+}
@@ -2,40 +2,123 @@ package querier
 
 import (
 	"context"
+	"errors"
+	"sync"
 
 	"github.com/avito-tech/go-transaction-manager/pgxv5"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
+const instrumentationName = "service-pr-reviewer-assignment/pkg/querier"
+
 type Querier struct {
 	pool   *pgxpool.Pool
 	getter *pgxv5.CtxGetter
+	tracer trace.Tracer
 }
 
 func Must(pool *pgxpool.Pool, getter *pgxv5.CtxGetter) *Querier {
 	return &Querier{
 		pool:   pool,
 		getter: getter,
+		tracer: otel.Tracer(instrumentationName),
 	}
 }
 
 func (q *Querier) Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+	ctx, span := q.startSpan(ctx, "Exec", sql)
+	defer span.End()
+
 	executor := q.get(ctx)
-	return executor.Exec(ctx, sql, args...)
+	tag, err := executor.Exec(ctx, sql, args...)
+	if err != nil {
+		recordError(span, err)
+		return tag, err
+	}
+
+	span.SetAttributes(attribute.Int64("db.response.affected_rows", tag.RowsAffected()))
+	return tag, nil
 }
 
 func (q *Querier) Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+	ctx, span := q.startSpan(ctx, "Query", sql)
+
 	executor := q.get(ctx)
-	return executor.Query(ctx, sql, args...)
+	rows, err := executor.Query(ctx, sql, args...)
+	if err != nil {
+		recordError(span, err)
+		span.End()
+		return nil, err
+	}
+
+	return &tracedRows{Rows: rows, span: span}, nil
 }
 
 func (q *Querier) QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+	ctx, span := q.startSpan(ctx, "QueryRow", sql)
+
 	executor := q.get(ctx)
-	return executor.QueryRow(ctx, sql, args...)
+	return &tracedRow{Row: executor.QueryRow(ctx, sql, args...), span: span}
 }
 
 func (q *Querier) get(ctx context.Context) pgxv5.Tr {
 	return q.getter.DefaultTrOrDB(ctx, q.pool)
 }
+
+func (q *Querier) startSpan(ctx context.Context, operation, sql string) (context.Context, trace.Span) {
+	return q.tracer.Start(ctx, "db."+operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("db.system", "postgresql"),
+			attribute.String("db.operation", operation),
+			attribute.String("db.statement", sql),
+		),
+	)
+}
+
+func recordError(span trace.Span, err error) {
+	if errors.Is(err, pgx.ErrNoRows) {
+		return
+	}
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
+
+// tracedRows завершает span при закрытии результата запроса.
+type tracedRows struct {
+	pgx.Rows
+	span trace.Span
+	once sync.Once
+}
+
+func (r *tracedRows) Close() {
+	r.Rows.Close()
+	r.once.Do(func() {
+		if err := r.Rows.Err(); err != nil {
+			recordError(r.span, err)
+		}
+		r.span.End()
+	})
+}
+
+// tracedRow завершает span после чтения строки.
+type tracedRow struct {
+	pgx.Row
+	span trace.Span
+}
+
+func (r *tracedRow) Scan(dest ...any) error {
+	defer r.span.End()
+
+	err := r.Row.Scan(dest...)
+	if err != nil {
+		recordError(r.span, err)
+	}
+	return err
+}
@@ -2,27 +2,85 @@ package tx
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net"
+	"sync/atomic"
+	"time"
 
 	"github.com/avito-tech/go-transaction-manager/pgxv5"
+	"github.com/avito-tech/go-transaction-manager/trm"
+	trmcontext "github.com/avito-tech/go-transaction-manager/trm/context"
 	"github.com/avito-tech/go-transaction-manager/trm/manager"
 	"github.com/avito-tech/go-transaction-manager/trm/settings"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
+const (
+	maxAttempts = 5
+	baseDelay   = 20 * time.Millisecond
+	maxDelay    = 500 * time.Millisecond
+)
+
+const instrumentationName = "service-pr-reviewer-assignment/pkg/tx"
+
+const (
+	serializationFailure = "40001"
+	deadlockDetected     = "40P01"
+)
+
+type Logger interface {
+	WarnfContext(ctx context.Context, format string, args ...any)
+	LogCtx(ctx context.Context, fields ...any) context.Context
+}
+
+// Stats счётчики транзакций с момента создания менеджера.
+type Stats struct {
+	// Retries количество повторных попыток после ошибок сериализации и дедлоков.
+	Retries uint64 `json:"retries"`
+
+	// Failures количество транзакций, завершившихся ошибкой базы данных или соединения,
+	// в том числе после исчерпания повторов. Доменные ошибки (не найдено, конфликт,
+	// нет прав), которыми fn прерывает транзакцию, не учитываются.
+	Failures uint64 `json:"failures"`
+}
+
 // Manager инкапсулирует логику управления транзакциями.
 type Manager struct {
 	internal *manager.Manager
+	logger   Logger
+	tracer   trace.Tracer
+
+	retries  atomic.Uint64
+	failures atomic.Uint64
 }
 
 // Must создаёт новый менеджер транзакций.
-func Must(db pgxv5.Transactional) *Manager {
+func Must(db pgxv5.Transactional, logger Logger) *Manager {
 	return &Manager{
 		internal: manager.Must(pgxv5.NewDefaultFactory(db)),
+		logger:   logger,
+		tracer:   otel.Tracer(instrumentationName),
+	}
+}
+
+// Stats возвращает текущие значения счётчиков.
+func (m *Manager) Stats() Stats {
+	return Stats{
+		Retries:  m.retries.Load(),
+		Failures: m.failures.Load(),
 	}
 }
 
 func (m *Manager) execWithIsoLevel(
 	ctx context.Context,
+	name string,
 	level pgx.TxIsoLevel,
 	fn func(ctx context.Context) error,
 ) error {
@@ -30,13 +88,131 @@ func (m *Manager) execWithIsoLevel(
 		settings.Must(),
 		pgxv5.WithTxOptions(pgx.TxOptions{IsoLevel: level}),
 	)
-	return m.internal.DoWithSettings(ctx, settings, fn)
+
+	ctx, span := m.tracer.Start(ctx, "tx."+name, trace.WithAttributes(
+		attribute.String("db.transaction.isolation_level", string(level)),
+	))
+	defer span.End()
+
+	// Вложенная транзакция не может быть повторена отдельно от внешней,
+	// поэтому повтор выполняет только самый внешний вызов.
+	if trmcontext.DefaultManager.Default(ctx) != nil {
+		span.SetAttributes(attribute.Bool("db.transaction.nested", true))
+		err := m.internal.DoWithSettings(ctx, settings, fn)
+		if err != nil {
+			recordError(span, err)
+		}
+		return err
+	}
+
+	attempts, err := m.retry(ctx, func(ctx context.Context) error {
+		return m.internal.DoWithSettings(ctx, settings, fn)
+	})
+	span.SetAttributes(attribute.Int("db.transaction.attempts", attempts))
+	if err != nil {
+		if isInfrastructureError(err) {
+			m.failures.Add(1)
+		}
+		recordError(span, err)
+		return err
+	}
+
+	return nil
+}
+
+// isInfrastructureError отличает сбой базы данных, соединения или самой транзакции
+// от доменной ошибки, которую вернул fn: такие ошибки хранилище возвращает без
+// обёртки над ошибкой драйвера.
+func isInfrastructureError(err error) bool {
+	var pgErr *pgconn.PgError
+	var netErr net.Error
+	switch {
+	case errors.Is(err, trm.ErrTransaction):
+		return true
+	case errors.As(err, &pgErr):
+		return true
+	case errors.As(err, &netErr):
+		return true
+	case errors.Is(err, context.DeadlineExceeded), pgconn.Timeout(err):
+		return true
+	case errors.Is(err, pgx.ErrTxClosed), errors.Is(err, pgx.ErrTxCommitRollback):
+		return true
+	default:
+		return false
+	}
+}
+
+func recordError(span trace.Span, err error) {
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
+
+func (m *Manager) retry(ctx context.Context, fn func(ctx context.Context) error) (int, error) {
+	attemptCtx := ctx
+	for attempt := 1; ; attempt++ {
+		err := fn(attemptCtx)
+		if err == nil {
+			return attempt, nil
+		}
+
+		code, ok := retryableCode(err)
+		if !ok || attempt == maxAttempts {
+			return attempt, err
+		}
+
+		delay := backoff(attempt)
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+			return attempt, err
+		}
+
+		m.retries.Add(1)
+		trace.SpanFromContext(ctx).AddEvent("retry", trace.WithAttributes(
+			attribute.Int("db.transaction.attempt", attempt+1),
+			attribute.String("db.response.status_code", code),
+		))
+		attemptCtx = m.logger.LogCtx(ctx, "tx_attempt", attempt+1)
+		m.logger.WarnfContext(
+			m.logger.LogCtx(attemptCtx, "tx_retry_reason", code),
+			"retrying transaction after %s: %v", delay, err,
+		)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return attempt, fmt.Errorf("wait for retry: %w", errors.Join(ctx.Err(), err))
+		case <-timer.C:
+		}
+	}
+}
+
+func retryableCode(err error) (string, bool) {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return "", false
+	}
+
+	switch pgErr.Code {
+	case serializationFailure, deadlockDetected:
+		return pgErr.Code, true
+	default:
+		return "", false
+	}
+}
+
+// backoff возвращает экспоненциальную задержку с полным джиттером.
+func backoff(attempt int) time.Duration {
+	ceiling := baseDelay << (attempt - 1)
+	if ceiling > maxDelay {
+		ceiling = maxDelay
+	}
+	return time.Duration(rand.Int63n(int64(ceiling))) + time.Millisecond
 }
 
 func (m *Manager) Write(ctx context.Context, fn func(ctx context.Context) error) error {
-	return m.execWithIsoLevel(ctx, pgx.Serializable, fn)
+	return m.execWithIsoLevel(ctx, "Write", pgx.Serializable, fn)
 }
 
 func (m *Manager) Read(ctx context.Context, fn func(ctx context.Context) error) error {
-	return m.execWithIsoLevel(ctx, pgx.RepeatableRead, fn)
+	return m.execWithIsoLevel(ctx, "Read", pgx.RepeatableRead, fn)
 }
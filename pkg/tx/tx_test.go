@@ -0,0 +1,196 @@
+package tx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/avito-tech/go-transaction-manager/trm"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+type domainError struct{}
+
+func (domainError) Error() string { return "team not found" }
+
+func TestIsInfrastructureError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"domain error", &domainError{}, false},
+		{"wrapped domain error", fmt.Errorf("get team: %w", &domainError{}), false},
+		{"client canceled", context.Canceled, false},
+		{"serialization failure", &pgconn.PgError{Code: serializationFailure}, true},
+		{"wrapped query error", fmt.Errorf("get team: %w", &pgconn.PgError{Code: "42P01"}), true},
+		{"commit failed", fmt.Errorf("%w: %w", trm.ErrCommit, errors.New("conn closed")), true},
+		{"deadline exceeded", fmt.Errorf("query: %w", context.DeadlineExceeded), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isInfrastructureError(tt.err); got != tt.want {
+				t.Errorf("isInfrastructureError(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+type noopLogger struct{}
+
+func (noopLogger) WarnfContext(context.Context, string, ...any) {}
+
+func (noopLogger) LogCtx(ctx context.Context, _ ...any) context.Context { return ctx }
+
+// fakeDB открывает транзакции, которые ничего не делают: fn теста сам решает,
+// завершится ли попытка ошибкой.
+type fakeDB struct {
+	begins int
+}
+
+func (db *fakeDB) BeginTx(context.Context, pgx.TxOptions) (pgx.Tx, error) {
+	db.begins++
+	return fakeTx{}, nil
+}
+
+type fakeTx struct {
+	pgx.Tx
+}
+
+func (fakeTx) Commit(context.Context) error { return nil }
+
+func (fakeTx) Rollback(context.Context) error { return nil }
+
+func TestRetry(t *testing.T) {
+	tests := []struct {
+		name         string
+		errs         []error
+		wantAttempts int
+		wantErr      bool
+		wantStats    Stats
+	}{
+		{
+			name:         "serialization failures exhaust attempts",
+			errs:         repeat(&pgconn.PgError{Code: serializationFailure}, maxAttempts),
+			wantAttempts: maxAttempts,
+			wantErr:      true,
+			wantStats:    Stats{Retries: maxAttempts - 1, Failures: 1},
+		},
+		{
+			name:         "deadlock then success",
+			errs:         []error{&pgconn.PgError{Code: deadlockDetected}, &pgconn.PgError{Code: deadlockDetected}, nil},
+			wantAttempts: 3,
+			wantStats:    Stats{Retries: 2},
+		},
+		{
+			name:         "other database error",
+			errs:         []error{&pgconn.PgError{Code: "23505"}},
+			wantAttempts: 1,
+			wantErr:      true,
+			wantStats:    Stats{Failures: 1},
+		},
+		{
+			name:         "domain error",
+			errs:         []error{&domainError{}},
+			wantAttempts: 1,
+			wantErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := &fakeDB{}
+			m := Must(db, noopLogger{})
+
+			attempts := 0
+			err := m.Write(context.Background(), func(context.Context) error {
+				attempts++
+				return tt.errs[attempts-1]
+			})
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, want error %v", err, tt.wantErr)
+			}
+			if attempts != tt.wantAttempts || db.begins != tt.wantAttempts {
+				t.Errorf("attempts = %d, begins = %d, want %d", attempts, db.begins, tt.wantAttempts)
+			}
+			if got := m.Stats(); got != tt.wantStats {
+				t.Errorf("stats = %+v, want %+v", got, tt.wantStats)
+			}
+		})
+	}
+}
+
+func TestRetryNestedTransaction(t *testing.T) {
+	m := Must(&fakeDB{}, noopLogger{})
+
+	var outerAttempts, innerAttempts int
+	var innerErr error
+	err := m.Write(context.Background(), func(ctx context.Context) error {
+		outerAttempts++
+		innerErr = m.Read(ctx, func(context.Context) error {
+			innerAttempts++
+			return &pgconn.PgError{Code: serializationFailure}
+		})
+		return nil
+	})
+
+	if err != nil {
+		t.Fatalf("outer transaction: %v", err)
+	}
+	if innerErr == nil || outerAttempts != 1 || innerAttempts != 1 {
+		t.Errorf("inner err = %v, outer attempts = %d, inner attempts = %d, want one attempt each and an error",
+			innerErr, outerAttempts, innerAttempts)
+	}
+	if got := m.Stats(); got.Retries != 0 {
+		t.Errorf("retries = %d, want 0", got.Retries)
+	}
+}
+
+func TestRetryStopsBeforeDeadline(t *testing.T) {
+	m := Must(&fakeDB{}, noopLogger{})
+
+	// Задержка перед повтором не меньше миллисекунды, поэтому до дедлайна повтор не успеет.
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+
+	attempts := 0
+	err := m.Write(ctx, func(context.Context) error {
+		attempts++
+		return &pgconn.PgError{Code: serializationFailure}
+	})
+
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) || pgErr.Code != serializationFailure {
+		t.Fatalf("err = %v, want the serialization failure", err)
+	}
+	if attempts != 1 {
+		t.Errorf("attempts = %d, want 1", attempts)
+	}
+	if got := m.Stats(); got != (Stats{Failures: 1}) {
+		t.Errorf("stats = %+v, want one failure without retries", got)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	for attempt := 1; attempt <= 10; attempt++ {
+		ceiling := min(baseDelay<<(attempt-1), maxDelay)
+		for range 100 {
+			if delay := backoff(attempt); delay < time.Millisecond || delay > ceiling+time.Millisecond {
+				t.Fatalf("backoff(%d) = %s, want within [1ms, %s]", attempt, delay, ceiling+time.Millisecond)
+			}
+		}
+	}
+}
+
+func repeat(err error, n int) []error {
+	errs := make([]error, n)
+	for i := range errs {
+		errs[i] = err
+	}
+	return errs
+}